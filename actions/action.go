@@ -0,0 +1,121 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	EventTypePreCommit = "pre-commit"
+	EventTypePreMerge  = "pre-merge"
+
+	// ActionsPrefix is the reserved path under which action files are stored in a branch
+	ActionsPrefix = "_lakefs_actions/"
+)
+
+var (
+	ErrInvalidAction    = errors.New("invalid action")
+	ErrActionFailed     = errors.New("action failed")
+	ErrUnknownHookType  = errors.New("unknown hook type")
+	ErrMissingHookParam = errors.New("missing hook property")
+
+	reHookID = regexp.MustCompile(`^[_a-zA-Z][\-_a-zA-Z0-9]{1,255}$`)
+)
+
+// ActionOn configures an event an action runs on.  The action runs on all branches if no
+// branches are given.
+type ActionOn struct {
+	Branches []string `yaml:"branches"`
+}
+
+// ActionHook is a single hook of an action
+type ActionHook struct {
+	ID          string                 `yaml:"id"`
+	Type        string                 `yaml:"type"`
+	Description string                 `yaml:"description"`
+	Properties  map[string]interface{} `yaml:"properties"`
+}
+
+// Action is a set of hooks that run on some events of some branches.  Actions are read from
+// YAML files stored in the repository under ActionsPrefix.  On is keyed by event type, an event
+// given without configuration runs on all branches.
+type Action struct {
+	Name        string               `yaml:"name"`
+	Description string               `yaml:"description"`
+	On          map[string]*ActionOn `yaml:"on"`
+	Hooks       []ActionHook         `yaml:"hooks"`
+}
+
+// ParseAction parses and validates the YAML definition of an action
+func ParseAction(data []byte) (*Action, error) {
+	var action Action
+	if err := yaml.UnmarshalStrict(data, &action); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAction, err)
+	}
+	if err := action.Validate(); err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
+
+// Validate checks that the action has a name, at least one event with valid branch patterns,
+// and hooks with unique valid ids and known types
+func (a *Action) Validate() error {
+	if a.Name == "" {
+		return fmt.Errorf("%w: missing name", ErrInvalidAction)
+	}
+	if len(a.On) == 0 {
+		return fmt.Errorf("%w: %s: no events", ErrInvalidAction, a.Name)
+	}
+	for eventType, on := range a.On {
+		if eventType != EventTypePreCommit && eventType != EventTypePreMerge {
+			return fmt.Errorf("%w: %s: unknown event %s", ErrInvalidAction, a.Name, eventType)
+		}
+		if on == nil {
+			continue
+		}
+		for _, pattern := range on.Branches {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("%w: %s: branch pattern %s: %s", ErrInvalidAction, a.Name, pattern, err)
+			}
+		}
+	}
+	if len(a.Hooks) == 0 {
+		return fmt.Errorf("%w: %s: no hooks", ErrInvalidAction, a.Name)
+	}
+	ids := make(map[string]struct{}, len(a.Hooks))
+	for _, hook := range a.Hooks {
+		if !reHookID.MatchString(hook.ID) {
+			return fmt.Errorf("%w: %s: invalid hook id '%s'", ErrInvalidAction, a.Name, hook.ID)
+		}
+		if _, ok := ids[hook.ID]; ok {
+			return fmt.Errorf("%w: %s: duplicate hook id '%s'", ErrInvalidAction, a.Name, hook.ID)
+		}
+		ids[hook.ID] = struct{}{}
+		if _, ok := hookFactories[hook.Type]; !ok {
+			return fmt.Errorf("%w: %s: hook %s: %s", ErrUnknownHookType, a.Name, hook.ID, hook.Type)
+		}
+	}
+	return nil
+}
+
+// Match returns true if the action runs on eventType of branch
+func (a *Action) Match(eventType, branch string) bool {
+	on, ok := a.On[eventType]
+	if !ok {
+		return false
+	}
+	if on == nil || len(on.Branches) == 0 {
+		return true
+	}
+	for _, pattern := range on.Branches {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package actions_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/actions"
+)
+
+const goodAction = `
+name: good files check
+description: check the files going into main
+on:
+  pre-commit:
+    branches:
+      - main
+      - release-*
+  pre-merge:
+hooks:
+  - id: no_temp
+    type: no_files_under_prefix
+    properties:
+      prefix: tmp/
+  - id: has_schema
+    type: file_exists
+    properties:
+      path: schema.json
+`
+
+func TestParseAction(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr error
+	}{
+		{name: "good", data: goodAction},
+		{name: "empty", data: "", expectedErr: actions.ErrInvalidAction},
+		{name: "bad_yaml", data: "name: [", expectedErr: actions.ErrInvalidAction},
+		{name: "unknown_field", data: goodAction + "runs: always\n", expectedErr: actions.ErrInvalidAction},
+		{
+			name:        "no_events",
+			data:        "name: n\nhooks:\n  - id: h1\n    type: file_exists\n",
+			expectedErr: actions.ErrInvalidAction,
+		},
+		{
+			name:        "no_hooks",
+			data:        "name: n\non:\n  pre-commit:\n",
+			expectedErr: actions.ErrInvalidAction,
+		},
+		{
+			name:        "bad_branch_pattern",
+			data:        "name: n\non:\n  pre-commit:\n    branches: ['[']\nhooks:\n  - id: h1\n    type: file_exists\n",
+			expectedErr: actions.ErrInvalidAction,
+		},
+		{
+			name:        "duplicate_hook_id",
+			data:        "name: n\non:\n  pre-commit:\nhooks:\n  - id: h1\n    type: file_exists\n  - id: h1\n    type: webhook\n",
+			expectedErr: actions.ErrInvalidAction,
+		},
+		{
+			name:        "unknown_hook_type",
+			data:        "name: n\non:\n  pre-commit:\nhooks:\n  - id: h1\n    type: shell\n",
+			expectedErr: actions.ErrUnknownHookType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, err := actions.ParseAction([]byte(tt.data))
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("ParseAction() error = %v, expected %v", err, tt.expectedErr)
+			}
+			if err == nil && len(action.Hooks) != 2 {
+				t.Errorf("ParseAction() got %d hooks, expected 2", len(action.Hooks))
+			}
+		})
+	}
+}
+
+func TestAction_Match(t *testing.T) {
+	action, err := actions.ParseAction([]byte(goodAction))
+	if err != nil {
+		t.Fatalf("ParseAction: %s", err)
+	}
+	tests := []struct {
+		eventType string
+		branch    string
+		expected  bool
+	}{
+		{eventType: actions.EventTypePreCommit, branch: "main", expected: true},
+		{eventType: actions.EventTypePreCommit, branch: "release-1", expected: true},
+		{eventType: actions.EventTypePreCommit, branch: "feature", expected: false},
+		{eventType: actions.EventTypePreMerge, branch: "feature", expected: true},
+		{eventType: "post-commit", branch: "main", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.eventType+"_"+tt.branch, func(t *testing.T) {
+			if got := action.Match(tt.eventType, tt.branch); got != tt.expected {
+				t.Errorf("Match() = %t, expected %t", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/hooks"
+	"github.com/treeverse/lakefs/hooks/params"
+)
+
+const (
+	HookTypeWebhook            = "webhook"
+	HookTypeFileExists         = "file_exists"
+	HookTypeNoFilesUnderPrefix = "no_files_under_prefix"
+)
+
+var ErrHookCheckFailed = errors.New("hook check failed")
+
+// Event is the catalog operation that triggered a run of actions
+type Event struct {
+	Type                 string
+	Repository           string
+	Branch               string
+	SourceRef            string
+	Committer            string
+	Message              string
+	Metadata             catalog.Metadata
+	Differences          catalog.Differences
+	DifferencesTruncated bool
+}
+
+// Ref returns the reference holding the data the operation brings into the branch: the branch
+// itself for a commit and the source reference for a merge.  Action files are read from it
+// and built-in checks run against it.
+func (e *Event) Ref() string {
+	if e.Type == EventTypePreMerge {
+		return e.SourceRef
+	}
+	return e.Branch
+}
+
+// Hook is a single step of an action.  Run writes the hook output to log and returns an error
+// to fail the hook, which fails the action and rejects the operation.
+type Hook interface {
+	Run(ctx context.Context, event *Event, log io.Writer) error
+}
+
+// HookDependencies are what hooks use to check and report on an event
+type HookDependencies struct {
+	Cataloger catalog.Cataloger
+	// WebhookPolicy restricts the endpoints webhook hooks may call
+	WebhookPolicy hooks.TargetPolicy
+}
+
+type hookFactory func(action *Action, h ActionHook, deps HookDependencies) (Hook, error)
+
+var hookFactories = map[string]hookFactory{
+	HookTypeWebhook:            newWebhookHook,
+	HookTypeFileExists:         newFileExistsHook,
+	HookTypeNoFilesUnderPrefix: newNoFilesUnderPrefixHook,
+}
+
+// NewHook returns the hook h of action
+func NewHook(action *Action, h ActionHook, deps HookDependencies) (Hook, error) {
+	factory, ok := hookFactories[h.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownHookType, h.Type)
+	}
+	return factory(action, h, deps)
+}
+
+func stringProperty(h ActionHook, name string) (string, error) {
+	v, ok := h.Properties[name]
+	if !ok {
+		return "", fmt.Errorf("%w: hook %s: %s", ErrMissingHookParam, h.ID, name)
+	}
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return "", fmt.Errorf("%w: hook %s: %s must be a non-empty string", ErrInvalidAction, h.ID, name)
+	}
+	return s, nil
+}
+
+// webhookHook POSTs the event to a URL allowed by the webhook policy, see hooks.Webhook.  Only
+// the status code of a rejecting response is logged.
+type webhookHook struct {
+	url     string
+	webhook *hooks.Webhook
+}
+
+func newWebhookHook(action *Action, h ActionHook, deps HookDependencies) (Hook, error) {
+	url, err := stringProperty(h, "url")
+	if err != nil {
+		return nil, err
+	}
+	p := params.Webhook{
+		Name: action.Name + "/" + h.ID,
+		URL:  url,
+	}
+	if _, ok := h.Properties["timeout"]; ok {
+		timeout, err := stringProperty(h, "timeout")
+		if err != nil {
+			return nil, err
+		}
+		p.Timeout, err = time.ParseDuration(timeout)
+		if err != nil {
+			return nil, fmt.Errorf("%w: hook %s: timeout: %s", ErrInvalidAction, h.ID, err)
+		}
+	}
+	webhook, err := hooks.NewRestrictedWebhook(p, deps.WebhookPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return &webhookHook{url: url, webhook: webhook}, nil
+}
+
+func (w *webhookHook) Run(ctx context.Context, event *Event, log io.Writer) error {
+	_, _ = fmt.Fprintf(log, "POST %s\n", w.url)
+	var err error
+	switch event.Type {
+	case EventTypePreCommit:
+		err = w.webhook.PreCommit(ctx, event.Repository, event.Branch, catalog.PreCommitEvent{
+			Committer:            event.Committer,
+			Message:              event.Message,
+			Metadata:             event.Metadata,
+			Differences:          event.Differences,
+			DifferencesTruncated: event.DifferencesTruncated,
+		})
+	case EventTypePreMerge:
+		err = w.webhook.PreMerge(ctx, event.Repository, event.Branch, catalog.PreMergeEvent{
+			SourceRef:            event.SourceRef,
+			Committer:            event.Committer,
+			Message:              event.Message,
+			Metadata:             event.Metadata,
+			Differences:          event.Differences,
+			DifferencesTruncated: event.DifferencesTruncated,
+		})
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(log, "%s\n", err)
+		return err
+	}
+	_, _ = fmt.Fprintln(log, "webhook allowed event")
+	return nil
+}
+
+// fileExistsHook fails unless an object exists at path
+type fileExistsHook struct {
+	path      string
+	cataloger catalog.Cataloger
+}
+
+func newFileExistsHook(_ *Action, h ActionHook, deps HookDependencies) (Hook, error) {
+	p, err := stringProperty(h, "path")
+	if err != nil {
+		return nil, err
+	}
+	return &fileExistsHook{path: p, cataloger: deps.Cataloger}, nil
+}
+
+func (f *fileExistsHook) Run(ctx context.Context, event *Event, log io.Writer) error {
+	_, err := f.cataloger.GetEntry(ctx, event.Repository, event.Ref(), f.path, catalog.GetEntryParams{})
+	if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
+		_, _ = fmt.Fprintf(log, "file %s does not exist on %s\n", f.path, event.Ref())
+		return fmt.Errorf("%w: file %s does not exist", ErrHookCheckFailed, f.path)
+	}
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(log, "file %s exists on %s\n", f.path, event.Ref())
+	return nil
+}
+
+// noFilesUnderPrefixHook fails if any object exists under prefix
+type noFilesUnderPrefixHook struct {
+	prefix    string
+	cataloger catalog.Cataloger
+}
+
+func newNoFilesUnderPrefixHook(_ *Action, h ActionHook, deps HookDependencies) (Hook, error) {
+	prefix, err := stringProperty(h, "prefix")
+	if err != nil {
+		return nil, err
+	}
+	return &noFilesUnderPrefixHook{prefix: prefix, cataloger: deps.Cataloger}, nil
+}
+
+func (n *noFilesUnderPrefixHook) Run(ctx context.Context, event *Event, log io.Writer) error {
+	entries, _, err := n.cataloger.ListEntries(ctx, event.Repository, event.Ref(), n.prefix, "", "", 1)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		_, _ = fmt.Fprintf(log, "found file %s under prefix %s on %s\n", entries[0].Path, n.prefix, event.Ref())
+		return fmt.Errorf("%w: found file %s under prefix %s", ErrHookCheckFailed, entries[0].Path, n.prefix)
+	}
+	_, _ = fmt.Fprintf(log, "no files under prefix %s on %s\n", n.prefix, event.Ref())
+	return nil
+}
+
+// limitedWriter keeps up to limit bytes written to it and drops the rest
+type limitedWriter struct {
+	sb        strings.Builder
+	limit     int
+	truncated bool
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if left := w.limit - w.sb.Len(); n > left {
+		p = p[:left]
+		w.truncated = true
+	}
+	w.sb.Write(p)
+	return n, nil
+}
+
+func (w *limitedWriter) String() string {
+	if w.truncated {
+		return w.sb.String() + "\n[log truncated]\n"
+	}
+	return w.sb.String()
+}
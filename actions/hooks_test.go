@@ -0,0 +1,167 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/treeverse/lakefs/actions"
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/hooks"
+)
+
+type catalogerFake struct {
+	catalog.Cataloger
+	paths []string
+}
+
+func (c *catalogerFake) GetEntry(_ context.Context, _, _ string, path string, _ catalog.GetEntryParams) (*catalog.Entry, error) {
+	for _, p := range c.paths {
+		if p == path {
+			return &catalog.Entry{Path: p}, nil
+		}
+	}
+	return nil, catalog.ErrEntryNotFound
+}
+
+func (c *catalogerFake) ListEntries(_ context.Context, _, _ string, prefix, _ string, _ string, limit int) ([]*catalog.Entry, bool, error) {
+	var entries []*catalog.Entry
+	for _, p := range c.paths {
+		if strings.HasPrefix(p, prefix) && len(entries) < limit {
+			entries = append(entries, &catalog.Entry{Path: p})
+		}
+	}
+	return entries, false, nil
+}
+
+func TestHooks_Run(t *testing.T) {
+	const rejectBody = "internal response body"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/reject" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(rejectBody))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	deps := actions.HookDependencies{
+		Cataloger: &catalogerFake{paths: []string{"schema.json", "tables/t1/part-0"}},
+		// the test server listens on loopback
+		WebhookPolicy: hooks.TargetPolicy{AllowedHosts: []string{"127.0.0.1"}, AllowPrivateAddresses: true},
+	}
+	action := &actions.Action{Name: "check"}
+	tests := []struct {
+		name        string
+		hook        actions.ActionHook
+		expectedErr error
+	}{
+		{
+			name: "file_exists",
+			hook: actions.ActionHook{ID: "h", Type: actions.HookTypeFileExists, Properties: map[string]interface{}{"path": "schema.json"}},
+		},
+		{
+			name:        "file_missing",
+			hook:        actions.ActionHook{ID: "h", Type: actions.HookTypeFileExists, Properties: map[string]interface{}{"path": "README"}},
+			expectedErr: actions.ErrHookCheckFailed,
+		},
+		{
+			name: "no_files_under_prefix",
+			hook: actions.ActionHook{ID: "h", Type: actions.HookTypeNoFilesUnderPrefix, Properties: map[string]interface{}{"prefix": "tmp/"}},
+		},
+		{
+			name:        "files_under_prefix",
+			hook:        actions.ActionHook{ID: "h", Type: actions.HookTypeNoFilesUnderPrefix, Properties: map[string]interface{}{"prefix": "tables/"}},
+			expectedErr: actions.ErrHookCheckFailed,
+		},
+		{
+			name: "webhook_allows",
+			hook: actions.ActionHook{ID: "h", Type: actions.HookTypeWebhook, Properties: map[string]interface{}{"url": server.URL + "/allow", "timeout": "10s"}},
+		},
+		{
+			name:        "webhook_rejects",
+			hook:        actions.ActionHook{ID: "h", Type: actions.HookTypeWebhook, Properties: map[string]interface{}{"url": server.URL + "/reject"}},
+			expectedErr: hooks.ErrWebhookRejected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook, err := actions.NewHook(action, tt.hook, deps)
+			if err != nil {
+				t.Fatalf("NewHook: %s", err)
+			}
+			var log strings.Builder
+			err = hook.Run(context.Background(), &actions.Event{
+				Type:       actions.EventTypePreCommit,
+				Repository: "repo",
+				Branch:     "main",
+			}, &log)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Run() error = %v, expected %v", err, tt.expectedErr)
+			}
+			if log.Len() == 0 {
+				t.Error("Run() wrote no log")
+			}
+			if strings.Contains(log.String(), rejectBody) {
+				t.Errorf("Run() logged the webhook response body: %s", log.String())
+			}
+		})
+	}
+}
+
+func TestHooks_WebhookNotAllowed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	action := &actions.Action{Name: "check"}
+	hook := actions.ActionHook{ID: "h", Type: actions.HookTypeWebhook, Properties: map[string]interface{}{"url": server.URL}}
+
+	t.Run("host_not_allowed", func(t *testing.T) {
+		deps := actions.HookDependencies{WebhookPolicy: hooks.TargetPolicy{AllowedHosts: []string{"hooks.example.com"}}}
+		_, err := actions.NewHook(action, hook, deps)
+		if !errors.Is(err, hooks.ErrWebhookNotAllowed) {
+			t.Errorf("NewHook() error = %v, expected %v", err, hooks.ErrWebhookNotAllowed)
+		}
+	})
+
+	t.Run("no_allowed_hosts", func(t *testing.T) {
+		_, err := actions.NewHook(action, hook, actions.HookDependencies{})
+		if !errors.Is(err, hooks.ErrWebhookNotAllowed) {
+			t.Errorf("NewHook() error = %v, expected %v", err, hooks.ErrWebhookNotAllowed)
+		}
+	})
+
+	t.Run("private_address", func(t *testing.T) {
+		deps := actions.HookDependencies{WebhookPolicy: hooks.TargetPolicy{AllowedHosts: []string{"127.0.0.1"}}}
+		h, err := actions.NewHook(action, hook, deps)
+		if err != nil {
+			t.Fatalf("NewHook: %s", err)
+		}
+		var log strings.Builder
+		err = h.Run(context.Background(), &actions.Event{
+			Type:       actions.EventTypePreCommit,
+			Repository: "repo",
+			Branch:     "main",
+		}, &log)
+		if !errors.Is(err, hooks.ErrWebhookNotAllowed) {
+			t.Errorf("Run() error = %v, expected %v", err, hooks.ErrWebhookNotAllowed)
+		}
+	})
+}
+
+func TestNewHook_MissingProperty(t *testing.T) {
+	for _, hookType := range []string{actions.HookTypeWebhook, actions.HookTypeFileExists, actions.HookTypeNoFilesUnderPrefix} {
+		t.Run(hookType, func(t *testing.T) {
+			_, err := actions.NewHook(&actions.Action{Name: "check"}, actions.ActionHook{ID: "h", Type: hookType}, actions.HookDependencies{Cataloger: &catalogerFake{}})
+			if !errors.Is(err, actions.ErrMissingHookParam) {
+				t.Errorf("NewHook() error = %v, expected %v", err, actions.ErrMissingHookParam)
+			}
+		})
+	}
+}
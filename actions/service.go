@@ -0,0 +1,318 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/hooks"
+	"github.com/treeverse/lakefs/logging"
+)
+
+const (
+	// maxActionFiles is the maximal number of action files read from a reference
+	maxActionFiles = 1000
+	// maxActionFileSize is the maximal size of a single action file
+	maxActionFileSize = 1024 * 1024
+	// maxHookLogSize is the maximal number of bytes of hook output kept with the run
+	maxHookLogSize = 64 * 1024
+
+	ListRunsMaxAmount = 1000
+)
+
+var ErrRunNotFound = fmt.Errorf("actions run %w", db.ErrNotFound)
+
+// RunResult is a single run of the actions that matched a catalog operation
+type RunResult struct {
+	RunID     string    `db:"run_id"`
+	EventType string    `db:"event_type"`
+	Branch    string    `db:"branch"`
+	SourceRef string    `db:"source_ref"`
+	Committer string    `db:"committer"`
+	Message   string    `db:"commit_message"`
+	StartTime time.Time `db:"start_time"`
+	EndTime   time.Time `db:"end_time"`
+	Passed    bool      `db:"passed"`
+}
+
+// HookRunResult is the run of a single hook of an action during a run
+type HookRunResult struct {
+	HookRunID  int       `db:"hook_run_id"`
+	ActionName string    `db:"action_name"`
+	HookID     string    `db:"hook_id"`
+	StartTime  time.Time `db:"start_time"`
+	EndTime    time.Time `db:"end_time"`
+	Passed     bool      `db:"passed"`
+	Log        string    `db:"log"`
+}
+
+// Service runs the actions stored in a repository on catalog operations, and keeps the results
+// of its runs.  Register it on the cataloger hooks to run actions before commits and merges.
+type Service struct {
+	db            db.Database
+	cataloger     catalog.Cataloger
+	blockAdapter  block.Adapter
+	webhookPolicy hooks.TargetPolicy
+}
+
+// NewService returns a service whose webhook hooks may only call the endpoints allowed by
+// webhookPolicy.
+func NewService(db db.Database, cataloger catalog.Cataloger, blockAdapter block.Adapter, webhookPolicy hooks.TargetPolicy) *Service {
+	return &Service{
+		db:            db,
+		cataloger:     cataloger,
+		blockAdapter:  blockAdapter,
+		webhookPolicy: webhookPolicy,
+	}
+}
+
+// Register adds the service to catalogerHooks
+func (s *Service) Register(catalogerHooks *catalog.CatalogerHooks) {
+	catalogerHooks.AddPreCommit(s.PreCommit)
+	catalogerHooks.AddPreMerge(s.PreMerge)
+}
+
+// PreCommit is a catalog PreCommitFunc
+func (s *Service) PreCommit(ctx context.Context, repo, branch string, event catalog.PreCommitEvent) error {
+	return s.Run(ctx, &Event{
+		Type:                 EventTypePreCommit,
+		Repository:           repo,
+		Branch:               branch,
+		Committer:            event.Committer,
+		Message:              event.Message,
+		Metadata:             event.Metadata,
+		Differences:          event.Differences,
+		DifferencesTruncated: event.DifferencesTruncated,
+	})
+}
+
+// PreMerge is a catalog PreMergeFunc
+func (s *Service) PreMerge(ctx context.Context, repo, branch string, event catalog.PreMergeEvent) error {
+	return s.Run(ctx, &Event{
+		Type:                 EventTypePreMerge,
+		Repository:           repo,
+		Branch:               branch,
+		SourceRef:            event.SourceRef,
+		Committer:            event.Committer,
+		Message:              event.Message,
+		Metadata:             event.Metadata,
+		Differences:          event.Differences,
+		DifferencesTruncated: event.DifferencesTruncated,
+	})
+}
+
+// NewRunID returns a new run id.  Run ids sort by their creation time.
+func NewRunID() string {
+	return xid.New().String()
+}
+
+// Run runs the hooks of all actions that match event and stores the results.  Hooks of an
+// action run in order until one fails.  Returns ErrActionFailed if any hook failed.  No run is
+// stored if no action matches event.
+func (s *Service) Run(ctx context.Context, event *Event) error {
+	actions, err := s.loadActions(ctx, event)
+	if err != nil {
+		return err
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+	run := &RunResult{
+		RunID:     NewRunID(),
+		EventType: event.Type,
+		Branch:    event.Branch,
+		SourceRef: event.SourceRef,
+		Committer: event.Committer,
+		Message:   event.Message,
+		StartTime: time.Now(),
+	}
+	log := logging.FromContext(ctx).WithFields(logging.Fields{
+		"run_id":     run.RunID,
+		"event_type": event.Type,
+		"repository": event.Repository,
+		"branch":     event.Branch,
+	})
+	var hookResults []*HookRunResult
+	var runErr error
+	for _, action := range actions {
+		for _, h := range action.Hooks {
+			result, err := s.runHook(ctx, event, action, h, len(hookResults))
+			hookResults = append(hookResults, result)
+			if err != nil {
+				log.WithError(err).WithFields(logging.Fields{"action": action.Name, "hook_id": h.ID}).Info("hook failed")
+				if runErr == nil {
+					runErr = fmt.Errorf("%w: %s: hook %s: %s", ErrActionFailed, action.Name, h.ID, err)
+				}
+				break
+			}
+		}
+	}
+	run.EndTime = time.Now()
+	run.Passed = runErr == nil
+	if err := s.saveRun(ctx, event.Repository, run, hookResults); err != nil {
+		return fmt.Errorf("save actions run %s: %w", run.RunID, err)
+	}
+	if runErr != nil {
+		return fmt.Errorf("%w (run %s)", runErr, run.RunID)
+	}
+	return nil
+}
+
+func (s *Service) runHook(ctx context.Context, event *Event, action *Action, h ActionHook, hookRunID int) (*HookRunResult, error) {
+	result := &HookRunResult{
+		HookRunID:  hookRunID,
+		ActionName: action.Name,
+		HookID:     h.ID,
+		StartTime:  time.Now(),
+	}
+	out := &limitedWriter{limit: maxHookLogSize}
+	hook, err := NewHook(action, h, HookDependencies{Cataloger: s.cataloger, WebhookPolicy: s.webhookPolicy})
+	if err == nil {
+		err = hook.Run(ctx, event, out)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "hook failed: %s\n", err)
+	}
+	result.EndTime = time.Now()
+	result.Passed = err == nil
+	result.Log = out.String()
+	return result, err
+}
+
+// loadActions reads the action files stored on the event reference and returns the actions
+// matching event
+func (s *Service) loadActions(ctx context.Context, event *Event) ([]*Action, error) {
+	ref := event.Ref()
+	entries, _, err := s.cataloger.ListEntries(ctx, event.Repository, ref, ActionsPrefix, "", catalog.DefaultPathDelimiter, maxActionFiles)
+	if err != nil {
+		return nil, fmt.Errorf("list actions on %s: %w", ref, err)
+	}
+	var repo *catalog.Repository
+	var actions []*Action
+	for _, entry := range entries {
+		if entry.CommonLevel {
+			continue
+		}
+		if ext := path.Ext(entry.Path); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		if entry.Size > maxActionFileSize {
+			return nil, fmt.Errorf("%w: %s: file too large", ErrInvalidAction, entry.Path)
+		}
+		if repo == nil {
+			repo, err = s.cataloger.GetRepository(ctx, event.Repository)
+			if err != nil {
+				return nil, err
+			}
+		}
+		data, err := s.readEntry(ctx, repo.StorageNamespace, entry)
+		if err != nil {
+			return nil, fmt.Errorf("read action %s: %w", entry.Path, err)
+		}
+		action, err := ParseAction(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Path, err)
+		}
+		if action.Match(event.Type, event.Branch) {
+			actions = append(actions, action)
+		}
+	}
+	return actions, nil
+}
+
+func (s *Service) readEntry(ctx context.Context, storageNamespace string, entry *catalog.Entry) ([]byte, error) {
+	reader, err := s.blockAdapter.WithContext(ctx).Get(block.ObjectPointer{
+		StorageNamespace: storageNamespace,
+		Identifier:       entry.PhysicalAddress,
+	}, entry.Size)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	return ioutil.ReadAll(reader)
+}
+
+func (s *Service) saveRun(ctx context.Context, repository string, run *RunResult, hookResults []*HookRunResult) error {
+	_, err := s.db.Transact(func(tx db.Tx) (interface{}, error) {
+		_, err := tx.Exec(`INSERT INTO actions_runs (repository, run_id, event_type, branch, source_ref, committer, commit_message, start_time, end_time, passed)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			repository, run.RunID, run.EventType, run.Branch, run.SourceRef, run.Committer, run.Message, run.StartTime, run.EndTime, run.Passed)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hookResults {
+			_, err := tx.Exec(`INSERT INTO actions_run_hooks (repository, run_id, hook_run_id, action_name, hook_id, start_time, end_time, passed, log)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+				repository, run.RunID, h.HookRunID, h.ActionName, h.HookID, h.StartTime, h.EndTime, h.Passed, h.Log)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}, db.WithContext(ctx))
+	return err
+}
+
+// ListRuns returns runs of repository, newest first, optionally only those of branch.  Returns
+// up to amount runs older than the run after, and whether more runs exist.
+func (s *Service) ListRuns(ctx context.Context, repository, branch, after string, amount int) ([]*RunResult, bool, error) {
+	if amount < 0 || amount > ListRunsMaxAmount {
+		amount = ListRunsMaxAmount
+	}
+	var runs []*RunResult
+	err := s.db.WithContext(ctx).Select(&runs,
+		`SELECT run_id, event_type, branch, source_ref, committer, commit_message, start_time, end_time, passed
+		FROM actions_runs
+		WHERE repository = $1 AND ($2 = '' OR branch = $2) AND ($3 = '' OR run_id < $3)
+		ORDER BY run_id DESC
+		LIMIT $4`, repository, branch, after, amount+1)
+	if err != nil {
+		return nil, false, err
+	}
+	hasMore := false
+	if len(runs) > amount {
+		runs = runs[:amount]
+		hasMore = true
+	}
+	return runs, hasMore, nil
+}
+
+// GetRun returns the run runID of repository, or ErrRunNotFound
+func (s *Service) GetRun(ctx context.Context, repository, runID string) (*RunResult, error) {
+	var run RunResult
+	err := s.db.WithContext(ctx).Get(&run,
+		`SELECT run_id, event_type, branch, source_ref, committer, commit_message, start_time, end_time, passed
+		FROM actions_runs
+		WHERE repository = $1 AND run_id = $2`, repository, runID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrRunNotFound
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ListRunHooks returns the hooks that ran during run runID of repository, in the order they ran
+func (s *Service) ListRunHooks(ctx context.Context, repository, runID string) ([]*HookRunResult, error) {
+	if _, err := s.GetRun(ctx, repository, runID); err != nil {
+		return nil, err
+	}
+	var hookResults []*HookRunResult
+	err := s.db.WithContext(ctx).Select(&hookResults,
+		`SELECT hook_run_id, action_name, hook_id, start_time, end_time, passed, log
+		FROM actions_run_hooks
+		WHERE repository = $1 AND run_id = $2
+		ORDER BY hook_run_id`, repository, runID)
+	if err != nil {
+		return nil, err
+	}
+	return hookResults, nil
+}
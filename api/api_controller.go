@@ -1,12 +1,14 @@
 package api
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,8 +21,11 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/treeverse/lakefs/actions"
 	"github.com/treeverse/lakefs/api/gen/models"
 	"github.com/treeverse/lakefs/api/gen/restapi/operations"
+	actionsop "github.com/treeverse/lakefs/api/gen/restapi/operations/actions"
+	auditop "github.com/treeverse/lakefs/api/gen/restapi/operations/audit"
 	authop "github.com/treeverse/lakefs/api/gen/restapi/operations/auth"
 	"github.com/treeverse/lakefs/api/gen/restapi/operations/branches"
 	"github.com/treeverse/lakefs/api/gen/restapi/operations/commits"
@@ -33,12 +38,16 @@ import (
 	"github.com/treeverse/lakefs/api/gen/restapi/operations/repositories"
 	retentionop "github.com/treeverse/lakefs/api/gen/restapi/operations/retention"
 	setupop "github.com/treeverse/lakefs/api/gen/restapi/operations/setup"
+	tagsop "github.com/treeverse/lakefs/api/gen/restapi/operations/tags"
+	"github.com/treeverse/lakefs/audit"
 	"github.com/treeverse/lakefs/auth"
 	"github.com/treeverse/lakefs/auth/model"
 	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/db"
 	"github.com/treeverse/lakefs/dedup"
+	"github.com/treeverse/lakefs/gateway/sig"
+	"github.com/treeverse/lakefs/graveler"
 	"github.com/treeverse/lakefs/httputil"
 	"github.com/treeverse/lakefs/logging"
 	"github.com/treeverse/lakefs/permissions"
@@ -55,6 +64,8 @@ const (
 	DefaultResultsPerPage            = 100
 	lakeFSPrefix                     = "symlinks"
 	UserContextKey        contextKey = "user"
+	// Longest validity of presigned URLs, as allowed by S3
+	presignMaxExpires = 7 * 24 * time.Hour
 )
 
 type Dependencies struct {
@@ -66,9 +77,12 @@ type Dependencies struct {
 	Retention       retention.Service
 	Parade          parade.Parade
 	Dedup           *dedup.Cleaner
+	Actions         *actions.Service
+	Audit           audit.Service
 	MetadataManager auth.MetadataManager
 	Migrator        db.Migrator
 	Collector       stats.Collector
+	S3Gateway       S3GatewayConfig
 	logger          logging.Logger
 }
 
@@ -82,9 +96,12 @@ func (d *Dependencies) WithContext(ctx context.Context) *Dependencies {
 		Retention:       d.Retention,
 		Parade:          d.Parade,
 		Dedup:           d.Dedup,
+		Actions:         d.Actions,
+		Audit:           d.Audit,
 		MetadataManager: d.MetadataManager,
 		Migrator:        d.Migrator,
 		Collector:       d.Collector,
+		S3Gateway:       d.S3Gateway,
 		logger:          d.logger.WithContext(ctx),
 	}
 }
@@ -101,7 +118,7 @@ type Controller struct {
 	deps *Dependencies
 }
 
-func NewController(cataloger catalog.Cataloger, auth auth.Service, blockAdapter block.Adapter, stats stats.Collector, retention retention.Service, parade parade.Parade, dedupCleaner *dedup.Cleaner, metadataManager auth.MetadataManager, migrator db.Migrator, collector stats.Collector, logger logging.Logger) *Controller {
+func NewController(cataloger catalog.Cataloger, auth auth.Service, blockAdapter block.Adapter, stats stats.Collector, retention retention.Service, parade parade.Parade, dedupCleaner *dedup.Cleaner, actionsService *actions.Service, auditService audit.Service, metadataManager auth.MetadataManager, migrator db.Migrator, collector stats.Collector, s3Gateway S3GatewayConfig, logger logging.Logger) *Controller {
 	c := &Controller{
 		deps: &Dependencies{
 			ctx:             context.Background(),
@@ -112,9 +129,12 @@ func NewController(cataloger catalog.Cataloger, auth auth.Service, blockAdapter
 			Retention:       retention,
 			Parade:          parade,
 			Dedup:           dedupCleaner,
+			Actions:         actionsService,
+			Audit:           auditService,
 			MetadataManager: metadataManager,
 			Migrator:        migrator,
 			Collector:       collector,
+			S3Gateway:       s3Gateway,
 			logger:          logger,
 		},
 	}
@@ -174,6 +194,15 @@ func (c *Controller) Configure(api *operations.LakefsAPI) {
 	api.BranchesCreateBranchHandler = c.CreateBranchHandler()
 	api.BranchesDeleteBranchHandler = c.DeleteBranchHandler()
 	api.BranchesRevertBranchHandler = c.RevertBranchHandler()
+	api.BranchesCherryPickHandler = c.CherryPickHandler()
+	api.BranchesGetBranchProtectionRulesHandler = c.GetBranchProtectionRulesHandler()
+	api.BranchesSetBranchProtectionRuleHandler = c.SetBranchProtectionRuleHandler()
+	api.BranchesDeleteBranchProtectionRuleHandler = c.DeleteBranchProtectionRuleHandler()
+
+	api.TagsListTagsHandler = c.ListTagsHandler()
+	api.TagsGetTagHandler = c.GetTagHandler()
+	api.TagsCreateTagHandler = c.CreateTagHandler()
+	api.TagsDeleteTagHandler = c.DeleteTagHandler()
 
 	api.CommitsCommitHandler = c.CommitHandler()
 	api.CommitsGetCommitHandler = c.GetCommitHandler()
@@ -184,10 +213,14 @@ func (c *Controller) Configure(api *operations.LakefsAPI) {
 	api.RefsMergeIntoBranchHandler = c.MergeMergeIntoBranchHandler()
 
 	api.ObjectsStatObjectHandler = c.ObjectsStatObjectHandler()
+	api.ObjectsPresignObjectHandler = c.ObjectsPresignObjectHandler()
 	api.ObjectsGetUnderlyingPropertiesHandler = c.ObjectsGetUnderlyingPropertiesHandler()
 	api.ObjectsListObjectsHandler = c.ObjectsListObjectsHandler()
 	api.ObjectsGetObjectHandler = c.ObjectsGetObjectHandler()
 	api.ObjectsUploadObjectHandler = c.ObjectsUploadObjectHandler()
+	api.ObjectsGetPhysicalAddressHandler = c.ObjectsGetPhysicalAddressHandler()
+	api.ObjectsLinkPhysicalAddressHandler = c.ObjectsLinkPhysicalAddressHandler()
+	api.ObjectsCopyObjectHandler = c.ObjectsCopyObjectHandler()
 	api.ObjectsDeleteObjectHandler = c.ObjectsDeleteObjectHandler()
 
 	api.RetentionGetRetentionPolicyHandler = c.RetentionGetRetentionPolicyHandler()
@@ -200,6 +233,12 @@ func (c *Controller) Configure(api *operations.LakefsAPI) {
 	api.ExportRunHandler = c.ExportRunHandler()
 	api.ExportRepairHandler = c.ExportRepairHandler()
 	api.ConfigGetConfigHandler = c.ConfigGetConfigHandler()
+
+	api.ActionsListRunsHandler = c.ActionsListRunsHandler()
+	api.ActionsGetRunHandler = c.ActionsGetRunHandler()
+	api.ActionsListRunHooksHandler = c.ActionsListRunHooksHandler()
+
+	api.AuditListAuditEntriesHandler = c.AuditListAuditEntriesHandler()
 }
 
 func (c *Controller) setupRequest(user *models.User, r *http.Request, permissions []permissions.Permission) (*Dependencies, error) {
@@ -207,9 +246,30 @@ func (c *Controller) setupRequest(user *models.User, r *http.Request, permission
 	ctx := logging.AddFields(r.Context(), logging.Fields{"user": user.ID})
 	ctx = context.WithValue(ctx, UserContextKey, user)
 	deps := c.deps.WithContext(ctx)
+	repository, ref := pathRef(r.URL.Path)
+	audit.Track(ctx, audit.NewEntry(user.ID, permissions, repository, ref))
 	return deps, authorize(deps.Auth, user, permissions)
 }
 
+// pathRef returns the repository and the branch, tag or ref in the path of an API request
+func pathRef(p string) (repository, ref string) {
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	for i, part := range parts {
+		if part != "repositories" || i+1 >= len(parts) {
+			continue
+		}
+		repository = parts[i+1]
+		if i+3 < len(parts) {
+			switch parts[i+2] {
+			case "branches", "refs", "tags":
+				ref = parts[i+3]
+			}
+		}
+		break
+	}
+	return repository, ref
+}
+
 func createPaginator(nextToken string, amountResults int) *models.Pagination {
 	return &models.Pagination{
 		HasMore:    swag.Bool(nextToken != ""),
@@ -443,6 +503,12 @@ func (c *Controller) CommitHandler() commits.CommitHandler {
 		commitMessage := swag.StringValue(params.Commit.Message)
 		commit, err := deps.Cataloger.Commit(c.Context(), params.Repository,
 			params.Branch, commitMessage, committer, params.Commit.Metadata)
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			return commits.NewCommitDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		}
+		if errors.Is(err, catalog.ErrHookRejected) {
+			return commits.NewCommitDefault(http.StatusPreconditionFailed).WithPayload(responseErrorFrom(err))
+		}
 		if err != nil {
 			return commits.NewCommitDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
 		}
@@ -472,9 +538,17 @@ func (c *Controller) CommitsGetBranchCommitLogHandler() commits.GetBranchCommitL
 		cataloger := deps.Cataloger
 
 		after, amount := getPaginationParams(params.After, params.Amount)
-		// get commit log
-		commitLog, hasMore, err := cataloger.ListCommits(c.Context(), params.Repository, params.Branch, after, amount)
+		// get commit log, filtered by path if requested
+		var commitLog []*catalog.CommitLog
+		var hasMore bool
+		if path := swag.StringValue(params.Path); path != "" {
+			commitLog, hasMore, err = cataloger.ListCommitsByPath(c.Context(), params.Repository, params.Branch, path, after, amount)
+		} else {
+			commitLog, hasMore, err = cataloger.ListCommits(c.Context(), params.Repository, params.Branch, after, amount)
+		}
 		switch {
+		case errors.Is(err, catalog.ErrFeatureNotSupported):
+			return commits.NewGetBranchCommitLogDefault(http.StatusNotImplemented).WithPayload(responseError(err.Error()))
 		case errors.Is(err, catalog.ErrBranchNotFound):
 			return commits.NewGetBranchCommitLogNotFound().WithPayload(responseError("branch '%s' not found.", params.Branch))
 		case errors.Is(err, catalog.ErrRepositoryNotFound):
@@ -512,25 +586,6 @@ func (c *Controller) CommitsGetBranchCommitLogHandler() commits.GetBranchCommitL
 	})
 }
 
-func ensureStorageNamespaceRW(adapter block.Adapter, storageNamespace string) error {
-	const (
-		dummyKey  = "dummy"
-		dummyData = "this is dummy data - created by lakeFS in order to check accessibility "
-	)
-
-	err := adapter.Put(block.ObjectPointer{StorageNamespace: storageNamespace, Identifier: dummyKey}, int64(len(dummyData)), bytes.NewReader([]byte(dummyData)), block.PutOpts{})
-	if err != nil {
-		return err
-	}
-
-	_, err = adapter.Get(block.ObjectPointer{StorageNamespace: storageNamespace, Identifier: dummyKey}, int64(len(dummyData)))
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (c *Controller) CreateRepositoryHandler() repositories.CreateRepositoryHandler {
 	return repositories.CreateRepositoryHandlerFunc(func(params repositories.CreateRepositoryParams, user *models.User) middleware.Responder {
 		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
@@ -544,7 +599,7 @@ func (c *Controller) CreateRepositoryHandler() repositories.CreateRepositoryHand
 		}
 		deps.LogAction("create_repo")
 
-		err = ensureStorageNamespaceRW(deps.BlockAdapter, swag.StringValue(params.Repository.StorageNamespace))
+		err = block.EnsureStorageNamespaceRW(deps.BlockAdapter, swag.StringValue(params.Repository.StorageNamespace))
 		if err != nil {
 			c.deps.logger.
 				WithError(err).
@@ -710,6 +765,8 @@ func (c *Controller) DeleteBranchHandler() branches.DeleteBranchHandler {
 			return branches.NewDeleteBranchNotFound().WithPayload(responseError("branch '%s' not found.", params.Branch))
 		case errors.Is(err, catalog.ErrRepositoryNotFound):
 			return branches.NewDeleteBranchNotFound().WithPayload(responseError("repository '%s' not found.", params.Repository))
+		case errors.Is(err, catalog.ErrBranchProtected):
+			return branches.NewDeleteBranchDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
 		case err != nil:
 			return branches.NewDeleteBranchDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
 		}
@@ -718,6 +775,228 @@ func (c *Controller) DeleteBranchHandler() branches.DeleteBranchHandler {
 	})
 }
 
+func (c *Controller) GetBranchProtectionRulesHandler() branches.GetBranchProtectionRulesHandler {
+	return branches.GetBranchProtectionRulesHandlerFunc(func(params branches.GetBranchProtectionRulesParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.BranchProtectionReadRulesAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return branches.NewGetBranchProtectionRulesUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("get_branch_protection_rules")
+		cataloger := deps.Cataloger
+		if _, err := cataloger.GetRepository(c.Context(), params.Repository); err != nil {
+			if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
+				return branches.NewGetBranchProtectionRulesNotFound().WithPayload(responseError("repository '%s' not found.", params.Repository))
+			}
+			return branches.NewGetBranchProtectionRulesDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		rules, err := cataloger.GetBranchProtectionRules(c.Context(), params.Repository)
+		if err != nil {
+			return branches.NewGetBranchProtectionRulesDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		payload := make([]*models.BranchProtectionRule, len(rules))
+		for i, rule := range rules {
+			payload[i] = &models.BranchProtectionRule{
+				Pattern:        swag.String(rule.Pattern),
+				BlockedActions: rule.BlockedActions,
+				CreationDate:   rule.CreationDate.Unix(),
+			}
+		}
+		return branches.NewGetBranchProtectionRulesOK().WithPayload(payload)
+	})
+}
+
+func (c *Controller) SetBranchProtectionRuleHandler() branches.SetBranchProtectionRuleHandler {
+	return branches.SetBranchProtectionRuleHandlerFunc(func(params branches.SetBranchProtectionRuleParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.BranchProtectionWriteRulesAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return branches.NewSetBranchProtectionRuleUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("set_branch_protection_rule")
+		err = deps.Cataloger.SetBranchProtectionRule(c.Context(), params.Repository, &catalog.BranchProtectionRule{
+			Pattern:        swag.StringValue(params.Rule.Pattern),
+			BlockedActions: params.Rule.BlockedActions,
+		})
+		switch {
+		case errors.Is(err, db.ErrNotFound), errors.Is(err, graveler.ErrNotFound):
+			return branches.NewSetBranchProtectionRuleNotFound().WithPayload(responseError("repository '%s' not found.", params.Repository))
+		case errors.Is(err, catalog.ErrInvalidValue), errors.Is(err, graveler.ErrInvalidValue):
+			return branches.NewSetBranchProtectionRuleBadRequest().WithPayload(responseErrorFrom(err))
+		case err != nil:
+			return branches.NewSetBranchProtectionRuleDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return branches.NewSetBranchProtectionRuleNoContent()
+	})
+}
+
+func (c *Controller) DeleteBranchProtectionRuleHandler() branches.DeleteBranchProtectionRuleHandler {
+	return branches.DeleteBranchProtectionRuleHandlerFunc(func(params branches.DeleteBranchProtectionRuleParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.BranchProtectionWriteRulesAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return branches.NewDeleteBranchProtectionRuleUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("delete_branch_protection_rule")
+		err = deps.Cataloger.DeleteBranchProtectionRule(c.Context(), params.Repository, params.Pattern)
+		switch {
+		case errors.Is(err, db.ErrNotFound):
+			return branches.NewDeleteBranchProtectionRuleNotFound().WithPayload(responseError("branch protection rule '%s' not found.", params.Pattern))
+		case err != nil:
+			return branches.NewDeleteBranchProtectionRuleDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return branches.NewDeleteBranchProtectionRuleNoContent()
+	})
+}
+
+func (c *Controller) ListTagsHandler() tagsop.ListTagsHandler {
+	return tagsop.ListTagsHandlerFunc(func(params tagsop.ListTagsParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ListTagsAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return tagsop.NewListTagsUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("list_tags")
+		after, amount := getPaginationParams(params.After, params.Amount)
+
+		res, hasMore, err := deps.Cataloger.ListTags(c.Context(), params.Repository, amount, after)
+		if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
+			return tagsop.NewListTagsNotFound().WithPayload(responseErrorFrom(err))
+		}
+		if errors.Is(err, catalog.ErrFeatureNotSupported) {
+			return tagsop.NewListTagsDefault(http.StatusNotImplemented).WithPayload(responseErrorFrom(err))
+		}
+		if err != nil {
+			return tagsop.NewListTagsDefault(http.StatusInternalServerError).
+				WithPayload(responseError("could not list tags: %s", err))
+		}
+
+		tagList := make([]*models.Tag, len(res))
+		var lastID string
+		for i, tag := range res {
+			tagList[i] = &models.Tag{
+				ID:       swag.String(tag.ID),
+				CommitID: swag.String(tag.CommitID),
+			}
+			lastID = tag.ID
+		}
+		returnValue := tagsop.NewListTagsOK().WithPayload(&tagsop.ListTagsOKBody{
+			Pagination: &models.Pagination{
+				HasMore:    swag.Bool(hasMore),
+				Results:    swag.Int64(int64(len(tagList))),
+				MaxPerPage: swag.Int64(MaxResultsPerPage),
+			},
+			Results: tagList,
+		})
+		if hasMore {
+			returnValue.Payload.Pagination.NextOffset = lastID
+		}
+		return returnValue
+	})
+}
+
+func (c *Controller) GetTagHandler() tagsop.GetTagHandler {
+	return tagsop.GetTagHandlerFunc(func(params tagsop.GetTagParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ReadTagAction,
+				Resource: permissions.TagArn(params.Repository, params.Tag),
+			},
+		})
+		if err != nil {
+			return tagsop.NewGetTagUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("get_tag")
+		commitID, err := deps.Cataloger.GetTag(c.Context(), params.Repository, params.Tag)
+		switch {
+		case errors.Is(err, db.ErrNotFound), errors.Is(err, graveler.ErrNotFound):
+			return tagsop.NewGetTagNotFound().WithPayload(responseError("tag '%s' not found.", params.Tag))
+		case errors.Is(err, catalog.ErrFeatureNotSupported):
+			return tagsop.NewGetTagDefault(http.StatusNotImplemented).WithPayload(responseErrorFrom(err))
+		case err != nil:
+			return tagsop.NewGetTagDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return tagsop.NewGetTagOK().WithPayload(&models.Tag{
+			ID:       swag.String(params.Tag),
+			CommitID: swag.String(commitID),
+		})
+	})
+}
+
+func (c *Controller) CreateTagHandler() tagsop.CreateTagHandler {
+	return tagsop.CreateTagHandlerFunc(func(params tagsop.CreateTagParams, user *models.User) middleware.Responder {
+		tagID := swag.StringValue(params.Tag.ID)
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.CreateTagAction,
+				Resource: permissions.TagArn(params.Repository, tagID),
+			},
+		})
+		if err != nil {
+			return tagsop.NewCreateTagUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("create_tag")
+		commitID, err := deps.Cataloger.CreateTag(c.Context(), params.Repository, tagID, swag.StringValue(params.Tag.Ref))
+		switch {
+		case errors.Is(err, graveler.ErrTagAlreadyExists):
+			return tagsop.NewCreateTagConflict().WithPayload(responseError("tag '%s' already exists.", tagID))
+		case errors.Is(err, db.ErrNotFound), errors.Is(err, graveler.ErrNotFound):
+			return tagsop.NewCreateTagNotFound().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, graveler.ErrInvalidValue):
+			return tagsop.NewCreateTagBadRequest().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, catalog.ErrFeatureNotSupported):
+			return tagsop.NewCreateTagDefault(http.StatusNotImplemented).WithPayload(responseErrorFrom(err))
+		case err != nil:
+			return tagsop.NewCreateTagDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return tagsop.NewCreateTagCreated().WithPayload(&models.Tag{
+			ID:       swag.String(tagID),
+			CommitID: swag.String(commitID),
+		})
+	})
+}
+
+func (c *Controller) DeleteTagHandler() tagsop.DeleteTagHandler {
+	return tagsop.DeleteTagHandlerFunc(func(params tagsop.DeleteTagParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.DeleteTagAction,
+				Resource: permissions.TagArn(params.Repository, params.Tag),
+			},
+		})
+		if err != nil {
+			return tagsop.NewDeleteTagUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("delete_tag")
+		err = deps.Cataloger.DeleteTag(c.Context(), params.Repository, params.Tag)
+		switch {
+		case errors.Is(err, db.ErrNotFound), errors.Is(err, graveler.ErrNotFound):
+			return tagsop.NewDeleteTagNotFound().WithPayload(responseError("tag '%s' not found.", params.Tag))
+		case errors.Is(err, catalog.ErrFeatureNotSupported):
+			return tagsop.NewDeleteTagDefault(http.StatusNotImplemented).WithPayload(responseErrorFrom(err))
+		case err != nil:
+			return tagsop.NewDeleteTagDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return tagsop.NewDeleteTagNoContent()
+	})
+}
+
 func (c *Controller) MergeMergeIntoBranchHandler() refs.MergeIntoBranchHandler {
 	return refs.MergeIntoBranchHandlerFunc(func(params refs.MergeIntoBranchParams, user *models.User) middleware.Responder {
 		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
@@ -734,17 +1013,19 @@ func (c *Controller) MergeMergeIntoBranchHandler() refs.MergeIntoBranchHandler {
 		if err != nil {
 			return refs.NewMergeIntoBranchUnauthorized().WithPayload(responseErrorFrom(err))
 		}
-		var message string
+		var message, strategy string
 		var metadata map[string]string
 		if params.Merge != nil {
 			message = params.Merge.Message
 			metadata = params.Merge.Metadata
+			strategy = params.Merge.Strategy
 		}
 		res, err := deps.Cataloger.Merge(c.Context(),
 			params.Repository, params.SourceRef, params.DestinationRef,
 			userModel.Username,
 			message,
-			metadata)
+			metadata,
+			strategy)
 
 		switch err {
 		case nil:
@@ -759,7 +1040,15 @@ func (c *Controller) MergeMergeIntoBranchHandler() refs.MergeIntoBranchHandler {
 			return refs.NewMergeIntoBranchConflict().WithPayload(payload)
 		case catalog.ErrNoDifferenceWasFound:
 			return refs.NewMergeIntoBranchDefault(http.StatusInternalServerError).WithPayload(responseError("no difference was found"))
+		case catalog.ErrInvalidMergeStrategy:
+			return refs.NewMergeIntoBranchDefault(http.StatusBadRequest).WithPayload(responseError("invalid merge strategy"))
 		default:
+			if errors.Is(err, catalog.ErrHookRejected) {
+				return refs.NewMergeIntoBranchDefault(http.StatusPreconditionFailed).WithPayload(responseErrorFrom(err))
+			}
+			if errors.Is(err, catalog.ErrFeatureNotSupported) {
+				return refs.NewMergeIntoBranchDefault(http.StatusNotImplemented).WithPayload(responseErrorFrom(err))
+			}
 			return refs.NewMergeIntoBranchDefault(http.StatusInternalServerError).WithPayload(responseError("internal error"))
 		}
 	})
@@ -902,20 +1191,114 @@ func (c *Controller) ObjectsStatObjectHandler() objects.StatObjectHandler {
 
 		// serialize entry
 		obj := &models.ObjectStats{
-			Checksum:  entry.Checksum,
-			Mtime:     entry.CreationDate.Unix(),
-			Path:      params.Path,
-			PathType:  models.ObjectStatsPathTypeObject,
-			SizeBytes: entry.Size,
+			Checksum:        entry.Checksum,
+			Mtime:           entry.CreationDate.Unix(),
+			Path:            params.Path,
+			PathType:        models.ObjectStatsPathTypeObject,
+			PhysicalAddress: entry.PhysicalAddress,
+			SizeBytes:       entry.Size,
 		}
 
 		if entry.Expired {
 			return objects.NewStatObjectGone().WithPayload(obj)
 		}
+		if swag.BoolValue(params.Presign) {
+			repo, err := cataloger.GetRepository(c.Context(), params.Repository)
+			if err != nil {
+				return objects.NewStatObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+			}
+			obj.PhysicalAddressURL, err = deps.BlockAdapter.GetPreSignedURL(block.ObjectPointer{
+				StorageNamespace: repo.StorageNamespace,
+				Identifier:       entry.PhysicalAddress,
+			}, block.PreSignModeRead)
+			if errors.Is(err, block.ErrOperationNotSupported) {
+				return objects.NewStatObjectBadRequest().WithPayload(responseError("blockstore %s does not support presigned URLs", deps.BlockAdapter.BlockstoreType()))
+			}
+			if err != nil {
+				return objects.NewStatObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+			}
+		}
 		return objects.NewStatObjectOK().WithPayload(obj)
 	})
 }
 
+func (c *Controller) ObjectsPresignObjectHandler() objects.PresignObjectHandler {
+	return objects.PresignObjectHandlerFunc(func(params objects.PresignObjectParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ReadObjectAction,
+				Resource: permissions.ObjectArn(params.Repository, params.Path),
+			},
+		})
+		if err != nil {
+			return objects.NewPresignObjectUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("presign_object")
+
+		expires := time.Duration(swag.Int64Value(params.Expires)) * time.Second
+		if expires <= 0 || expires > presignMaxExpires {
+			return objects.NewPresignObjectBadRequest().WithPayload(responseError("expires must be between 1 and %d seconds", int64(presignMaxExpires/time.Second)))
+		}
+		_, err = deps.Cataloger.GetEntry(c.Context(), params.Repository, params.Ref, params.Path, catalog.GetEntryParams{})
+		if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
+			return objects.NewPresignObjectNotFound().WithPayload(responseError("resource not found"))
+		}
+		if err != nil {
+			return objects.NewPresignObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+
+		creds, err := presignCredentials(deps.Auth, user, params.HTTPRequest)
+		if err != nil {
+			return objects.NewPresignObjectBadRequest().WithPayload(responseErrorFrom(err))
+		}
+		signTime := time.Now()
+		presigned, err := sig.V4Presign(c.gatewayObjectURL(params.HTTPRequest, params.Repository, params.Ref, params.Path),
+			http.MethodGet, creds, deps.S3Gateway.Region, signTime, expires)
+		if err != nil {
+			return objects.NewPresignObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return objects.NewPresignObjectOK().WithPayload(&models.PresignedURL{
+			URL:       swag.String(presigned.String()),
+			ExpiresAt: swag.Int64(signTime.Add(expires).Unix()),
+		})
+	})
+}
+
+// presignCredentials returns the credentials with which to presign URLs for user: those that
+// authenticated the request, or else the first credentials of the user.
+func presignCredentials(authService auth.Service, user *models.User, r *http.Request) (*model.Credential, error) {
+	accessKeyID, _, ok := r.BasicAuth()
+	if !ok {
+		credentials, _, err := authService.ListUserCredentials(user.ID, &model.PaginationParams{Amount: 1})
+		if err != nil {
+			return nil, err
+		}
+		if len(credentials) == 0 {
+			return nil, ErrNoPresignCredentials
+		}
+		accessKeyID = credentials[0].AccessKeyID
+	}
+	return authService.GetCredentials(accessKeyID)
+}
+
+// gatewayObjectURL returns the path-style S3 gateway URL of an object.  The gateway is served
+// by this server under its domain name, so the URL uses the scheme and port of r.
+func (c *Controller) gatewayObjectURL(r *http.Request, repository, ref, path string) *url.URL {
+	scheme := "http"
+	if r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	host := c.deps.S3Gateway.DomainName
+	if _, port, err := net.SplitHostPort(r.Host); err == nil {
+		host = net.JoinHostPort(host, port)
+	}
+	return &url.URL{
+		Scheme: scheme,
+		Host:   host,
+		Path:   "/" + repository + "/" + ref + "/" + path,
+	}
+}
+
 func (c *Controller) ObjectsGetUnderlyingPropertiesHandler() objects.GetUnderlyingPropertiesHandler {
 	return objects.GetUnderlyingPropertiesHandlerFunc(func(params objects.GetUnderlyingPropertiesParams, user *models.User) middleware.Responder {
 		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
@@ -1237,6 +1620,9 @@ func (c *Controller) ObjectsUploadObjectHandler() objects.UploadObjectHandler {
 		if errors.Is(err, db.ErrNotFound) {
 			return objects.NewUploadObjectNotFound().WithPayload(responseErrorFrom(err))
 		}
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			return objects.NewUploadObjectDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		}
 		if err != nil {
 			return objects.NewUploadObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
 		}
@@ -1250,6 +1636,217 @@ func (c *Controller) ObjectsUploadObjectHandler() objects.UploadObjectHandler {
 	})
 }
 
+func (c *Controller) ObjectsGetPhysicalAddressHandler() objects.GetPhysicalAddressHandler {
+	return objects.GetPhysicalAddressHandlerFunc(func(params objects.GetPhysicalAddressParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.WriteObjectAction,
+				Resource: permissions.ObjectArn(params.Repository, params.Path),
+			},
+		})
+		if err != nil {
+			return objects.NewGetPhysicalAddressUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("get_physical_address")
+		cataloger := deps.Cataloger
+
+		repo, err := cataloger.GetRepository(c.Context(), params.Repository)
+		if errors.Is(err, db.ErrNotFound) {
+			return objects.NewGetPhysicalAddressNotFound().WithPayload(responseError("repository not found"))
+		}
+		if err != nil {
+			return objects.NewGetPhysicalAddressDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		branchExists, err := cataloger.BranchExists(c.Context(), params.Repository, params.Branch)
+		if err != nil {
+			return objects.NewGetPhysicalAddressDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		if !branchExists {
+			return objects.NewGetPhysicalAddressNotFound().WithPayload(responseError("branch '%s' not found", params.Branch))
+		}
+
+		address := upload.NewPhysicalAddress()
+		signTime := time.Now()
+		location := &models.StagingLocation{
+			PhysicalAddress: swag.String(address),
+			Token: upload.StagingToken(deps.Auth.SecretStore().SharedSecret(),
+				params.Repository, params.Branch, params.Path, address, signTime.Add(upload.DefaultStagingTokenExpiry)),
+		}
+		presignedURL, err := deps.BlockAdapter.GetPreSignedURL(block.ObjectPointer{
+			StorageNamespace: repo.StorageNamespace,
+			Identifier:       address,
+		}, block.PreSignModeWrite)
+		switch {
+		case errors.Is(err, block.ErrOperationNotSupported):
+			// the client cannot upload directly, only the address is returned
+		case err != nil:
+			return objects.NewGetPhysicalAddressDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		default:
+			location.PresignedURL = swag.String(presignedURL)
+			location.ExpiresAt = signTime.Add(block.DefaultPreSignExpiry).Unix()
+		}
+		return objects.NewGetPhysicalAddressOK().WithPayload(location)
+	})
+}
+
+func (c *Controller) ObjectsLinkPhysicalAddressHandler() objects.LinkPhysicalAddressHandler {
+	return objects.LinkPhysicalAddressHandlerFunc(func(params objects.LinkPhysicalAddressParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.WriteObjectAction,
+				Resource: permissions.ObjectArn(params.Repository, params.Path),
+			},
+		})
+		if err != nil {
+			return objects.NewLinkPhysicalAddressUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("link_physical_address")
+		cataloger := deps.Cataloger
+
+		// only addresses issued by GetPhysicalAddress for this path may be linked
+		address := swag.StringValue(params.Metadata.Staging.PhysicalAddress)
+		if !upload.IsValidPhysicalAddress(address) {
+			return objects.NewLinkPhysicalAddressBadRequest().WithPayload(responseError("invalid physical address '%s'", address))
+		}
+		err = upload.VerifyStagingToken(deps.Auth.SecretStore().SharedSecret(),
+			params.Repository, params.Branch, params.Path, address, params.Metadata.Staging.Token, time.Now())
+		if err != nil {
+			return objects.NewLinkPhysicalAddressBadRequest().WithPayload(responseErrorFrom(err))
+		}
+		repo, err := cataloger.GetRepository(c.Context(), params.Repository)
+		if errors.Is(err, db.ErrNotFound) {
+			return objects.NewLinkPhysicalAddressNotFound().WithPayload(responseError("repository not found"))
+		}
+		if err != nil {
+			return objects.NewLinkPhysicalAddressDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+
+		// size and checksum are those of the uploaded object, not as reported by the client
+		obj := block.ObjectPointer{StorageNamespace: repo.StorageNamespace, Identifier: address}
+		props, err := deps.BlockAdapter.GetProperties(obj)
+		if err != nil {
+			return objects.NewLinkPhysicalAddressBadRequest().WithPayload(responseError("read uploaded object: %s", err))
+		}
+		if props.Size != swag.Int64Value(params.Metadata.SizeBytes) {
+			return objects.NewLinkPhysicalAddressBadRequest().WithPayload(responseError("uploaded object size %d does not match size %d", props.Size, swag.Int64Value(params.Metadata.SizeBytes)))
+		}
+		checksum := props.ETag
+		if checksum == "" {
+			checksum, err = upload.ReadChecksum(deps.BlockAdapter, obj, props.Size)
+			if err != nil {
+				return objects.NewLinkPhysicalAddressDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+			}
+		}
+
+		writeTime := time.Now()
+		entry := catalog.Entry{
+			Path:            params.Path,
+			PhysicalAddress: address,
+			CreationDate:    writeTime,
+			Size:            props.Size,
+			Checksum:        checksum,
+		}
+		err = cataloger.CreateEntry(c.Context(), repo.Name, params.Branch, entry, catalog.CreateEntryParams{})
+		if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
+			return objects.NewLinkPhysicalAddressNotFound().WithPayload(responseErrorFrom(err))
+		}
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			return objects.NewLinkPhysicalAddressDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		}
+		if err != nil {
+			return objects.NewLinkPhysicalAddressDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return objects.NewLinkPhysicalAddressOK().WithPayload(&models.ObjectStats{
+			Checksum:        entry.Checksum,
+			Mtime:           writeTime.Unix(),
+			Path:            params.Path,
+			PathType:        models.ObjectStatsPathTypeObject,
+			PhysicalAddress: address,
+			SizeBytes:       entry.Size,
+		})
+	})
+}
+
+func (c *Controller) ObjectsCopyObjectHandler() objects.CopyObjectHandler {
+	return objects.CopyObjectHandlerFunc(func(params objects.CopyObjectParams, user *models.User) middleware.Responder {
+		srcRepository := params.Source.SrcRepository
+		if srcRepository == "" {
+			srcRepository = params.Repository
+		}
+		srcRef := swag.StringValue(params.Source.SrcRef)
+		srcPath := swag.StringValue(params.Source.SrcPath)
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ReadObjectAction,
+				Resource: permissions.ObjectArn(srcRepository, srcPath),
+			},
+			{
+				Action:   permissions.WriteObjectAction,
+				Resource: permissions.ObjectArn(params.Repository, params.Path),
+			},
+		})
+		if err != nil {
+			return objects.NewCopyObjectUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("copy_object")
+		cataloger := deps.Cataloger
+
+		repo, err := cataloger.GetRepository(c.Context(), params.Repository)
+		if errors.Is(err, db.ErrNotFound) {
+			return objects.NewCopyObjectNotFound().WithPayload(responseError("repository not found"))
+		}
+		if err != nil {
+			return objects.NewCopyObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		srcRepo := repo
+		if srcRepository != params.Repository {
+			srcRepo, err = cataloger.GetRepository(c.Context(), srcRepository)
+			if errors.Is(err, db.ErrNotFound) {
+				return objects.NewCopyObjectNotFound().WithPayload(responseError("source repository not found"))
+			}
+			if err != nil {
+				return objects.NewCopyObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+			}
+		}
+		entry, err := cataloger.GetEntry(c.Context(), srcRepo.Name, srcRef, srcPath, catalog.GetEntryParams{})
+		if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
+			return objects.NewCopyObjectNotFound().WithPayload(responseError("source object not found"))
+		}
+		if err != nil {
+			return objects.NewCopyObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+
+		// refer to the source data from the destination repository, copying it if the two
+		// repositories do not share storage
+		address, err := upload.CopyBlob(deps.BlockAdapter, srcRepo.StorageNamespace, entry.PhysicalAddress, repo.StorageNamespace)
+		if err != nil {
+			return objects.NewCopyObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		writeTime := time.Now()
+		entry.Path = params.Path
+		entry.PhysicalAddress = address
+		entry.CreationDate = writeTime
+		err = cataloger.CreateEntry(c.Context(), repo.Name, params.Branch, *entry, catalog.CreateEntryParams{})
+		if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
+			return objects.NewCopyObjectNotFound().WithPayload(responseErrorFrom(err))
+		}
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			return objects.NewCopyObjectDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		}
+		if err != nil {
+			return objects.NewCopyObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return objects.NewCopyObjectCreated().WithPayload(&models.ObjectStats{
+			Checksum:        entry.Checksum,
+			Mtime:           writeTime.Unix(),
+			Path:            params.Path,
+			PathType:        models.ObjectStatsPathTypeObject,
+			PhysicalAddress: address,
+			SizeBytes:       entry.Size,
+		})
+	})
+}
+
 func (c *Controller) ObjectsDeleteObjectHandler() objects.DeleteObjectHandler {
 	return objects.DeleteObjectHandlerFunc(func(params objects.DeleteObjectParams, user *models.User) middleware.Responder {
 		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
@@ -1268,6 +1865,9 @@ func (c *Controller) ObjectsDeleteObjectHandler() objects.DeleteObjectHandler {
 		if errors.Is(err, db.ErrNotFound) {
 			return objects.NewDeleteObjectNotFound().WithPayload(responseError("resource not found"))
 		}
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			return objects.NewDeleteObjectDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		}
 		if err != nil {
 			return objects.NewDeleteObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
 		}
@@ -1307,6 +1907,9 @@ func (c *Controller) RevertBranchHandler() branches.RevertBranchHandler {
 		if errors.Is(err, db.ErrNotFound) {
 			return branches.NewRevertBranchNotFound().WithPayload(responseErrorFrom(err))
 		}
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			return branches.NewRevertBranchDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		}
 		if err != nil {
 			return branches.NewRevertBranchDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
 		}
@@ -1315,6 +1918,53 @@ func (c *Controller) RevertBranchHandler() branches.RevertBranchHandler {
 	})
 }
 
+func (c *Controller) CherryPickHandler() branches.CherryPickHandler {
+	return branches.CherryPickHandlerFunc(func(params branches.CherryPickParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.CreateCommitAction,
+				Resource: permissions.BranchArn(params.Repository, params.Branch),
+			},
+		})
+		if err != nil {
+			return branches.NewCherryPickUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("cherry_pick")
+		userModel, err := deps.Auth.GetUser(user.ID)
+		if err != nil {
+			return branches.NewCherryPickUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		commit, err := deps.Cataloger.CherryPick(c.Context(), params.Repository,
+			swag.StringValue(params.CherryPick.Ref), params.Branch, userModel.Username)
+		var conflictErr *catalog.ConflictError
+		switch {
+		case errors.As(err, &conflictErr):
+			return branches.NewCherryPickConflict().WithPayload(&models.CherryPickConflict{
+				Message: conflictErr.Error(),
+				Paths:   conflictErr.Paths,
+			})
+		case errors.Is(err, db.ErrNotFound), errors.Is(err, graveler.ErrNotFound):
+			return branches.NewCherryPickNotFound().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, catalog.ErrInvalidValue), errors.Is(err, graveler.ErrInvalidValue), errors.Is(err, graveler.ErrDirtyBranch):
+			return branches.NewCherryPickBadRequest().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, catalog.ErrBranchProtected):
+			return branches.NewCherryPickDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		case errors.Is(err, catalog.ErrFeatureNotSupported):
+			return branches.NewCherryPickDefault(http.StatusNotImplemented).WithPayload(responseErrorFrom(err))
+		case err != nil:
+			return branches.NewCherryPickDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return branches.NewCherryPickCreated().WithPayload(&models.Commit{
+			Committer:    commit.Committer,
+			CreationDate: commit.CreationDate.Unix(),
+			ID:           commit.Reference,
+			Message:      commit.Message,
+			Metadata:     commit.Metadata,
+			Parents:      commit.Parents,
+		})
+	})
+}
+
 func (c *Controller) CreateUserHandler() authop.CreateUserHandler {
 	return authop.CreateUserHandlerFunc(func(params authop.CreateUserParams, user *models.User) middleware.Responder {
 		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
@@ -2371,3 +3021,199 @@ func (c *Controller) ConfigGetConfigHandler() configop.GetConfigHandler {
 		})
 	})
 }
+
+func runStatus(passed bool) string {
+	if passed {
+		return "completed"
+	}
+	return "failed"
+}
+
+func newActionRun(run *actions.RunResult) *models.ActionRun {
+	return &models.ActionRun{
+		RunID:         swag.String(run.RunID),
+		EventType:     swag.String(run.EventType),
+		Branch:        swag.String(run.Branch),
+		SourceRef:     run.SourceRef,
+		Committer:     run.Committer,
+		CommitMessage: run.Message,
+		StartTime:     swag.Int64(run.StartTime.Unix()),
+		EndTime:       swag.Int64(run.EndTime.Unix()),
+		Status:        swag.String(runStatus(run.Passed)),
+	}
+}
+
+func (c *Controller) ActionsListRunsHandler() actionsop.ListRunsHandler {
+	return actionsop.ListRunsHandlerFunc(func(params actionsop.ListRunsParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ReadActionsAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return actionsop.NewListRunsUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("list_actions_runs")
+		if _, err := deps.Cataloger.GetRepository(c.Context(), params.Repository); err != nil {
+			if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
+				return actionsop.NewListRunsNotFound().WithPayload(responseError("repository '%s' not found.", params.Repository))
+			}
+			return actionsop.NewListRunsDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+
+		after, amount := getPaginationParams(params.After, params.Amount)
+		runs, hasMore, err := deps.Actions.ListRuns(c.Context(), params.Repository, swag.StringValue(params.Branch), after, amount)
+		if err != nil {
+			return actionsop.NewListRunsDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		results := make([]*models.ActionRun, len(runs))
+		var lastID string
+		for i, run := range runs {
+			results[i] = newActionRun(run)
+			lastID = run.RunID
+		}
+		returnValue := actionsop.NewListRunsOK().WithPayload(&actionsop.ListRunsOKBody{
+			Pagination: &models.Pagination{
+				HasMore:    swag.Bool(hasMore),
+				Results:    swag.Int64(int64(len(results))),
+				MaxPerPage: swag.Int64(MaxResultsPerPage),
+			},
+			Results: results,
+		})
+		if hasMore {
+			returnValue.Payload.Pagination.NextOffset = lastID
+		}
+		return returnValue
+	})
+}
+
+func (c *Controller) ActionsGetRunHandler() actionsop.GetRunHandler {
+	return actionsop.GetRunHandlerFunc(func(params actionsop.GetRunParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ReadActionsAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return actionsop.NewGetRunUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("get_actions_run")
+		run, err := deps.Actions.GetRun(c.Context(), params.Repository, params.RunID)
+		if errors.Is(err, db.ErrNotFound) {
+			return actionsop.NewGetRunNotFound().WithPayload(responseError("run '%s' not found.", params.RunID))
+		}
+		if err != nil {
+			return actionsop.NewGetRunDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return actionsop.NewGetRunOK().WithPayload(newActionRun(run))
+	})
+}
+
+func (c *Controller) ActionsListRunHooksHandler() actionsop.ListRunHooksHandler {
+	return actionsop.ListRunHooksHandlerFunc(func(params actionsop.ListRunHooksParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ReadActionsAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return actionsop.NewListRunHooksUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("list_actions_run_hooks")
+		hookResults, err := deps.Actions.ListRunHooks(c.Context(), params.Repository, params.RunID)
+		if errors.Is(err, db.ErrNotFound) {
+			return actionsop.NewListRunHooksNotFound().WithPayload(responseError("run '%s' not found.", params.RunID))
+		}
+		if err != nil {
+			return actionsop.NewListRunHooksDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		payload := make([]*models.HookRun, len(hookResults))
+		for i, h := range hookResults {
+			payload[i] = &models.HookRun{
+				HookRunID: swag.Int64(int64(h.HookRunID)),
+				Action:    swag.String(h.ActionName),
+				HookID:    swag.String(h.HookID),
+				StartTime: swag.Int64(h.StartTime.Unix()),
+				EndTime:   swag.Int64(h.EndTime.Unix()),
+				Status:    swag.String(runStatus(h.Passed)),
+				Log:       h.Log,
+			}
+		}
+		return actionsop.NewListRunHooksOK().WithPayload(payload)
+	})
+}
+
+func (c *Controller) AuditListAuditEntriesHandler() auditop.ListAuditEntriesHandler {
+	return auditop.ListAuditEntriesHandlerFunc(func(params auditop.ListAuditEntriesParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ReadAuditLogAction,
+				Resource: permissions.All,
+			},
+		})
+		if err != nil {
+			return auditop.NewListAuditEntriesUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("list_audit_entries")
+
+		after, amount := getPaginationParams(params.After, params.Amount)
+		listParams := &audit.ListParams{
+			User:       swag.StringValue(params.User),
+			Action:     swag.StringValue(params.Action),
+			Repository: swag.StringValue(params.Repository),
+			Ref:        swag.StringValue(params.Ref),
+			Result:     swag.StringValue(params.Result),
+			Amount:     amount,
+		}
+		if params.Since != nil {
+			listParams.Since = time.Unix(*params.Since, 0)
+		}
+		if params.Until != nil {
+			listParams.Until = time.Unix(*params.Until, 0)
+		}
+		if after != "" {
+			listParams.After, err = strconv.ParseInt(after, 10, 64)
+			if err != nil {
+				return auditop.NewListAuditEntriesBadRequest().WithPayload(responseError("invalid after: %s", after))
+			}
+		}
+		entries, hasMore, err := deps.Audit.List(c.Context(), listParams)
+		if err != nil {
+			return auditop.NewListAuditEntriesDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		results := make([]*models.AuditEntry, len(entries))
+		var lastID string
+		for i, entry := range entries {
+			id := strconv.FormatInt(entry.ID, 10)
+			results[i] = &models.AuditEntry{
+				ID:         swag.String(id),
+				Time:       swag.Int64(entry.CreatedAt.Unix()),
+				Source:     swag.String(entry.Source),
+				User:       swag.String(entry.User),
+				Action:     swag.String(entry.Action),
+				Resource:   entry.Resource,
+				Repository: entry.Repository,
+				Ref:        entry.Ref,
+				RequestID:  entry.RequestID,
+				StatusCode: int64(entry.StatusCode),
+				Result:     swag.String(entry.Result),
+			}
+			lastID = id
+		}
+		returnValue := auditop.NewListAuditEntriesOK().WithPayload(&auditop.ListAuditEntriesOKBody{
+			Pagination: &models.Pagination{
+				HasMore:    swag.Bool(hasMore),
+				Results:    swag.Int64(int64(len(results))),
+				MaxPerPage: swag.Int64(MaxResultsPerPage),
+			},
+			Results: results,
+		})
+		if hasMore {
+			returnValue.Payload.Pagination.NextOffset = lastID
+		}
+		return returnValue
+	})
+}
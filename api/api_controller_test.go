@@ -3,6 +3,8 @@ package api_test
 import (
 	"bytes"
 	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -28,6 +30,7 @@ import (
 	"github.com/treeverse/lakefs/api/gen/client/objects"
 	"github.com/treeverse/lakefs/api/gen/client/repositories"
 	"github.com/treeverse/lakefs/api/gen/client/retention"
+	"github.com/treeverse/lakefs/api/gen/client/tags"
 	"github.com/treeverse/lakefs/api/gen/models"
 	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/catalog"
@@ -350,6 +353,68 @@ func TestHandler_CommitHandler(t *testing.T) {
 	})
 }
 
+func TestHandler_MergeIntoBranchHandler(t *testing.T) {
+	handler, deps := getHandler(t, "")
+
+	// create user
+	creds := createDefaultAdminUser(deps.auth, t)
+	bauth := httptransport.BasicAuth(creds.AccessKeyID, creds.AccessSecretKey)
+
+	// setup client
+	clt := client.Default
+	clt.SetTransport(&handlerTransport{Handler: handler})
+
+	ctx := context.Background()
+	_, err := deps.cataloger.CreateRepository(ctx, "repo1", "s3://repo1", "master")
+	testutil.MustDo(t, "create repo repo1", err)
+	_, err = deps.cataloger.CreateBranch(ctx, "repo1", "branch1", "master")
+	testutil.MustDo(t, "create branch branch1", err)
+
+	t.Run("unsupported strategy", func(t *testing.T) {
+		// the default cataloger resolves no conflicts
+		_, err := clt.Refs.MergeIntoBranch(&refs.MergeIntoBranchParams{
+			DestinationRef: "master",
+			SourceRef:      "branch1",
+			Merge:          &models.Merge{Strategy: catalog.MergeStrategySourceWins},
+			Repository:     "repo1",
+		}, bauth)
+		var defaultErr *refs.MergeIntoBranchDefault
+		if !errors.As(err, &defaultErr) || defaultErr.Code() != http.StatusNotImplemented {
+			t.Fatalf("expected merge with an unsupported strategy to fail with status %d, got %v", http.StatusNotImplemented, err)
+		}
+	})
+}
+
+func TestHandler_CreateTagHandler(t *testing.T) {
+	handler, deps := getHandler(t, "")
+
+	// create user
+	creds := createDefaultAdminUser(deps.auth, t)
+	bauth := httptransport.BasicAuth(creds.AccessKeyID, creds.AccessSecretKey)
+
+	// setup client
+	clt := client.Default
+	clt.SetTransport(&handlerTransport{Handler: handler})
+
+	_, err := deps.cataloger.CreateRepository(context.Background(), "repo1", "s3://repo1", "master")
+	testutil.MustDo(t, "create repo repo1", err)
+
+	t.Run("unsupported tags", func(t *testing.T) {
+		// the default cataloger keeps no tags
+		_, err := clt.Tags.CreateTag(&tags.CreateTagParams{
+			Repository: "repo1",
+			Tag: &models.TagCreation{
+				ID:  swag.String("v1"),
+				Ref: swag.String("master"),
+			},
+		}, bauth)
+		var defaultErr *tags.CreateTagDefault
+		if !errors.As(err, &defaultErr) || defaultErr.Code() != http.StatusNotImplemented {
+			t.Fatalf("expected create tag to fail with status %d, got %v", http.StatusNotImplemented, err)
+		}
+	})
+}
+
 func TestHandler_CreateRepositoryHandler(t *testing.T) {
 	handler, deps := getHandler(t, "")
 
@@ -1134,6 +1199,246 @@ func TestHandler_ObjectsUploadObjectHandler(t *testing.T) {
 	})
 }
 
+func TestHandler_ObjectsLinkPhysicalAddressHandler(t *testing.T) {
+	handler, deps := getHandler(t, "")
+
+	// create user
+	creds := createDefaultAdminUser(deps.auth, t)
+	bauth := httptransport.BasicAuth(creds.AccessKeyID, creds.AccessSecretKey)
+
+	// setup client
+	clt := client.Default
+	clt.SetTransport(&handlerTransport{Handler: handler})
+	ctx := context.Background()
+	_, err := deps.cataloger.CreateRepository(ctx, "repo1", "ns1", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("link uploaded object", func(t *testing.T) {
+		const content = "hello world this is my direct content"
+		resp, err := clt.Objects.GetPhysicalAddress(&objects.GetPhysicalAddressParams{
+			Branch:     "master",
+			Path:       "foo/direct",
+			Repository: "repo1",
+		}, bauth)
+		testutil.MustDo(t, "get physical address", err)
+		location := resp.Payload
+		address := swag.StringValue(location.PhysicalAddress)
+		if address == "" {
+			t.Fatal("expected a physical address")
+		}
+
+		// upload as a client would, directly to the underlying storage
+		err = deps.blocks.Put(block.ObjectPointer{StorageNamespace: "ns1", Identifier: address},
+			int64(len(content)), strings.NewReader(content), block.PutOpts{})
+		testutil.MustDo(t, "put object", err)
+
+		linkResp, err := clt.Objects.LinkPhysicalAddress(&objects.LinkPhysicalAddressParams{
+			Branch: "master",
+			Metadata: &models.StagingMetadata{
+				Staging:   location,
+				Checksum:  swag.String("this_is_a_checksum"),
+				SizeBytes: swag.Int64(int64(len(content))),
+			},
+			Path:       "foo/direct",
+			Repository: "repo1",
+		}, bauth)
+		testutil.MustDo(t, "link physical address", err)
+		if linkResp.Payload.PhysicalAddress != address {
+			t.Fatalf("expected linked object at %s, got %s", address, linkResp.Payload.PhysicalAddress)
+		}
+		expectedChecksum := md5.Sum([]byte(content)) //nolint:gosec
+		if linkResp.Payload.Checksum != hex.EncodeToString(expectedChecksum[:]) {
+			t.Fatalf("expected checksum of the uploaded object, got %s", linkResp.Payload.Checksum)
+		}
+
+		rbuf := new(bytes.Buffer)
+		_, err = clt.Objects.GetObject(&objects.GetObjectParams{
+			Ref:        "master",
+			Path:       "foo/direct",
+			Repository: "repo1",
+		}, bauth, rbuf)
+		testutil.MustDo(t, "get linked object", err)
+		if rbuf.String() != content {
+			t.Fatalf("expected linked object content %q, got %q", content, rbuf.String())
+		}
+	})
+
+	t.Run("get physical address missing branch", func(t *testing.T) {
+		_, err := clt.Objects.GetPhysicalAddress(&objects.GetPhysicalAddressParams{
+			Branch:     "masterX",
+			Path:       "foo/direct",
+			Repository: "repo1",
+		}, bauth)
+		if _, ok := err.(*objects.GetPhysicalAddressNotFound); !ok {
+			t.Fatalf("expected not found for missing branch, got %v", err)
+		}
+	})
+
+	t.Run("link address not issued for path", func(t *testing.T) {
+		resp, err := clt.Objects.GetPhysicalAddress(&objects.GetPhysicalAddressParams{
+			Branch:     "master",
+			Path:       "foo/issued",
+			Repository: "repo1",
+		}, bauth)
+		testutil.MustDo(t, "get physical address", err)
+		address := swag.StringValue(resp.Payload.PhysicalAddress)
+		err = deps.blocks.Put(block.ObjectPointer{StorageNamespace: "ns1", Identifier: address},
+			1, strings.NewReader("x"), block.PutOpts{})
+		testutil.MustDo(t, "put object", err)
+
+		locations := map[string]*models.StagingLocation{
+			"other path":     resp.Payload,
+			"no token":       {PhysicalAddress: resp.Payload.PhysicalAddress},
+			"path traversal": {PhysicalAddress: swag.String("../../../etc/passwd"), Token: resp.Payload.Token},
+		}
+		for name, location := range locations {
+			_, err := clt.Objects.LinkPhysicalAddress(&objects.LinkPhysicalAddressParams{
+				Branch: "master",
+				Metadata: &models.StagingMetadata{
+					Staging:   location,
+					Checksum:  swag.String("this_is_a_checksum"),
+					SizeBytes: swag.Int64(1),
+				},
+				Path:       "foo/other",
+				Repository: "repo1",
+			}, bauth)
+			if _, ok := err.(*objects.LinkPhysicalAddressBadRequest); !ok {
+				t.Errorf("%s: expected bad request, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("link wrong size", func(t *testing.T) {
+		resp, err := clt.Objects.GetPhysicalAddress(&objects.GetPhysicalAddressParams{
+			Branch:     "master",
+			Path:       "foo/size",
+			Repository: "repo1",
+		}, bauth)
+		testutil.MustDo(t, "get physical address", err)
+		err = deps.blocks.Put(block.ObjectPointer{StorageNamespace: "ns1", Identifier: swag.StringValue(resp.Payload.PhysicalAddress)},
+			1, strings.NewReader("x"), block.PutOpts{})
+		testutil.MustDo(t, "put object", err)
+
+		_, err = clt.Objects.LinkPhysicalAddress(&objects.LinkPhysicalAddressParams{
+			Branch: "master",
+			Metadata: &models.StagingMetadata{
+				Staging:   resp.Payload,
+				Checksum:  swag.String("this_is_a_checksum"),
+				SizeBytes: swag.Int64(1000),
+			},
+			Path:       "foo/size",
+			Repository: "repo1",
+		}, bauth)
+		if _, ok := err.(*objects.LinkPhysicalAddressBadRequest); !ok {
+			t.Fatalf("expected bad request for a size that does not match the uploaded object, got %v", err)
+		}
+	})
+
+	t.Run("link full address", func(t *testing.T) {
+		_, err := clt.Objects.LinkPhysicalAddress(&objects.LinkPhysicalAddressParams{
+			Branch: "master",
+			Metadata: &models.StagingMetadata{
+				Staging:   &models.StagingLocation{PhysicalAddress: swag.String("s3://other-bucket/data")},
+				Checksum:  swag.String("this_is_a_checksum"),
+				SizeBytes: swag.Int64(1),
+			},
+			Path:       "foo/other",
+			Repository: "repo1",
+		}, bauth)
+		if _, ok := err.(*objects.LinkPhysicalAddressBadRequest); !ok {
+			t.Fatalf("expected bad request for an address outside the repository, got %v", err)
+		}
+	})
+}
+
+func TestHandler_ObjectsCopyObjectHandler(t *testing.T) {
+	handler, deps := getHandler(t, "")
+
+	// create user
+	creds := createDefaultAdminUser(deps.auth, t)
+	bauth := httptransport.BasicAuth(creds.AccessKeyID, creds.AccessSecretKey)
+
+	// setup client
+	clt := client.Default
+	clt.SetTransport(&handlerTransport{Handler: handler})
+	ctx := context.Background()
+	_, err := deps.cataloger.CreateRepository(ctx, "repo1", "ns1", "master")
+	testutil.MustDo(t, "create repo1", err)
+	_, err = deps.cataloger.CreateRepository(ctx, "repo2", "ns2", "master")
+	testutil.MustDo(t, "create repo2", err)
+
+	const content = "curated data to publish"
+	uploadResp, err := clt.Objects.UploadObject(&objects.UploadObjectParams{
+		Branch:     "master",
+		Content:    runtime.NamedReader("content", strings.NewReader(content)),
+		Path:       "foo/bar",
+		Repository: "repo1",
+	}, bauth)
+	testutil.MustDo(t, "upload object", err)
+
+	t.Run("copy within repository", func(t *testing.T) {
+		resp, err := clt.Objects.CopyObject(&objects.CopyObjectParams{
+			Branch:     "master",
+			Path:       "foo/copy",
+			Repository: "repo1",
+			Source: &models.ObjectCopyCreation{
+				SrcRef:  swag.String("master"),
+				SrcPath: swag.String("foo/bar"),
+			},
+		}, bauth)
+		testutil.MustDo(t, "copy object", err)
+		if resp.Payload.PhysicalAddress != uploadResp.Payload.PhysicalAddress {
+			t.Fatalf("expected copy to link %s, got %s", uploadResp.Payload.PhysicalAddress, resp.Payload.PhysicalAddress)
+		}
+	})
+
+	t.Run("copy across repositories", func(t *testing.T) {
+		resp, err := clt.Objects.CopyObject(&objects.CopyObjectParams{
+			Branch:     "master",
+			Path:       "published/bar",
+			Repository: "repo2",
+			Source: &models.ObjectCopyCreation{
+				SrcRepository: "repo1",
+				SrcRef:        swag.String("master"),
+				SrcPath:       swag.String("foo/bar"),
+			},
+		}, bauth)
+		testutil.MustDo(t, "copy object", err)
+		if resp.Payload.Checksum != uploadResp.Payload.Checksum {
+			t.Fatalf("expected copy checksum %s, got %s", uploadResp.Payload.Checksum, resp.Payload.Checksum)
+		}
+
+		rbuf := new(bytes.Buffer)
+		_, err = clt.Objects.GetObject(&objects.GetObjectParams{
+			Ref:        "master",
+			Path:       "published/bar",
+			Repository: "repo2",
+		}, bauth, rbuf)
+		testutil.MustDo(t, "get copied object", err)
+		if rbuf.String() != content {
+			t.Fatalf("expected copied object content %q, got %q", content, rbuf.String())
+		}
+	})
+
+	t.Run("copy missing source", func(t *testing.T) {
+		_, err := clt.Objects.CopyObject(&objects.CopyObjectParams{
+			Branch:     "master",
+			Path:       "published/missing",
+			Repository: "repo2",
+			Source: &models.ObjectCopyCreation{
+				SrcRepository: "repo1",
+				SrcRef:        swag.String("master"),
+				SrcPath:       swag.String("foo/missing"),
+			},
+		}, bauth)
+		if _, ok := err.(*objects.CopyObjectNotFound); !ok {
+			t.Fatalf("expected not found for a missing source, got %v", err)
+		}
+	})
+}
+
 func TestHandler_ObjectsDeleteObjectHandler(t *testing.T) {
 	handler, deps := getHandler(t, "")
 
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/url"
 	"path"
+	"time"
 
 	"github.com/treeverse/lakefs/api/gen/client/export"
 
@@ -13,6 +14,8 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	genclient "github.com/treeverse/lakefs/api/gen/client"
+	"github.com/treeverse/lakefs/api/gen/client/actions"
+	"github.com/treeverse/lakefs/api/gen/client/audit"
 	"github.com/treeverse/lakefs/api/gen/client/auth"
 	"github.com/treeverse/lakefs/api/gen/client/branches"
 	"github.com/treeverse/lakefs/api/gen/client/commits"
@@ -21,6 +24,7 @@ import (
 	"github.com/treeverse/lakefs/api/gen/client/refs"
 	"github.com/treeverse/lakefs/api/gen/client/repositories"
 	"github.com/treeverse/lakefs/api/gen/client/retention"
+	"github.com/treeverse/lakefs/api/gen/client/tags"
 	"github.com/treeverse/lakefs/api/gen/models"
 	"github.com/treeverse/lakefs/catalog"
 )
@@ -53,6 +57,19 @@ type AuthClient interface {
 	ListGroupPolicies(ctx context.Context, groupID string, after string, amount int) ([]*models.Policy, *models.Pagination, error)
 	AttachPolicyToGroup(ctx context.Context, groupID, policyID string) error
 	DetachPolicyFromGroup(ctx context.Context, groupID, policyID string) error
+	ListAuditEntries(ctx context.Context, filter *AuditFilter, after string, amount int) ([]*models.AuditEntry, *models.Pagination, error)
+}
+
+// AuditFilter selects audit log entries.  Empty fields do not filter.
+type AuditFilter struct {
+	User       string
+	Action     string
+	Repository string
+	Ref        string
+	Result     string
+	// Since and Until are unix times
+	Since int64
+	Until int64
 }
 
 type RepositoryClient interface {
@@ -66,19 +83,37 @@ type RepositoryClient interface {
 	CreateBranch(ctx context.Context, repository string, branch *models.BranchCreation) (string, error)
 	DeleteBranch(ctx context.Context, repository, branchID string) error
 	RevertBranch(ctx context.Context, repository, branchID string, revertProps *models.RevertCreation) error
+	CherryPick(ctx context.Context, repository, ref, branchID string) (*models.Commit, error)
+
+	GetBranchProtectionRules(ctx context.Context, repository string) ([]*models.BranchProtectionRule, error)
+	SetBranchProtectionRule(ctx context.Context, repository string, rule *models.BranchProtectionRule) error
+	DeleteBranchProtectionRule(ctx context.Context, repository, pattern string) error
+
+	ListRuns(ctx context.Context, repository, branch, after string, amount int) ([]*models.ActionRun, *models.Pagination, error)
+	GetRun(ctx context.Context, repository, runID string) (*models.ActionRun, error)
+	ListRunHooks(ctx context.Context, repository, runID string) ([]*models.HookRun, error)
+
+	ListTags(ctx context.Context, repository string, from string, amount int) ([]*models.Tag, *models.Pagination, error)
+	GetTag(ctx context.Context, repository, tagID string) (*models.Tag, error)
+	CreateTag(ctx context.Context, repository, tagID, ref string) (*models.Tag, error)
+	DeleteTag(ctx context.Context, repository, tagID string) error
 
 	Commit(ctx context.Context, repository, branchID, message string, metadata map[string]string) (*models.Commit, error)
 	GetCommit(ctx context.Context, repository, commitID string) (*models.Commit, error)
-	GetCommitLog(ctx context.Context, repository, branchID, after string, amount int) ([]*models.Commit, *models.Pagination, error)
+	GetCommitLog(ctx context.Context, repository, branchID, path, after string, amount int) ([]*models.Commit, *models.Pagination, error)
 
-	StatObject(ctx context.Context, repository, ref, path string) (*models.ObjectStats, error)
+	StatObject(ctx context.Context, repository, ref, path string, presign bool) (*models.ObjectStats, error)
+	PresignObject(ctx context.Context, repository, ref, path string, expires time.Duration) (*models.PresignedURL, error)
 	ListObjects(ctx context.Context, repository, ref, prefix, from string, amount int) ([]*models.ObjectStats, *models.Pagination, error)
 	GetObject(ctx context.Context, repository, ref, path string, w io.Writer) (*objects.GetObjectOK, error)
 	UploadObject(ctx context.Context, repository, branchID, path string, r io.Reader) (*models.ObjectStats, error)
+	GetPhysicalAddress(ctx context.Context, repository, branchID, path string) (*models.StagingLocation, error)
+	LinkPhysicalAddress(ctx context.Context, repository, branchID, path string, metadata *models.StagingMetadata) (*models.ObjectStats, error)
+	CopyObject(ctx context.Context, repository, branchID, path string, source *models.ObjectCopyCreation) (*models.ObjectStats, error)
 	DeleteObject(ctx context.Context, repository, branchID, path string) error
 
 	DiffRefs(ctx context.Context, repository, leftRef, rightRef string, after string, amount int) ([]*models.Diff, *models.Pagination, error)
-	Merge(ctx context.Context, repository, leftRef, rightRef string) (*models.MergeResult, error)
+	Merge(ctx context.Context, repository, leftRef, rightRef, strategy string) (*models.MergeResult, error)
 
 	DiffBranch(ctx context.Context, repository, branch string, after string, amount int) ([]*models.Diff, *models.Pagination, error)
 
@@ -396,6 +431,40 @@ func (c *client) DetachPolicyFromGroup(ctx context.Context, groupID, policyID st
 	return err
 }
 
+func (c *client) ListAuditEntries(ctx context.Context, filter *AuditFilter, after string, amount int) ([]*models.AuditEntry, *models.Pagination, error) {
+	params := &audit.ListAuditEntriesParams{
+		After:   swag.String(after),
+		Amount:  swag.Int64(int64(amount)),
+		Context: ctx,
+	}
+	if filter.User != "" {
+		params.User = swag.String(filter.User)
+	}
+	if filter.Action != "" {
+		params.Action = swag.String(filter.Action)
+	}
+	if filter.Repository != "" {
+		params.Repository = swag.String(filter.Repository)
+	}
+	if filter.Ref != "" {
+		params.Ref = swag.String(filter.Ref)
+	}
+	if filter.Result != "" {
+		params.Result = swag.String(filter.Result)
+	}
+	if filter.Since != 0 {
+		params.Since = swag.Int64(filter.Since)
+	}
+	if filter.Until != 0 {
+		params.Until = swag.Int64(filter.Until)
+	}
+	resp, err := c.remote.Audit.ListAuditEntries(params, c.auth)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.GetPayload().Results, resp.GetPayload().Pagination, nil
+}
+
 func (c *client) ListRepositories(ctx context.Context, after string, amount int) ([]*models.Repository, *models.Pagination, error) {
 	resp, err := c.remote.Repositories.ListRepositories(&repositories.ListRepositoriesParams{
 		After:   swag.String(after),
@@ -432,6 +501,55 @@ func (c *client) ListBranches(ctx context.Context, repository string, after stri
 	return resp.GetPayload().Results, resp.GetPayload().Pagination, nil
 }
 
+func (c *client) ListTags(ctx context.Context, repository string, after string, amount int) ([]*models.Tag, *models.Pagination, error) {
+	resp, err := c.remote.Tags.ListTags(&tags.ListTagsParams{
+		After:      swag.String(after),
+		Amount:     swag.Int64(int64(amount)),
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.GetPayload().Results, resp.GetPayload().Pagination, nil
+}
+
+func (c *client) GetTag(ctx context.Context, repository, tagID string) (*models.Tag, error) {
+	resp, err := c.remote.Tags.GetTag(&tags.GetTagParams{
+		Repository: repository,
+		Tag:        tagID,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+func (c *client) CreateTag(ctx context.Context, repository, tagID, ref string) (*models.Tag, error) {
+	resp, err := c.remote.Tags.CreateTag(&tags.CreateTagParams{
+		Repository: repository,
+		Tag: &models.TagCreation{
+			ID:  swag.String(tagID),
+			Ref: swag.String(ref),
+		},
+		Context: ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+func (c *client) DeleteTag(ctx context.Context, repository, tagID string) error {
+	_, err := c.remote.Tags.DeleteTag(&tags.DeleteTagParams{
+		Repository: repository,
+		Tag:        tagID,
+		Context:    ctx,
+	}, c.auth)
+	return err
+}
+
 func (c *client) CreateRepository(ctx context.Context, repository *models.RepositoryCreation) error {
 	_, err := c.remote.Repositories.CreateRepository(&repositories.CreateRepositoryParams{
 		Repository: repository,
@@ -491,6 +609,92 @@ func (c *client) RevertBranch(ctx context.Context, repository, branchID string,
 	return err
 }
 
+func (c *client) CherryPick(ctx context.Context, repository, ref, branchID string) (*models.Commit, error) {
+	resp, err := c.remote.Branches.CherryPick(&branches.CherryPickParams{
+		Branch:     branchID,
+		CherryPick: &models.CherryPickCreation{Ref: swag.String(ref)},
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	if err == nil {
+		return resp.GetPayload(), nil
+	}
+	if conflict, ok := err.(*branches.CherryPickConflict); ok {
+		return nil, &catalog.ConflictError{Paths: conflict.GetPayload().Paths}
+	}
+	return nil, err
+}
+
+func (c *client) GetBranchProtectionRules(ctx context.Context, repository string) ([]*models.BranchProtectionRule, error) {
+	resp, err := c.remote.Branches.GetBranchProtectionRules(&branches.GetBranchProtectionRulesParams{
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+func (c *client) SetBranchProtectionRule(ctx context.Context, repository string, rule *models.BranchProtectionRule) error {
+	_, err := c.remote.Branches.SetBranchProtectionRule(&branches.SetBranchProtectionRuleParams{
+		Repository: repository,
+		Rule:       rule,
+		Context:    ctx,
+	}, c.auth)
+	return err
+}
+
+func (c *client) DeleteBranchProtectionRule(ctx context.Context, repository, pattern string) error {
+	_, err := c.remote.Branches.DeleteBranchProtectionRule(&branches.DeleteBranchProtectionRuleParams{
+		Repository: repository,
+		Pattern:    pattern,
+		Context:    ctx,
+	}, c.auth)
+	return err
+}
+
+func (c *client) ListRuns(ctx context.Context, repository, branch, after string, amount int) ([]*models.ActionRun, *models.Pagination, error) {
+	params := &actions.ListRunsParams{
+		After:      swag.String(after),
+		Amount:     swag.Int64(int64(amount)),
+		Repository: repository,
+		Context:    ctx,
+	}
+	if branch != "" {
+		params.Branch = swag.String(branch)
+	}
+	resp, err := c.remote.Actions.ListRuns(params, c.auth)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.GetPayload().Results, resp.GetPayload().Pagination, nil
+}
+
+func (c *client) GetRun(ctx context.Context, repository, runID string) (*models.ActionRun, error) {
+	resp, err := c.remote.Actions.GetRun(&actions.GetRunParams{
+		Repository: repository,
+		RunID:      runID,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+func (c *client) ListRunHooks(ctx context.Context, repository, runID string) ([]*models.HookRun, error) {
+	resp, err := c.remote.Actions.ListRunHooks(&actions.ListRunHooksParams{
+		Repository: repository,
+		RunID:      runID,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
 func (c *client) SetContinuousExport(ctx context.Context, repository, branchID string, config *models.ContinuousExportConfiguration) error {
 	_, err := c.remote.Export.SetContinuousExport(&export.SetContinuousExportParams{
 		Branch:     branchID,
@@ -569,10 +773,11 @@ func (c *client) GetCommit(ctx context.Context, repository, commitID string) (*m
 	return commit.GetPayload(), nil
 }
 
-func (c *client) GetCommitLog(ctx context.Context, repository, branchID, after string, amount int) ([]*models.Commit, *models.Pagination, error) {
+func (c *client) GetCommitLog(ctx context.Context, repository, branchID, path, after string, amount int) ([]*models.Commit, *models.Pagination, error) {
 	resp, err := c.remote.Commits.GetBranchCommitLog(&commits.GetBranchCommitLogParams{
 		Amount:     swag.Int64(int64(amount)),
 		After:      swag.String(after),
+		Path:       swag.String(path),
 		Branch:     branchID,
 		Repository: repository,
 		Context:    ctx,
@@ -599,10 +804,11 @@ func (c *client) DiffRefs(ctx context.Context, repository, leftRef, rightRef, af
 	return payload.Results, payload.Pagination, nil
 }
 
-func (c *client) Merge(ctx context.Context, repository, leftRef, rightRef string) (*models.MergeResult, error) {
+func (c *client) Merge(ctx context.Context, repository, leftRef, rightRef, strategy string) (*models.MergeResult, error) {
 	statusOK, err := c.remote.Refs.MergeIntoBranch(&refs.MergeIntoBranchParams{
 		DestinationRef: leftRef,
 		SourceRef:      rightRef,
+		Merge:          &models.Merge{Strategy: strategy},
 		Repository:     repository,
 		Context:        ctx,
 	}, c.auth)
@@ -665,10 +871,25 @@ func (c *client) UpdateRetentionPolicy(ctx context.Context, repository string, p
 	return err
 }
 
-func (c *client) StatObject(ctx context.Context, repoID, ref, path string) (*models.ObjectStats, error) {
+func (c *client) StatObject(ctx context.Context, repoID, ref, path string, presign bool) (*models.ObjectStats, error) {
 	resp, err := c.remote.Objects.StatObject(&objects.StatObjectParams{
 		Ref:        ref,
 		Path:       path,
+		Presign:    swag.Bool(presign),
+		Repository: repoID,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+func (c *client) PresignObject(ctx context.Context, repoID, ref, path string, expires time.Duration) (*models.PresignedURL, error) {
+	resp, err := c.remote.Objects.PresignObject(&objects.PresignObjectParams{
+		Expires:    swag.Int64(int64(expires / time.Second)),
+		Path:       path,
+		Ref:        ref,
 		Repository: repoID,
 		Context:    ctx,
 	}, c.auth)
@@ -721,6 +942,47 @@ func (c *client) UploadObject(ctx context.Context, repoID, branchID, path string
 	return resp.GetPayload(), nil
 }
 
+func (c *client) GetPhysicalAddress(ctx context.Context, repoID, branchID, path string) (*models.StagingLocation, error) {
+	resp, err := c.remote.Objects.GetPhysicalAddress(&objects.GetPhysicalAddressParams{
+		Branch:     branchID,
+		Path:       path,
+		Repository: repoID,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+func (c *client) LinkPhysicalAddress(ctx context.Context, repoID, branchID, path string, metadata *models.StagingMetadata) (*models.ObjectStats, error) {
+	resp, err := c.remote.Objects.LinkPhysicalAddress(&objects.LinkPhysicalAddressParams{
+		Branch:     branchID,
+		Metadata:   metadata,
+		Path:       path,
+		Repository: repoID,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+func (c *client) CopyObject(ctx context.Context, repoID, branchID, path string, source *models.ObjectCopyCreation) (*models.ObjectStats, error) {
+	resp, err := c.remote.Objects.CopyObject(&objects.CopyObjectParams{
+		Branch:     branchID,
+		Path:       path,
+		Repository: repoID,
+		Source:     source,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
 func (c *client) DeleteObject(ctx context.Context, repository, branchID, path string) error {
 	_, err := c.remote.Objects.DeleteObject(&objects.DeleteObjectParams{
 		Branch:     branchID,
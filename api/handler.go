@@ -13,9 +13,11 @@ import (
 	"github.com/go-openapi/loads"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/treeverse/lakefs/actions"
 	"github.com/treeverse/lakefs/api/gen/models"
 	"github.com/treeverse/lakefs/api/gen/restapi"
 	"github.com/treeverse/lakefs/api/gen/restapi/operations"
+	"github.com/treeverse/lakefs/audit"
 	"github.com/treeverse/lakefs/auth"
 	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/catalog"
@@ -39,8 +41,16 @@ const (
 var (
 	ErrAuthenticationFailed    = openapierr.New(http.StatusUnauthorized, "error authenticating request")
 	ErrUnexpectedSigningMethod = errors.New("unexpected signing method")
+	ErrNoPresignCredentials    = errors.New("user has no credentials to presign with")
 )
 
+// S3GatewayConfig locates the S3 gateway served alongside the API, for URLs that the API
+// returns.
+type S3GatewayConfig struct {
+	Region     string
+	DomainName string
+}
+
 type Handler struct {
 	metadataManager auth.MetadataManager
 	cataloger       catalog.Cataloger
@@ -53,6 +63,9 @@ type Handler struct {
 	apiServer       *restapi.Server
 	handler         *http.ServeMux
 	dedupCleaner    *dedup.Cleaner
+	actions         *actions.Service
+	audit           audit.Service
+	s3Gateway       S3GatewayConfig
 	logger          logging.Logger
 }
 
@@ -65,6 +78,9 @@ func NewHandler(cataloger catalog.Cataloger,
 	migrator db.Migrator,
 	parade parade.Parade,
 	dedupCleaner *dedup.Cleaner,
+	actionsService *actions.Service,
+	auditService audit.Service,
+	s3Gateway S3GatewayConfig,
 	logger logging.Logger,
 ) http.Handler {
 	logger.Info("initialized OpenAPI server")
@@ -78,6 +94,9 @@ func NewHandler(cataloger catalog.Cataloger,
 		parade:          parade,
 		migrator:        migrator,
 		dedupCleaner:    dedupCleaner,
+		actions:         actionsService,
+		audit:           auditService,
+		s3Gateway:       s3Gateway,
 		logger:          logger,
 	}
 	s.buildAPI()
@@ -170,7 +189,7 @@ func (s *Handler) buildAPI() {
 	api.BasicAuthAuth = s.BasicAuth()
 	api.JwtTokenAuth = s.JwtTokenAuth()
 	// bind our handlers to the server
-	NewController(s.cataloger, s.authService, s.blockStore, s.stats, s.retention, s.parade, s.dedupCleaner, s.metadataManager, s.migrator, s.stats, s.logger).Configure(api)
+	NewController(s.cataloger, s.authService, s.blockStore, s.stats, s.retention, s.parade, s.dedupCleaner, s.actions, s.audit, s.metadataManager, s.migrator, s.stats, s.s3Gateway, s.logger).Configure(api)
 
 	// setup host/port
 	s.apiServer = restapi.NewServer(api)
@@ -182,9 +201,10 @@ func (s *Handler) buildAPI() {
 			logging.Fields{"service_name": LoggerServiceName},
 			promhttp.InstrumentHandlerCounter(requestCounter,
 				metricsMiddleware(api.Context(),
-					cookieToAPIHeader(
-						s.apiServer.GetHandler(),
-					)),
+					audit.Middleware(s.audit, audit.SourceAPI,
+						cookieToAPIHeader(
+							s.apiServer.GetHandler(),
+						))),
 			),
 		),
 
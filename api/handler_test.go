@@ -12,9 +12,11 @@ import (
 	"github.com/go-openapi/runtime"
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/ory/dockertest/v3"
+	"github.com/treeverse/lakefs/actions"
 	"github.com/treeverse/lakefs/api"
 	"github.com/treeverse/lakefs/api/gen/client"
 	"github.com/treeverse/lakefs/api/gen/client/repositories"
+	"github.com/treeverse/lakefs/audit"
 	"github.com/treeverse/lakefs/auth"
 	"github.com/treeverse/lakefs/auth/crypt"
 	authmodel "github.com/treeverse/lakefs/auth/model"
@@ -25,6 +27,7 @@ import (
 	"github.com/treeverse/lakefs/db"
 	dbparams "github.com/treeverse/lakefs/db/params"
 	"github.com/treeverse/lakefs/dedup"
+	"github.com/treeverse/lakefs/hooks"
 	"github.com/treeverse/lakefs/logging"
 	"github.com/treeverse/lakefs/retention"
 	"github.com/treeverse/lakefs/stats"
@@ -96,6 +99,8 @@ func getHandler(t *testing.T, blockstoreType string, opts ...testutil.GetDBOptio
 	migrator := db.NewDatabaseMigrator(dbparams.Database{ConnectionString: handlerDatabaseURI})
 
 	dedupCleaner := dedup.NewCleaner(blockAdapter, cataloger.DedupReportChannel())
+	actionsService := actions.NewService(conn, cataloger, blockAdapter, hooks.TargetPolicy{})
+	actionsService.Register(cataloger.Hooks())
 	t.Cleanup(func() {
 		// order is important - close cataloger channel before dedup
 		_ = cataloger.Close()
@@ -112,6 +117,9 @@ func getHandler(t *testing.T, blockstoreType string, opts ...testutil.GetDBOptio
 		migrator,
 		nil,
 		dedupCleaner,
+		actionsService,
+		audit.NewDBService(conn),
+		api.S3GatewayConfig{},
 		logging.Default(),
 	)
 
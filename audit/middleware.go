@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/treeverse/lakefs/httputil"
+	"github.com/treeverse/lakefs/logging"
+	"github.com/treeverse/lakefs/permissions"
+)
+
+type contextKey string
+
+const trackerContextKey contextKey = "audit_tracker"
+
+// readOnlyActionPrefixes start the names of permission actions that change nothing
+var readOnlyActionPrefixes = []string{"Read", "List", "Get"}
+
+// tracker collects the entries of a single request
+type tracker struct {
+	mu      sync.Mutex
+	entries []*Entry
+}
+
+// IsMutatingAction returns false if action is a permission action that only reads
+func IsMutatingAction(action string) bool {
+	name := action
+	if idx := strings.IndexByte(action, ':'); idx >= 0 {
+		name = action[idx+1:]
+	}
+	for _, prefix := range readOnlyActionPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseResource returns the repository and the branch or tag that an fs resource ARN points
+// at, if any
+func ParseResource(resource string) (repository, ref string) {
+	const repositoryPrefix = "arn:lakefs:fs:::repository/"
+	if !strings.HasPrefix(resource, repositoryPrefix) {
+		return "", ""
+	}
+	parts := strings.SplitN(strings.TrimPrefix(resource, repositoryPrefix), "/", 3)
+	repository = parts[0]
+	if len(parts) == 3 && (parts[1] == "branch" || parts[1] == "tag") {
+		ref = parts[2]
+	}
+	return repository, ref
+}
+
+// NewEntry returns the entry of an operation by user that required perms, or nil if the
+// operation changes nothing.  The entry is for the first mutating permission.  repository
+// and ref are used when the permission resource does not name them.
+func NewEntry(user string, perms []permissions.Permission, repository, ref string) *Entry {
+	for _, perm := range perms {
+		if !IsMutatingAction(perm.Action) {
+			continue
+		}
+		entry := &Entry{
+			User:     user,
+			Action:   perm.Action,
+			Resource: perm.Resource,
+		}
+		entry.Repository, entry.Ref = ParseResource(perm.Resource)
+		if entry.Repository == "" {
+			entry.Repository = repository
+		}
+		if entry.Ref == "" {
+			entry.Ref = ref
+		}
+		return entry
+	}
+	return nil
+}
+
+// Track adds entry to the audit log of the request of ctx.  The middleware serving the
+// request records it once the request completes, with the result of the request unless the
+// entry already has one.  Does nothing if entry is nil or ctx is not of a request served by
+// Middleware.
+func Track(ctx context.Context, entry *Entry) {
+	if entry == nil {
+		return
+	}
+	t, ok := ctx.Value(trackerContextKey).(*tracker)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, entry)
+}
+
+// Middleware records the entries tracked by requests to next that may change something in
+// service.  Requests with method GET or HEAD are never recorded.
+func Middleware(service Service, source string, next http.Handler) http.Handler {
+	if service == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		r, requestID := httputil.RequestID(r)
+		t := &tracker{}
+		r = r.WithContext(context.WithValue(r.Context(), trackerContextKey, t))
+		mrw := httputil.NewMetricResponseWriter(w)
+		next.ServeHTTP(mrw, r)
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		now := time.Now()
+		for _, entry := range t.entries {
+			entry.CreatedAt = now
+			entry.Source = source
+			entry.RequestID = requestID
+			entry.StatusCode = mrw.StatusCode
+			if entry.Result == "" {
+				entry.Result = ResultSuccess
+				if mrw.StatusCode >= http.StatusBadRequest {
+					entry.Result = ResultFailure
+				}
+			}
+			// record even if the client went away: the operation may have completed
+			if err := service.Record(context.Background(), entry); err != nil {
+				logging.FromContext(r.Context()).WithError(err).WithFields(logging.Fields{
+					"action":   entry.Action,
+					"resource": entry.Resource,
+				}).Error("failed to record audit log entry")
+			}
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package audit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/audit"
+	"github.com/treeverse/lakefs/permissions"
+)
+
+type serviceFake struct {
+	entries []*audit.Entry
+}
+
+func (s *serviceFake) Record(_ context.Context, entry *audit.Entry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *serviceFake) List(_ context.Context, _ *audit.ListParams) ([]*audit.Entry, bool, error) {
+	return s.entries, false, nil
+}
+
+func TestNewEntry(t *testing.T) {
+	tests := []struct {
+		name       string
+		perms      []permissions.Permission
+		repository string
+		ref        string
+		expected   *audit.Entry
+	}{
+		{
+			name:  "read_only",
+			perms: []permissions.Permission{{Action: permissions.ReadObjectAction, Resource: permissions.ObjectArn("repo", "a")}},
+		},
+		{
+			name: "branch",
+			perms: []permissions.Permission{
+				{Action: permissions.ReadBranchAction, Resource: permissions.BranchArn("repo", "feature")},
+				{Action: permissions.CreateCommitAction, Resource: permissions.BranchArn("repo", "master")},
+			},
+			expected: &audit.Entry{
+				User:       "user",
+				Action:     permissions.CreateCommitAction,
+				Resource:   permissions.BranchArn("repo", "master"),
+				Repository: "repo",
+				Ref:        "master",
+			},
+		},
+		{
+			name:  "object_with_ref",
+			perms: []permissions.Permission{{Action: permissions.WriteObjectAction, Resource: permissions.ObjectArn("repo", "a/b")}},
+			ref:   "master",
+			expected: &audit.Entry{
+				User:       "user",
+				Action:     permissions.WriteObjectAction,
+				Resource:   permissions.ObjectArn("repo", "a/b"),
+				Repository: "repo",
+				Ref:        "master",
+			},
+		},
+		{
+			name:  "auth",
+			perms: []permissions.Permission{{Action: permissions.CreateUserAction, Resource: permissions.UserArn("u1")}},
+			expected: &audit.Entry{
+				User:     "user",
+				Action:   permissions.CreateUserAction,
+				Resource: permissions.UserArn("u1"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := audit.NewEntry("user", tt.perms, tt.repository, tt.ref)
+			if diff := deep.Equal(entry, tt.expected); diff != nil {
+				t.Errorf("NewEntry() diff: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		statusCode     int
+		track          bool
+		expectedResult string
+	}{
+		{name: "success", method: http.MethodPost, statusCode: http.StatusCreated, track: true, expectedResult: audit.ResultSuccess},
+		{name: "failure", method: http.MethodDelete, statusCode: http.StatusForbidden, track: true, expectedResult: audit.ResultFailure},
+		{name: "not_tracked", method: http.MethodPut, statusCode: http.StatusOK},
+		{name: "get", method: http.MethodGet, statusCode: http.StatusOK, track: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &serviceFake{}
+			handler := audit.Middleware(service, audit.SourceAPI, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.track {
+					audit.Track(r.Context(), &audit.Entry{User: "user", Action: permissions.DeleteBranchAction})
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, "/api/v1/repositories", nil))
+
+			if tt.expectedResult == "" {
+				if len(service.entries) != 0 {
+					t.Fatalf("recorded %d entries, expected none", len(service.entries))
+				}
+				return
+			}
+			if len(service.entries) != 1 {
+				t.Fatalf("recorded %d entries, expected 1", len(service.entries))
+			}
+			entry := service.entries[0]
+			if entry.Result != tt.expectedResult {
+				t.Errorf("result %s, expected %s", entry.Result, tt.expectedResult)
+			}
+			if entry.StatusCode != tt.statusCode {
+				t.Errorf("status code %d, expected %d", entry.StatusCode, tt.statusCode)
+			}
+			if entry.Source != audit.SourceAPI {
+				t.Errorf("source %s, expected %s", entry.Source, audit.SourceAPI)
+			}
+			if entry.RequestID == "" {
+				t.Error("missing request ID")
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/db"
+)
+
+const (
+	SourceAPI       = "api"
+	SourceS3Gateway = "s3_gateway"
+
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+
+	ListMaxAmount = 1000
+)
+
+var psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+// Entry is a single operation recorded in the audit log
+type Entry struct {
+	ID         int64     `db:"id"`
+	CreatedAt  time.Time `db:"created_at"`
+	Source     string    `db:"source"`
+	User       string    `db:"user_id"`
+	Action     string    `db:"action"`
+	Resource   string    `db:"resource"`
+	Repository string    `db:"repository"`
+	Ref        string    `db:"ref"`
+	RequestID  string    `db:"request_id"`
+	StatusCode int       `db:"status_code"`
+	Result     string    `db:"result"`
+}
+
+// ListParams filters and paginates the entries returned by List.  Empty fields do not filter.
+type ListParams struct {
+	User       string
+	Action     string
+	Repository string
+	Ref        string
+	Result     string
+	Since      time.Time
+	Until      time.Time
+	// After is the ID of the last entry of the previous page
+	After int64
+	// Amount is the maximal number of entries to return
+	Amount int
+}
+
+// Service keeps the audit log.  Entries are never updated or deleted.
+type Service interface {
+	Record(ctx context.Context, entry *Entry) error
+	// List returns entries matching params, newest first, and whether more entries exist
+	List(ctx context.Context, params *ListParams) ([]*Entry, bool, error)
+}
+
+type DBService struct {
+	db db.Database
+}
+
+func NewDBService(db db.Database) *DBService {
+	return &DBService{db: db}
+}
+
+func (s *DBService) Record(ctx context.Context, entry *Entry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	return s.db.WithContext(ctx).Get(&entry.ID,
+		`INSERT INTO audit_log (created_at, source, user_id, action, resource, repository, ref, request_id, status_code, result)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`,
+		entry.CreatedAt, entry.Source, entry.User, entry.Action, entry.Resource, entry.Repository, entry.Ref,
+		entry.RequestID, entry.StatusCode, entry.Result)
+}
+
+func (s *DBService) List(ctx context.Context, params *ListParams) ([]*Entry, bool, error) {
+	amount := params.Amount
+	if amount <= 0 || amount > ListMaxAmount {
+		amount = ListMaxAmount
+	}
+	q := psql.Select("id", "created_at", "source", "user_id", "action", "resource", "repository", "ref", "request_id", "status_code", "result").
+		From("audit_log").
+		OrderBy("id DESC").
+		Limit(uint64(amount) + 1)
+	for column, value := range map[string]string{
+		"user_id":    params.User,
+		"action":     params.Action,
+		"repository": params.Repository,
+		"ref":        params.Ref,
+		"result":     params.Result,
+	} {
+		if value != "" {
+			q = q.Where(sq.Eq{column: value})
+		}
+	}
+	if !params.Since.IsZero() {
+		q = q.Where(sq.GtOrEq{"created_at": params.Since})
+	}
+	if !params.Until.IsZero() {
+		q = q.Where(sq.Lt{"created_at": params.Until})
+	}
+	if params.After > 0 {
+		q = q.Where(sq.Lt{"id": params.After})
+	}
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+	var entries []*Entry
+	if err := s.db.WithContext(ctx).Select(&entries, query, args...); err != nil {
+		return nil, false, err
+	}
+	hasMore := false
+	if len(entries) > amount {
+		entries = entries[:amount]
+		hasMore = true
+	}
+	return entries, hasMore, nil
+}
@@ -85,6 +85,10 @@ func SetupBaseGroups(authService Service, ts time.Time) error {
 						permissions.CreateBranchAction,
 						permissions.DeleteBranchAction,
 						permissions.CreateCommitAction,
+						permissions.ListTagsAction,
+						permissions.ReadTagAction,
+						permissions.CreateTagAction,
+						permissions.DeleteTagAction,
 					},
 					Resource: permissions.All,
 					Effect:   model.StatementEffectAllow,
@@ -112,6 +116,8 @@ func SetupBaseGroups(authService Service, ts time.Time) error {
 				{
 					Action: []string{
 						"retention:*",
+						"branches:*",
+						"actions:*",
 					},
 					Resource: permissions.All,
 					Effect:   model.StatementEffectAllow,
@@ -125,6 +131,8 @@ func SetupBaseGroups(authService Service, ts time.Time) error {
 				{
 					Action: []string{
 						"retention:Get*",
+						"branches:Get*",
+						"actions:Read*",
 					},
 					Resource: permissions.All,
 					Effect:   model.StatementEffectAllow,
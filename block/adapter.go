@@ -1,15 +1,31 @@
 package block
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/s3"
 )
 
+// DefaultPreSignExpiry is the validity of URLs returned by GetPreSignedURL.
+const DefaultPreSignExpiry = 15 * time.Minute
+
+var ErrOperationNotSupported = errors.New("operation not supported")
+
 type MultipartUploadCompletion struct{ Part []*s3.CompletedPart }
 
+// PreSignMode is the operation a presigned URL allows.
+type PreSignMode int
+
+const (
+	PreSignModeRead PreSignMode = iota
+	PreSignModeWrite
+)
+
 // ObjectPointer is a unique identifier of an object in the object
 // store: the store is a 1:1 mapping between pointers and objects.
 type ObjectPointer struct {
@@ -46,8 +62,26 @@ type CreateMultiPartUploadOpts struct {
 // actually reported.
 type Properties struct {
 	StorageClass *string
+	// Size of the object in bytes
+	Size int64
+	// ETag is the hex MD5 checksum of the object, if the underlying storage reports it
+	ETag string
+}
+
+// WalkEntry describes an object found by Walk.
+type WalkEntry struct {
+	// Identifier of the object, relative to the walked storage namespace.
+	Identifier   string
+	Size         int64
+	LastModified time.Time
+	// ETag of the object, if the storage reports it when listing.  Empty if it does not.
+	ETag string
 }
 
+// WalkFunc is called by Walk for each object found.  Returning an error stops the walk and
+// makes Walk return that error.
+type WalkFunc func(entry WalkEntry) error
+
 type Adapter interface {
 	InventoryGenerator
 	WithContext(ctx context.Context) Adapter
@@ -59,14 +93,47 @@ type Adapter interface {
 	Copy(sourceObj, destinationObj ObjectPointer) error
 	CreateMultiPartUpload(obj ObjectPointer, r *http.Request, opts CreateMultiPartUploadOpts) (string, error)
 	UploadPart(obj ObjectPointer, sizeBytes int64, reader io.Reader, uploadID string, partNumber int64) (string, error)
+	// UploadCopyPart uploads all of sourceObj as part partNumber of uploadID to
+	// destinationObj, and returns the ETag of the part.
+	UploadCopyPart(sourceObj, destinationObj ObjectPointer, uploadID string, partNumber int64) (string, error)
+	// UploadCopyPartRange is UploadCopyPart for the bytes of sourceObj from startPosition to
+	// endPosition, inclusive.
+	UploadCopyPartRange(sourceObj, destinationObj ObjectPointer, uploadID string, partNumber, startPosition, endPosition int64) (string, error)
 	AbortMultiPartUpload(obj ObjectPointer, uploadID string) error
 	CompleteMultiPartUpload(obj ObjectPointer, uploadID string, multipartList *MultipartUploadCompletion) (*string, int64, error)
+	// GetPreSignedURL returns a URL that allows the operation of mode on obj directly on the
+	// underlying storage, without credentials, for DefaultPreSignExpiry.  Adapters that cannot
+	// sign URLs return ErrOperationNotSupported.
+	GetPreSignedURL(obj ObjectPointer, mode PreSignMode) (string, error)
+	// Walk calls walkFn for every object of prefix.StorageNamespace whose identifier starts
+	// with prefix.Identifier.
+	Walk(prefix ObjectPointer, walkFn WalkFunc) error
 	// ValidateConfiguration validates an appropriate bucket
 	// configuration and returns a validation error or nil.
 	ValidateConfiguration(storageNamespace string) error
 	BlockstoreType() string
 }
 
+// EnsureStorageNamespaceRW checks that adapter can write to and read from storageNamespace.
+func EnsureStorageNamespaceRW(adapter Adapter, storageNamespace string) error {
+	const (
+		dummyKey  = "dummy"
+		dummyData = "this is dummy data - created by lakeFS in order to check accessibility "
+	)
+
+	err := adapter.Put(ObjectPointer{StorageNamespace: storageNamespace, Identifier: dummyKey}, int64(len(dummyData)), bytes.NewReader([]byte(dummyData)), PutOpts{})
+	if err != nil {
+		return err
+	}
+
+	_, err = adapter.Get(ObjectPointer{StorageNamespace: storageNamespace, Identifier: dummyKey}, int64(len(dummyData)))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 type UploadIDTranslator interface {
 	SetUploadID(uploadID string) string
 	TranslateUploadID(simulationID string) string
@@ -0,0 +1,571 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/rs/xid"
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/logging"
+)
+
+const (
+	BlockstoreType = "azure"
+
+	// DefaultRetryRequests is the number of times a read retries a broken download
+	DefaultRetryRequests = 3
+
+	// DefaultPartBlockSize is the size of the blocks parts of multipart uploads are staged in.
+	// Each upload of a part holds a single block in memory.
+	DefaultPartBlockSize = 16 * 1024 * 1024
+
+	// copyPollInterval is the time between checks of the status of a pending copy
+	copyPollInterval = 500 * time.Millisecond
+
+	// kinds of blocks staged by an upload of a part
+	dataBlockKind = 'd'
+	etagBlockKind = 'e'
+
+	// lengths of the fields of block IDs following the upload ID
+	partNumberLength   = 5
+	partUploadIDLength = 20 // an xid
+	blockKindLength    = 1
+	blockPayloadLength = md5.Size
+)
+
+var (
+	ErrNotImplemented    = errors.New("not implemented")
+	ErrMissingPartNumber = errors.New("missing part number")
+	ErrMissingPartETag   = errors.New("missing part ETag")
+	ErrInvalidPartETag   = errors.New("invalid part ETag")
+	ErrCopyFailed        = errors.New("copy failed")
+	ErrPartNotFound      = errors.New("part not found")
+)
+
+// Adapter stores objects as block blobs in containers of an Azure Blob Storage account.  The
+// storage namespace of an object is its container.
+//
+// Multipart uploads stage each part as uncommitted blocks of the target blob, and commit the
+// block list to complete.  Uncommitted blocks of aborted uploads are garbage collected by
+// Azure.
+type Adapter struct {
+	ctx                context.Context
+	serviceURL         azblob.ServiceURL
+	uploadIDTranslator block.UploadIDTranslator
+	partBlockSize      int
+	storageAccount     string
+}
+
+func WithContext(ctx context.Context) func(a *Adapter) {
+	return func(a *Adapter) {
+		a.ctx = ctx
+	}
+}
+
+func WithTranslator(t block.UploadIDTranslator) func(a *Adapter) {
+	return func(a *Adapter) {
+		a.uploadIDTranslator = t
+	}
+}
+
+// WithPartBlockSize sets the size of the blocks parts of multipart uploads are staged in
+func WithPartBlockSize(size int) func(a *Adapter) {
+	return func(a *Adapter) {
+		a.partBlockSize = size
+	}
+}
+
+// WithStorageAccount sets the storage account of the service.  Storage namespaces that name
+// another account in their https URL are rejected.
+func WithStorageAccount(account string) func(a *Adapter) {
+	return func(a *Adapter) {
+		a.storageAccount = account
+	}
+}
+
+func NewAdapter(serviceURL azblob.ServiceURL, opts ...func(a *Adapter)) *Adapter {
+	a := &Adapter{
+		ctx:                context.Background(),
+		serviceURL:         serviceURL,
+		uploadIDTranslator: &block.NoOpTranslator{},
+		partBlockSize:      DefaultPartBlockSize,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func (a *Adapter) WithContext(ctx context.Context) block.Adapter {
+	return &Adapter{
+		ctx:                ctx,
+		serviceURL:         a.serviceURL,
+		uploadIDTranslator: a.uploadIDTranslator,
+		partBlockSize:      a.partBlockSize,
+		storageAccount:     a.storageAccount,
+	}
+}
+
+func (a *Adapter) log() logging.Logger {
+	return logging.FromContext(a.ctx)
+}
+
+// resolveNamespace returns the qualified key of obj, which must be stored in Azure under the
+// storage account of the adapter, if it has one.
+func (a *Adapter) resolveNamespace(obj block.ObjectPointer) (block.QualifiedKey, error) {
+	qualifiedKey, err := block.ResolveNamespace(obj.StorageNamespace, obj.Identifier)
+	if err != nil {
+		return qualifiedKey, err
+	}
+	if qualifiedKey.StorageType != block.StorageTypeAzure {
+		return qualifiedKey, block.ErrInvalidNamespace
+	}
+	if qualifiedKey.StorageAccount != "" && a.storageAccount != "" && !strings.EqualFold(qualifiedKey.StorageAccount, a.storageAccount) {
+		return qualifiedKey, fmt.Errorf("%w: storage account %s is not the configured %s", block.ErrInvalidNamespace, qualifiedKey.StorageAccount, a.storageAccount)
+	}
+	return qualifiedKey, nil
+}
+
+func (a *Adapter) blockBlobURL(qualifiedKey block.QualifiedKey) azblob.BlockBlobURL {
+	return a.serviceURL.
+		NewContainerURL(qualifiedKey.StorageNamespace).
+		NewBlockBlobURL(qualifiedKey.Key)
+}
+
+func (a *Adapter) Put(obj block.ObjectPointer, sizeBytes int64, reader io.Reader, _ block.PutOpts) error {
+	var err error
+	defer reportMetrics("Put", time.Now(), &sizeBytes, &err)
+	qualifiedKey, err := a.resolveNamespace(obj)
+	if err != nil {
+		return err
+	}
+	_, err = azblob.UploadStreamToBlockBlob(a.ctx, reader, a.blockBlobURL(qualifiedKey), azblob.UploadStreamToBlockBlobOptions{})
+	if err != nil {
+		return fmt.Errorf("UploadStreamToBlockBlob: %w", err)
+	}
+	return nil
+}
+
+func (a *Adapter) Get(obj block.ObjectPointer, _ int64) (io.ReadCloser, error) {
+	return a.download("Get", obj, 0, azblob.CountToEnd)
+}
+
+func (a *Adapter) GetRange(obj block.ObjectPointer, startPosition int64, endPosition int64) (io.ReadCloser, error) {
+	return a.download("GetRange", obj, startPosition, endPosition-startPosition+1)
+}
+
+func (a *Adapter) download(operation string, obj block.ObjectPointer, offset, count int64) (io.ReadCloser, error) {
+	var err error
+	defer reportMetrics(operation, time.Now(), nil, &err)
+	qualifiedKey, err := a.resolveNamespace(obj)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.blockBlobURL(qualifiedKey).Download(a.ctx, offset, count, azblob.BlobAccessConditions{}, false, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(azblob.RetryReaderOptions{MaxRetryRequests: DefaultRetryRequests}), nil
+}
+
+func (a *Adapter) GetProperties(obj block.ObjectPointer) (block.Properties, error) {
+	var err error
+	defer reportMetrics("GetProperties", time.Now(), nil, &err)
+	var props block.Properties
+	qualifiedKey, err := a.resolveNamespace(obj)
+	if err != nil {
+		return props, err
+	}
+	resp, err := a.blockBlobURL(qualifiedKey).GetProperties(a.ctx, azblob.BlobAccessConditions{}, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		return props, err
+	}
+	if tier := resp.AccessTier(); tier != "" {
+		props.StorageClass = &tier
+	}
+	props.Size = resp.ContentLength()
+	// blobs uploaded in blocks have no MD5
+	if contentMD5 := resp.ContentMD5(); len(contentMD5) > 0 {
+		props.ETag = hex.EncodeToString(contentMD5)
+	}
+	return props, nil
+}
+
+func (a *Adapter) GetPreSignedURL(_ block.ObjectPointer, _ block.PreSignMode) (string, error) {
+	return "", block.ErrOperationNotSupported
+}
+
+func (a *Adapter) Remove(obj block.ObjectPointer) error {
+	var err error
+	defer reportMetrics("Remove", time.Now(), nil, &err)
+	qualifiedKey, err := a.resolveNamespace(obj)
+	if err != nil {
+		return err
+	}
+	_, err = a.blockBlobURL(qualifiedKey).Delete(a.ctx, azblob.DeleteSnapshotsOptionInclude, azblob.BlobAccessConditions{})
+	if err != nil {
+		return fmt.Errorf("Blob(%q).Delete: %w", qualifiedKey.Key, err)
+	}
+	return nil
+}
+
+func (a *Adapter) Walk(prefix block.ObjectPointer, walkFn block.WalkFunc) error {
+	var err error
+	defer reportMetrics("Walk", time.Now(), nil, &err)
+	qualifiedKey, err := a.resolveNamespace(prefix)
+	if err != nil {
+		return err
+	}
+	containerURL := a.serviceURL.NewContainerURL(qualifiedKey.StorageNamespace)
+	for marker := (azblob.Marker{}); marker.NotDone(); {
+		var resp *azblob.ListBlobsFlatSegmentResponse
+		resp, err = containerURL.ListBlobsFlatSegment(a.ctx, marker, azblob.ListBlobsSegmentOptions{Prefix: qualifiedKey.Key})
+		if err != nil {
+			return fmt.Errorf("listing container '%s' prefix '%s': %w", qualifiedKey.StorageNamespace, qualifiedKey.Key, err)
+		}
+		for _, item := range resp.Segment.BlobItems {
+			var id string
+			id, err = block.RelativeIdentifier(prefix.StorageNamespace, item.Name)
+			if err != nil {
+				return err
+			}
+			var size int64
+			if item.Properties.ContentLength != nil {
+				size = *item.Properties.ContentLength
+			}
+			etag := strings.Trim(string(item.Properties.Etag), `"`)
+			if len(item.Properties.ContentMD5) > 0 {
+				etag = hex.EncodeToString(item.Properties.ContentMD5)
+			}
+			err = walkFn(block.WalkEntry{
+				Identifier:   id,
+				Size:         size,
+				LastModified: item.Properties.LastModified,
+				ETag:         etag,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		marker = resp.NextMarker
+	}
+	return nil
+}
+
+func (a *Adapter) Copy(sourceObj, destinationObj block.ObjectPointer) error {
+	var err error
+	defer reportMetrics("Copy", time.Now(), nil, &err)
+	qualifiedDestinationKey, err := a.resolveNamespace(destinationObj)
+	if err != nil {
+		return fmt.Errorf("resolve destination: %w", err)
+	}
+	qualifiedSourceKey, err := a.resolveNamespace(sourceObj)
+	if err != nil {
+		return fmt.Errorf("resolve source: %w", err)
+	}
+	destinationURL := a.blockBlobURL(qualifiedDestinationKey)
+	resp, err := destinationURL.StartCopyFromURL(a.ctx, a.blockBlobURL(qualifiedSourceKey).URL(),
+		azblob.Metadata{}, azblob.ModifiedAccessConditions{}, azblob.BlobAccessConditions{}, azblob.DefaultAccessTier, nil)
+	if err != nil {
+		return fmt.Errorf("StartCopyFromURL: %w", err)
+	}
+	// copies within an account usually complete immediately, but may be left pending
+	status := resp.CopyStatus()
+	for status == azblob.CopyStatusPending {
+		select {
+		case <-a.ctx.Done():
+			err = a.ctx.Err()
+			return err
+		case <-time.After(copyPollInterval):
+		}
+		var props *azblob.BlobGetPropertiesResponse
+		props, err = destinationURL.GetProperties(a.ctx, azblob.BlobAccessConditions{}, azblob.ClientProvidedKeyOptions{})
+		if err != nil {
+			return fmt.Errorf("copy status: %w", err)
+		}
+		status = props.CopyStatus()
+	}
+	if status != azblob.CopyStatusSuccess {
+		err = fmt.Errorf("%w: status %s", ErrCopyFailed, status)
+		return err
+	}
+	return nil
+}
+
+func (a *Adapter) CreateMultiPartUpload(obj block.ObjectPointer, _ *http.Request, _ block.CreateMultiPartUploadOpts) (string, error) {
+	var err error
+	defer reportMetrics("CreateMultiPartUpload", time.Now(), nil, &err)
+	qualifiedKey, err := a.resolveNamespace(obj)
+	if err != nil {
+		return "", err
+	}
+	// nothing is stored until the first part: parts are staged on the target blob itself
+	uploadID := a.uploadIDTranslator.SetUploadID(xid.New().String())
+	a.log().WithFields(logging.Fields{
+		"upload_id":     uploadID,
+		"qualified_ns":  qualifiedKey.StorageNamespace,
+		"qualified_key": qualifiedKey.Key,
+		"key":           obj.Identifier,
+	}).Debug("created multipart upload")
+	return uploadID, nil
+}
+
+func (a *Adapter) UploadPart(obj block.ObjectPointer, sizeBytes int64, reader io.Reader, uploadID string, partNumber int64) (string, error) {
+	var err error
+	defer reportMetrics("UploadPart", time.Now(), &sizeBytes, &err)
+	qualifiedKey, err := a.resolveNamespace(obj)
+	if err != nil {
+		return "", err
+	}
+	uploadID = a.uploadIDTranslator.TranslateUploadID(uploadID)
+	blobURL := a.blockBlobURL(qualifiedKey)
+	// staging a block requires a seekable body so that the pipeline can retry it: the part is
+	// read and staged one block at a time, under block IDs of this upload of the part
+	partUploadID := xid.New().String()
+	partHash := md5.New() //nolint:gosec
+	bufSize := a.partBlockSize
+	if sizeBytes > 0 && sizeBytes < int64(bufSize) {
+		bufSize = int(sizeBytes)
+	}
+	buf := make([]byte, bufSize)
+	for index := 0; ; index++ {
+		n, readErr := io.ReadFull(reader, buf)
+		if n > 0 {
+			data := buf[:n]
+			_, _ = partHash.Write(data)
+			md5Sum := md5.Sum(data) //nolint:gosec
+			blockID := formatBlockID(uploadID, partNumber, partUploadID, dataBlockKind, []byte(fmt.Sprintf("%0*d", blockPayloadLength, index)))
+			_, err = blobURL.StageBlock(a.ctx, blockID, bytes.NewReader(data), azblob.LeaseAccessConditions{}, md5Sum[:], azblob.ClientProvidedKeyOptions{})
+			if err != nil {
+				return "", fmt.Errorf("StageBlock: %w", err)
+			}
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			err = fmt.Errorf("read part: %w", readErr)
+			return "", err
+		}
+	}
+	md5Sum := partHash.Sum(nil)
+	etag := hex.EncodeToString(md5Sum)
+	// the ETag block, staged last, holds the MD5 of the part in its ID and marks this upload
+	// of the part complete.  It is never committed.
+	blockID := formatBlockID(uploadID, partNumber, partUploadID, etagBlockKind, md5Sum)
+	_, err = blobURL.StageBlock(a.ctx, blockID, bytes.NewReader(md5Sum), azblob.LeaseAccessConditions{}, nil, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		return "", fmt.Errorf("StageBlock: %w", err)
+	}
+	return etag, nil
+}
+
+func (a *Adapter) UploadCopyPart(sourceObj, destinationObj block.ObjectPointer, uploadID string, partNumber int64) (string, error) {
+	r, err := a.Get(sourceObj, 0)
+	if err != nil {
+		return "", fmt.Errorf("Get: %w", err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return a.UploadPart(destinationObj, -1, r, uploadID, partNumber)
+}
+
+func (a *Adapter) UploadCopyPartRange(sourceObj, destinationObj block.ObjectPointer, uploadID string, partNumber, startPosition, endPosition int64) (string, error) {
+	r, err := a.GetRange(sourceObj, startPosition, endPosition)
+	if err != nil {
+		return "", fmt.Errorf("GetRange: %w", err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return a.UploadPart(destinationObj, endPosition-startPosition+1, r, uploadID, partNumber)
+}
+
+func (a *Adapter) AbortMultiPartUpload(obj block.ObjectPointer, uploadID string) error {
+	var err error
+	defer reportMetrics("AbortMultiPartUpload", time.Now(), nil, &err)
+	qualifiedKey, err := a.resolveNamespace(obj)
+	if err != nil {
+		return err
+	}
+	uploadID = a.uploadIDTranslator.TranslateUploadID(uploadID)
+	// Azure offers no way to discard uncommitted blocks; it removes them a week after they
+	// were staged
+	a.uploadIDTranslator.RemoveUploadID(uploadID)
+	a.log().WithFields(logging.Fields{
+		"upload_id":     uploadID,
+		"qualified_ns":  qualifiedKey.StorageNamespace,
+		"qualified_key": qualifiedKey.Key,
+		"key":           obj.Identifier,
+	}).Debug("aborted multipart upload")
+	return nil
+}
+
+func (a *Adapter) CompleteMultiPartUpload(obj block.ObjectPointer, uploadID string, multipartList *block.MultipartUploadCompletion) (*string, int64, error) {
+	var err error
+	defer reportMetrics("CompleteMultiPartUpload", time.Now(), nil, &err)
+	qualifiedKey, err := a.resolveNamespace(obj)
+	if err != nil {
+		return nil, 0, err
+	}
+	uploadID = a.uploadIDTranslator.TranslateUploadID(uploadID)
+	lg := a.log().WithFields(logging.Fields{
+		"upload_id":     uploadID,
+		"qualified_ns":  qualifiedKey.StorageNamespace,
+		"qualified_key": qualifiedKey.Key,
+		"key":           obj.Identifier,
+	})
+
+	etags := make([]string, len(multipartList.Part))
+	for i, p := range multipartList.Part {
+		if p.PartNumber == nil {
+			err = fmt.Errorf("invalid part at position %d: %w", i, ErrMissingPartNumber)
+			return nil, 0, err
+		}
+		if p.ETag == nil {
+			err = fmt.Errorf("invalid part at position %d: %w", i, ErrMissingPartETag)
+			return nil, 0, err
+		}
+		etags[i] = strings.Trim(*p.ETag, `"`)
+	}
+	etag, err := computeETag(etags)
+	if err != nil {
+		return nil, 0, err
+	}
+	blobURL := a.blockBlobURL(qualifiedKey)
+	blockList, err := blobURL.GetBlockList(a.ctx, azblob.BlockListUncommitted, azblob.LeaseAccessConditions{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("GetBlockList: %w", err)
+	}
+	// ETag blocks name the ETag of their part, so committing fails unless the parts match the
+	// request
+	parts := stagedParts(uploadID, blockList.UncommittedBlocks)
+	var blockIDs []string
+	for i, p := range multipartList.Part {
+		partBlockIDs, ok := parts[stagedPart{number: *p.PartNumber, etag: etags[i]}]
+		if !ok {
+			err = fmt.Errorf("part %d with ETag %s: %w", *p.PartNumber, etags[i], ErrPartNotFound)
+			return nil, 0, err
+		}
+		blockIDs = append(blockIDs, partBlockIDs...)
+	}
+	_, err = blobURL.CommitBlockList(a.ctx, blockIDs, azblob.BlobHTTPHeaders{}, azblob.Metadata{}, azblob.BlobAccessConditions{},
+		azblob.DefaultAccessTier, nil, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		lg.WithError(err).Error("CompleteMultipartUpload failed")
+		return nil, 0, fmt.Errorf("CommitBlockList: %w", err)
+	}
+	props, err := blobURL.GetProperties(a.ctx, azblob.BlobAccessConditions{}, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("GetProperties: %w", err)
+	}
+	a.uploadIDTranslator.RemoveUploadID(uploadID)
+	lg.Debug("completed multipart upload")
+	return &etag, props.ContentLength(), nil
+}
+
+func (a *Adapter) ValidateConfiguration(_ string) error {
+	return nil
+}
+
+func (a *Adapter) GenerateInventory(_ context.Context, _ logging.Logger, _ string, _ bool, _ []string) (block.Inventory, error) {
+	return nil, fmt.Errorf("inventory %w", ErrNotImplemented)
+}
+
+func (a *Adapter) BlockstoreType() string {
+	return BlockstoreType
+}
+
+// formatBlockID returns the base64 ID of a block staged by an upload of a part.  payload is the
+// zero padded index of data blocks, or the MD5 of the part for ETag blocks.  All block IDs of
+// a blob must have the same length, of at most 64 bytes: xid upload IDs and the fields
+// following them have fixed lengths.
+func formatBlockID(uploadID string, partNumber int64, partUploadID string, kind byte, payload []byte) string {
+	id := fmt.Sprintf("%s%0*d%s%c%s", uploadID, partNumberLength, partNumber, partUploadID, kind, payload)
+	return base64.StdEncoding.EncodeToString([]byte(id))
+}
+
+// stagedPart identifies a part of a multipart upload staged in full
+type stagedPart struct {
+	number int64
+	etag   string
+}
+
+// stagedParts returns the IDs of the data blocks, in order, of each part of uploadID staged in
+// full in blocks, the uncommitted blocks of a blob.  A part staged by several uploads with the
+// same ETag holds the same data, any of them is returned.
+func stagedParts(uploadID string, blocks []azblob.Block) map[stagedPart][]string {
+	type partUpload struct {
+		number int64
+		id     string
+	}
+	type dataBlock struct {
+		index string
+		id    string
+	}
+	dataBlocks := make(map[partUpload][]dataBlock)
+	etags := make(map[partUpload]string)
+	for _, b := range blocks {
+		decoded, err := base64.StdEncoding.DecodeString(b.Name)
+		if err != nil || !bytes.HasPrefix(decoded, []byte(uploadID)) {
+			continue
+		}
+		fields := decoded[len(uploadID):]
+		if len(fields) != partNumberLength+partUploadIDLength+blockKindLength+blockPayloadLength {
+			continue
+		}
+		number, err := strconv.ParseInt(string(fields[:partNumberLength]), 10, 64)
+		if err != nil {
+			continue
+		}
+		fields = fields[partNumberLength:]
+		upload := partUpload{number: number, id: string(fields[:partUploadIDLength])}
+		fields = fields[partUploadIDLength:]
+		kind, payload := fields[0], fields[blockKindLength:]
+		switch kind {
+		case dataBlockKind:
+			dataBlocks[upload] = append(dataBlocks[upload], dataBlock{index: string(payload), id: b.Name})
+		case etagBlockKind:
+			etags[upload] = hex.EncodeToString(payload)
+		}
+	}
+	parts := make(map[stagedPart][]string, len(etags))
+	for upload, etag := range etags {
+		partBlocks := dataBlocks[upload]
+		// zero padded indexes order the data blocks of an upload of a part
+		sort.Slice(partBlocks, func(i, j int) bool { return partBlocks[i].index < partBlocks[j].index })
+		blockIDs := make([]string, len(partBlocks))
+		for i, b := range partBlocks {
+			blockIDs[i] = b.id
+		}
+		parts[stagedPart{number: upload.number, etag: etag}] = blockIDs
+	}
+	return parts
+}
+
+// computeETag returns the S3 style ETag of an object uploaded in parts with etags
+func computeETag(etags []string) (string, error) {
+	var b []byte
+	for i, etag := range etags {
+		sum, err := hex.DecodeString(etag)
+		if err != nil {
+			return "", fmt.Errorf("part at position %d: %w", i, ErrInvalidPartETag)
+		}
+		b = append(b, sum...)
+	}
+	md5res := md5.Sum(b) //nolint:gosec
+	return hex.EncodeToString(md5res[:]) + "-" + strconv.Itoa(len(etags)), nil
+}
@@ -0,0 +1,255 @@
+package azure_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/block/azure"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func makePointer(t *testing.T, path string) block.ObjectPointer {
+	t.Helper()
+	// each test keeps its objects under its own path of the test container
+	return block.ObjectPointer{
+		StorageNamespace: "azure://" + containerName + "/" + strings.SplitN(t.Name(), "/", 2)[0],
+		Identifier:       path,
+	}
+}
+
+func readAll(t *testing.T, a block.Adapter, pointer block.ObjectPointer) string {
+	t.Helper()
+	reader, err := a.Get(pointer, 0)
+	testutil.MustDo(t, "Get", err)
+	defer func() { _ = reader.Close() }()
+	got, err := ioutil.ReadAll(reader)
+	testutil.MustDo(t, "ReadAll", err)
+	return string(got)
+}
+
+func TestAzurePutGet(t *testing.T) {
+	a := azure.NewAdapter(serviceURL)
+	cases := []struct {
+		name string
+		path string
+	}{
+		{"simple", "abc"},
+		{"nested", "foo/bar"},
+	}
+
+	contents := "def"
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pointer := makePointer(t, c.path)
+			testutil.MustDo(t, "Put", a.Put(pointer, int64(len(contents)), strings.NewReader(contents), block.PutOpts{}))
+			if got := readAll(t, a, pointer); got != contents {
+				t.Errorf("expected to read \"%s\" as written, got \"%s\"", contents, got)
+			}
+		})
+	}
+}
+
+func TestAzureGetRange(t *testing.T) {
+	a := azure.NewAdapter(serviceURL)
+	pointer := makePointer(t, "range")
+	contents := "0123456789"
+	testutil.MustDo(t, "Put", a.Put(pointer, int64(len(contents)), strings.NewReader(contents), block.PutOpts{}))
+
+	reader, err := a.GetRange(pointer, 2, 5)
+	testutil.MustDo(t, "GetRange", err)
+	defer func() { _ = reader.Close() }()
+	got, err := ioutil.ReadAll(reader)
+	testutil.MustDo(t, "ReadAll", err)
+	if string(got) != "2345" {
+		t.Errorf("expected to read range \"2345\", got \"%s\"", string(got))
+	}
+}
+
+func TestAzureRemove(t *testing.T) {
+	a := azure.NewAdapter(serviceURL)
+	pointer := makePointer(t, "removed")
+	testutil.MustDo(t, "Put", a.Put(pointer, 4, strings.NewReader("data"), block.PutOpts{}))
+	testutil.MustDo(t, "Remove", a.Remove(pointer))
+	if _, err := a.GetProperties(pointer); err == nil {
+		t.Error("expected GetProperties of removed object to fail")
+	}
+}
+
+func TestAzureMultipartUpload(t *testing.T) {
+	a := azure.NewAdapter(serviceURL)
+	cases := []struct {
+		name     string
+		path     string
+		partData []string
+	}{
+		{"simple", "abc", []string{"one ", "two ", "three"}},
+		{"nested", "foo/bar", []string{"one ", "two ", "three"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pointer := makePointer(t, c.path)
+			uploadID, err := a.CreateMultiPartUpload(pointer, nil, block.CreateMultiPartUploadOpts{})
+			testutil.MustDo(t, "CreateMultiPartUpload", err)
+			parts := make([]*s3.CompletedPart, 0)
+			for i, content := range c.partData {
+				partNumber := int64(i + 1)
+				etag, err := a.UploadPart(pointer, int64(len(content)), strings.NewReader(content), uploadID, partNumber)
+				testutil.MustDo(t, "UploadPart", err)
+				parts = append(parts, &s3.CompletedPart{
+					ETag:       aws.String(`"` + etag + `"`),
+					PartNumber: aws.Int64(partNumber),
+				})
+			}
+			etag, size, err := a.CompleteMultiPartUpload(pointer, uploadID, &block.MultipartUploadCompletion{
+				Part: parts,
+			})
+			testutil.MustDo(t, "CompleteMultiPartUpload", err)
+			expected := strings.Join(c.partData, "")
+			if size != int64(len(expected)) {
+				t.Errorf("expected size %d, got %d", len(expected), size)
+			}
+			if !strings.HasSuffix(*etag, "-3") {
+				t.Errorf("expected multipart ETag of 3 parts, got %s", *etag)
+			}
+			if got := readAll(t, a, pointer); got != expected {
+				t.Errorf("expected to read \"%s\" as written, got \"%s\"", expected, got)
+			}
+		})
+	}
+}
+
+func TestAzureMultipartUploadBlocks(t *testing.T) {
+	// parts are staged in blocks of 4 bytes
+	a := azure.NewAdapter(serviceURL, azure.WithPartBlockSize(4))
+	pointer := makePointer(t, "blocks")
+	uploadID, err := a.CreateMultiPartUpload(pointer, nil, block.CreateMultiPartUploadOpts{})
+	testutil.MustDo(t, "CreateMultiPartUpload", err)
+	uploadPart := func(partNumber int64, content string) *s3.CompletedPart {
+		etag, err := a.UploadPart(pointer, int64(len(content)), strings.NewReader(content), uploadID, partNumber)
+		testutil.MustDo(t, "UploadPart", err)
+		return &s3.CompletedPart{
+			ETag:       aws.String(`"` + etag + `"`),
+			PartNumber: aws.Int64(partNumber),
+		}
+	}
+	// the first part is uploaded again, only its last upload is completed
+	uploadPart(1, "first upload of one ")
+	parts := []*s3.CompletedPart{
+		uploadPart(1, "one, "),
+		uploadPart(2, "two and "),
+		uploadPart(3, ""),
+		uploadPart(4, "three"),
+	}
+	_, size, err := a.CompleteMultiPartUpload(pointer, uploadID, &block.MultipartUploadCompletion{
+		Part: parts,
+	})
+	testutil.MustDo(t, "CompleteMultiPartUpload", err)
+	const expected = "one, two and three"
+	if size != int64(len(expected)) {
+		t.Errorf("expected size %d, got %d", len(expected), size)
+	}
+	if got := readAll(t, a, pointer); got != expected {
+		t.Errorf("expected to read \"%s\" as written, got \"%s\"", expected, got)
+	}
+}
+
+func TestAzureMultipartUploadMismatchETag(t *testing.T) {
+	a := azure.NewAdapter(serviceURL)
+	pointer := makePointer(t, "mismatch")
+	uploadID, err := a.CreateMultiPartUpload(pointer, nil, block.CreateMultiPartUploadOpts{})
+	testutil.MustDo(t, "CreateMultiPartUpload", err)
+	_, err = a.UploadPart(pointer, 4, strings.NewReader("data"), uploadID, 1)
+	testutil.MustDo(t, "UploadPart", err)
+	_, _, err = a.CompleteMultiPartUpload(pointer, uploadID, &block.MultipartUploadCompletion{
+		Part: []*s3.CompletedPart{{
+			ETag:       aws.String("0123456789abcdef0123456789abcdef"),
+			PartNumber: aws.Int64(1),
+		}},
+	})
+	if !errors.Is(err, azure.ErrPartNotFound) {
+		t.Fatalf("expected CompleteMultiPartUpload with a wrong part ETag to fail with %s, got %v", azure.ErrPartNotFound, err)
+	}
+
+	_, _, err = a.CompleteMultiPartUpload(pointer, uploadID, &block.MultipartUploadCompletion{
+		Part: []*s3.CompletedPart{{
+			ETag:       aws.String("not hex"),
+			PartNumber: aws.Int64(1),
+		}},
+	})
+	if !errors.Is(err, azure.ErrInvalidPartETag) {
+		t.Fatalf("expected CompleteMultiPartUpload with an invalid part ETag to fail with %s, got %v", azure.ErrInvalidPartETag, err)
+	}
+}
+
+func TestAzureCopy(t *testing.T) {
+	a := azure.NewAdapter(serviceURL)
+	contents := "foo bar baz quux"
+	testutil.MustDo(t, "Put", a.Put(makePointer(t, "src"), int64(len(contents)), strings.NewReader(contents), block.PutOpts{}))
+
+	testutil.MustDo(t, "Copy", a.Copy(makePointer(t, "src"), makePointer(t, "export/to/dst")))
+	if got := readAll(t, a, makePointer(t, "export/to/dst")); got != contents {
+		t.Errorf("expected to read \"%s\" as written, got \"%s\"", contents, got)
+	}
+}
+
+func TestAzureWalk(t *testing.T) {
+	a := azure.NewAdapter(serviceURL)
+	for _, p := range []string{"abc", "foo/bar", "foo/baz", "food"} {
+		testutil.MustDo(t, "Put", a.Put(makePointer(t, p), 4, strings.NewReader("data"), block.PutOpts{}))
+	}
+
+	cases := []struct {
+		name     string
+		prefix   string
+		expected []string
+	}{
+		{"all", "", []string{"abc", "foo/bar", "foo/baz", "food"}},
+		{"directory", "foo/", []string{"foo/bar", "foo/baz"}},
+		{"partial name", "foo", []string{"foo/bar", "foo/baz", "food"}},
+		{"missing", "missing/", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []string
+			err := a.Walk(makePointer(t, c.prefix), func(entry block.WalkEntry) error {
+				got = append(got, entry.Identifier)
+				if entry.Size != 4 {
+					t.Errorf("expected %s to have size 4, got %d", entry.Identifier, entry.Size)
+				}
+				return nil
+			})
+			testutil.MustDo(t, "Walk", err)
+			if strings.Join(got, ",") != strings.Join(c.expected, ",") {
+				t.Errorf("expected walk to return %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestAzureStorageAccountMismatch(t *testing.T) {
+	a := azure.NewAdapter(serviceURL, azure.WithStorageAccount(storageAccount))
+	cases := []struct {
+		name    string
+		pointer block.ObjectPointer
+	}{
+		{"namespace", block.ObjectPointer{StorageNamespace: "https://other.blob.core.windows.net/container/repo", Identifier: "file"}},
+		{"identifier", block.ObjectPointer{StorageNamespace: "azure://container/repo", Identifier: "https://other.blob.core.windows.net/container/repo/file"}},
+	}
+	contents := "def"
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := a.Put(c.pointer, int64(len(contents)), strings.NewReader(contents), block.PutOpts{})
+			if !errors.Is(err, block.ErrInvalidNamespace) {
+				t.Errorf("Put to another storage account err=%v, expected %s", err, block.ErrInvalidNamespace)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package azure_test
+
+import (
+	"context"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/ory/dockertest/v3"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	azuriteContainerTimeoutSeconds = 10 * 60 // 10 min
+	storageAccount                 = "lakefs"
+	containerName                  = "lakefs-test"
+)
+
+var (
+	pool       *dockertest.Pool
+	serviceURL azblob.ServiceURL
+)
+
+// runAzurite starts an Azurite blob storage emulator serving storageAccount, and returns
+// the service URL of the account and a function to stop the emulator.
+func runAzurite() (azblob.ServiceURL, func()) {
+	accessKey := base64.StdEncoding.EncodeToString([]byte("lakefs-azurite-test-access-key"))
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "mcr.microsoft.com/azure-storage/azurite",
+		Tag:        "3.11.0",
+		Cmd:        []string{"azurite-blob", "--blobHost", "0.0.0.0"},
+		Env:        []string{fmt.Sprintf("AZURITE_ACCOUNTS=%s:%s", storageAccount, accessKey)},
+	})
+	if err != nil {
+		log.Fatalf("Could not start azurite: %s", err)
+	}
+	if err := resource.Expire(azuriteContainerTimeoutSeconds); err != nil {
+		log.Fatalf("Could not expire azurite container: %s", err)
+	}
+
+	credential, err := azblob.NewSharedKeyCredential(storageAccount, accessKey)
+	if err != nil {
+		log.Fatalf("Could not create credential: %s", err)
+	}
+	endpoint, err := url.Parse(fmt.Sprintf("http://localhost:%s/%s/", resource.GetPort("10000/tcp"), storageAccount))
+	if err != nil {
+		log.Fatalf("Could not parse azurite endpoint: %s", err)
+	}
+	u := azblob.NewServiceURL(*endpoint, azblob.NewPipeline(credential, azblob.PipelineOptions{}))
+
+	// wait for azurite to start and create the test container
+	err = pool.Retry(func() error {
+		_, err := u.NewContainerURL(containerName).Create(context.Background(), azblob.Metadata{}, azblob.PublicAccessNone)
+		return err
+	})
+	if err != nil {
+		log.Fatalf("Could not create container on azurite: %s", err)
+	}
+	return u, func() {
+		if err := pool.Purge(resource); err != nil {
+			log.Fatalf("Could not purge azurite container: %s", err)
+		}
+	}
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Verbose() {
+		// keep the log level calm
+		logrus.SetLevel(logrus.PanicLevel)
+	}
+
+	var err error
+	pool, err = dockertest.NewPool("")
+	if err != nil {
+		log.Fatalf("Could not connect to Docker: %s", err)
+	}
+	var closer func()
+	serviceURL, closer = runAzurite()
+	code := m.Run()
+	closer()
+	os.Exit(code)
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/url"
 
 	"cloud.google.com/go/storage"
+	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	log "github.com/sirupsen/logrus"
 	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/block/azure"
 	"github.com/treeverse/lakefs/block/gs"
 	"github.com/treeverse/lakefs/block/local"
 	"github.com/treeverse/lakefs/block/mem"
@@ -24,7 +28,13 @@ import (
 // googleAuthCloudPlatform - Cloud Storage authentication https://cloud.google.com/storage/docs/authentication
 const googleAuthCloudPlatform = "https://www.googleapis.com/auth/cloud-platform"
 
-var ErrInvalidBlockStoreType = errors.New("invalid blockstore type")
+// azureDefaultEndpointFormat formats the blob service URL of a storage account
+const azureDefaultEndpointFormat = "https://%s.blob.core.windows.net/"
+
+var (
+	ErrInvalidBlockStoreType      = errors.New("invalid blockstore type")
+	ErrMissingAzureStorageAccount = errors.New("missing azure storage account")
+)
 
 func BuildBlockAdapter(c params.AdapterConfig) (block.Adapter, error) {
 	blockstore := c.GetBlockstoreType()
@@ -54,9 +64,15 @@ func BuildBlockAdapter(c params.AdapterConfig) (block.Adapter, error) {
 			return nil, err
 		}
 		return buildGSAdapter(p)
+	case azure.BlockstoreType:
+		p, err := c.GetBlockAdapterAzureParams()
+		if err != nil {
+			return nil, err
+		}
+		return buildAzureAdapter(p)
 	default:
 		return nil, fmt.Errorf("%w '%s' please choose one of %s",
-			ErrInvalidBlockStoreType, blockstore, []string{local.BlockstoreType, s3a.BlockstoreType, mem.BlockstoreType, transient.BlockstoreType, gs.BlockstoreType})
+			ErrInvalidBlockStoreType, blockstore, []string{local.BlockstoreType, s3a.BlockstoreType, mem.BlockstoreType, transient.BlockstoreType, gs.BlockstoreType, azure.BlockstoreType})
 	}
 }
 
@@ -89,9 +105,16 @@ func buildS3Adapter(params params.S3) (*s3a.Adapter, error) {
 
 func buildGSAdapter(params params.GS) (*gs.Adapter, error) {
 	var opts []option.ClientOption
+	var credentialsJSON []byte
 	if params.CredentialsFile != "" {
 		opts = append(opts, option.WithCredentialsFile(params.CredentialsFile))
+		var err error
+		credentialsJSON, err = ioutil.ReadFile(params.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
 	} else if params.CredentialsJSON != "" {
+		credentialsJSON = []byte(params.CredentialsJSON)
 		ctx := context.Background()
 		cred, err := google.CredentialsFromJSON(ctx, []byte(params.CredentialsJSON), googleAuthCloudPlatform)
 		if err != nil {
@@ -104,7 +127,45 @@ func buildGSAdapter(params params.GS) (*gs.Adapter, error) {
 	if err != nil {
 		return nil, err
 	}
-	adapter := gs.NewAdapter(client)
+	var adapterOpts []func(a *gs.Adapter)
+	if credentialsJSON != nil {
+		// only service account keys can sign URLs
+		if jwtConfig, err := google.JWTConfigFromJSON(credentialsJSON); err == nil {
+			adapterOpts = append(adapterOpts, gs.WithSigningCredentials(jwtConfig.Email, jwtConfig.PrivateKey))
+		}
+	}
+	adapter := gs.NewAdapter(client, adapterOpts...)
 	log.WithField("type", "gs").Info("initialized blockstore adapter")
 	return adapter, nil
 }
+
+func buildAzureAdapter(params params.Azure) (*azure.Adapter, error) {
+	if params.StorageAccount == "" {
+		return nil, ErrMissingAzureStorageAccount
+	}
+	var credential azblob.Credential = azblob.NewAnonymousCredential()
+	if params.StorageAccessKey != "" {
+		var err error
+		credential, err = azblob.NewSharedKeyCredential(params.StorageAccount, params.StorageAccessKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid azure storage access key: %w", err)
+		}
+	}
+	endpoint := params.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf(azureDefaultEndpointFormat, params.StorageAccount)
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("azure endpoint %s: %w", endpoint, err)
+	}
+	p := azblob.NewPipeline(credential, azblob.PipelineOptions{
+		Retry: azblob.RetryOptions{TryTimeout: params.TryTimeout},
+	})
+	adapter := azure.NewAdapter(azblob.NewServiceURL(*endpointURL, p), azure.WithStorageAccount(params.StorageAccount))
+	logging.Default().WithFields(logging.Fields{
+		"type":     "azure",
+		"endpoint": endpoint,
+	}).Info("initialized blockstore adapter")
+	return adapter, nil
+}
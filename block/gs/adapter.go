@@ -2,6 +2,7 @@ package gs
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -39,6 +40,9 @@ type Adapter struct {
 	client             *storage.Client
 	ctx                context.Context
 	uploadIDTranslator block.UploadIDTranslator
+	// googleAccessID and privateKey sign presigned URLs, if set
+	googleAccessID string
+	privateKey     []byte
 }
 
 func WithContext(ctx context.Context) func(a *Adapter) {
@@ -53,6 +57,15 @@ func WithTranslator(t block.UploadIDTranslator) func(a *Adapter) {
 	}
 }
 
+// WithSigningCredentials sets the service account email and private key used to sign
+// presigned URLs.
+func WithSigningCredentials(googleAccessID string, privateKey []byte) func(a *Adapter) {
+	return func(a *Adapter) {
+		a.googleAccessID = googleAccessID
+		a.privateKey = privateKey
+	}
+}
+
 func NewAdapter(client *storage.Client, opts ...func(a *Adapter)) *Adapter {
 	a := &Adapter{
 		ctx:                context.Background(),
@@ -70,6 +83,8 @@ func (a *Adapter) WithContext(ctx context.Context) block.Adapter {
 		ctx:                ctx,
 		client:             a.client,
 		uploadIDTranslator: a.uploadIDTranslator,
+		googleAccessID:     a.googleAccessID,
+		privateKey:         a.privateKey,
 	}
 }
 
@@ -146,16 +161,61 @@ func (a *Adapter) GetProperties(obj block.ObjectPointer) (block.Properties, erro
 	if err != nil {
 		return props, err
 	}
-	_, err = a.client.
+	attrs, err := a.client.
 		Bucket(qualifiedKey.StorageNamespace).
 		Object(qualifiedKey.Key).
 		Attrs(a.ctx)
 	if err != nil {
 		return props, err
 	}
+	props.Size = attrs.Size
+	// composite objects have no MD5
+	if len(attrs.MD5) > 0 {
+		props.ETag = hex.EncodeToString(attrs.MD5)
+	}
 	return props, nil
 }
 
+func (a *Adapter) GetPreSignedURL(obj block.ObjectPointer, mode block.PreSignMode) (string, error) {
+	var err error
+	defer reportMetrics("GetPreSignedURL", time.Now(), nil, &err)
+	if a.googleAccessID == "" || len(a.privateKey) == 0 {
+		// signing requires the key of a service account
+		err = block.ErrOperationNotSupported
+		return "", err
+	}
+	qualifiedKey, err := resolveNamespace(obj)
+	if err != nil {
+		return "", err
+	}
+	var method string
+	switch mode {
+	case block.PreSignModeRead:
+		method = http.MethodGet
+	case block.PreSignModeWrite:
+		method = http.MethodPut
+	default:
+		err = block.ErrOperationNotSupported
+		return "", err
+	}
+	signedURL, err := storage.SignedURL(qualifiedKey.StorageNamespace, qualifiedKey.Key, &storage.SignedURLOptions{
+		GoogleAccessID: a.googleAccessID,
+		PrivateKey:     a.privateKey,
+		Method:         method,
+		Expires:        time.Now().Add(block.DefaultPreSignExpiry),
+		Scheme:         storage.SigningSchemeV4,
+	})
+	if err != nil {
+		a.log().WithError(err).WithFields(logging.Fields{
+			"operation":  "GetPreSignedURL",
+			"namespace":  obj.StorageNamespace,
+			"identifier": obj.Identifier,
+		}).Error("could not sign URL")
+		return "", err
+	}
+	return signedURL, nil
+}
+
 func (a *Adapter) Remove(obj block.ObjectPointer) error {
 	var err error
 	defer reportMetrics("Remove", time.Now(), nil, &err)
@@ -173,6 +233,44 @@ func (a *Adapter) Remove(obj block.ObjectPointer) error {
 	return nil
 }
 
+func (a *Adapter) Walk(prefix block.ObjectPointer, walkFn block.WalkFunc) error {
+	var err error
+	defer reportMetrics("Walk", time.Now(), nil, &err)
+	qualifiedKey, err := resolveNamespace(prefix)
+	if err != nil {
+		return err
+	}
+	it := a.client.
+		Bucket(qualifiedKey.StorageNamespace).
+		Objects(a.ctx, &storage.Query{Prefix: qualifiedKey.Key})
+	for {
+		var attrs *storage.ObjectAttrs
+		attrs, err = it.Next()
+		if errors.Is(err, iterator.Done) {
+			err = nil
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("listing bucket '%s' prefix '%s': %w", qualifiedKey.StorageNamespace, qualifiedKey.Key, err)
+		}
+		var id string
+		id, err = block.RelativeIdentifier(prefix.StorageNamespace, attrs.Name)
+		if err != nil {
+			return err
+		}
+		err = walkFn(block.WalkEntry{
+			Identifier:   id,
+			Size:         attrs.Size,
+			LastModified: attrs.Updated,
+			ETag:         checksum(attrs.MD5, attrs.Etag),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *Adapter) Copy(sourceObj, destinationObj block.ObjectPointer) error {
 	var err error
 	defer reportMetrics("Copy", time.Now(), nil, &err)
@@ -253,6 +351,42 @@ func (a *Adapter) UploadPart(obj block.ObjectPointer, sizeBytes int64, reader io
 	return attrs.Etag, nil
 }
 
+func (a *Adapter) UploadCopyPart(sourceObj, destinationObj block.ObjectPointer, uploadID string, partNumber int64) (string, error) {
+	var err error
+	defer reportMetrics("UploadCopyPart", time.Now(), nil, &err)
+	qualifiedKey, err := resolveNamespace(destinationObj)
+	if err != nil {
+		return "", fmt.Errorf("resolve destination: %w", err)
+	}
+	qualifiedSourceKey, err := resolveNamespace(sourceObj)
+	if err != nil {
+		return "", fmt.Errorf("resolve source: %w", err)
+	}
+	uploadID = a.uploadIDTranslator.SetUploadID(uploadID)
+	objName := formatMultipartFilename(uploadID, partNumber)
+	// a part is an object, so copying the whole source is a copy of that object
+	attrs, err := a.client.
+		Bucket(qualifiedKey.StorageNamespace).
+		Object(objName).
+		CopierFrom(a.client.Bucket(qualifiedSourceKey.StorageNamespace).Object(qualifiedSourceKey.Key)).
+		Run(a.ctx)
+	if err != nil {
+		return "", fmt.Errorf("CopierFrom: %w", err)
+	}
+	return attrs.Etag, nil
+}
+
+func (a *Adapter) UploadCopyPartRange(sourceObj, destinationObj block.ObjectPointer, uploadID string, partNumber, startPosition, endPosition int64) (string, error) {
+	r, err := a.GetRange(sourceObj, startPosition, endPosition)
+	if err != nil {
+		return "", fmt.Errorf("GetRange: %w", err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return a.UploadPart(destinationObj, endPosition-startPosition+1, r, uploadID, partNumber)
+}
+
 func (a *Adapter) AbortMultiPartUpload(obj block.ObjectPointer, uploadID string) error {
 	var err error
 	defer reportMetrics("AbortMultiPartUpload", time.Now(), nil, &err)
@@ -424,10 +558,6 @@ func (a *Adapter) ValidateConfiguration(_ string) error {
 	return nil
 }
 
-func (a *Adapter) GenerateInventory(_ context.Context, _ logging.Logger, _ string, _ bool, _ []string) (block.Inventory, error) {
-	return nil, fmt.Errorf("inventory %w", ErrNotImplemented)
-}
-
 func (a *Adapter) Close() error {
 	return a.client.Close()
 }
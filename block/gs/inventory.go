@@ -0,0 +1,364 @@
+package gs
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/spf13/cast"
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/logging"
+	"github.com/xitongsys/parquet-go-source/gcs"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+	"google.golang.org/api/iterator"
+)
+
+// Storage Insights inventory reports are written as shard files next to a manifest named
+// <report config id>_<snapshot time>_manifest.json.
+const (
+	reportManifestSuffix = "_manifest.json"
+	csvShardSuffix       = ".csv"
+	parquetShardSuffix   = ".parquet"
+
+	// listPageSize is the number of objects fetched by each request of an inventory listing
+	listPageSize = 1000
+
+	parquetReaderParallelism = 4
+)
+
+// metadata fields of objects in inventory reports
+const (
+	reportBucketField  = "bucket"
+	reportNameField    = "name"
+	reportSizeField    = "size"
+	reportUpdatedField = "updated"
+	reportMD5Field     = "md5Hash"
+	reportETagField    = "etag"
+)
+
+var (
+	ErrUnsupportedReportFormat = errors.New("unsupported inventory report shard format")
+	ErrReportFieldNotFound     = errors.New("required field not found in inventory report")
+	ErrInvalidReportField      = errors.New("invalid field in inventory report")
+)
+
+var reportFields = []string{reportBucketField, reportNameField, reportSizeField, reportUpdatedField, reportMD5Field, reportETagField}
+
+type reportManifest struct {
+	ReportConfig struct {
+		CSVOptions struct {
+			Delimiter string `json:"delimiter"`
+		} `json:"csvOptions"`
+		ObjectMetadataReportOptions struct {
+			StorageFilters struct {
+				Bucket string `json:"bucket"`
+			} `json:"storageFilters"`
+		} `json:"objectMetadataReportOptions"`
+	} `json:"report_config"`
+	SnapshotTime     string   `json:"snapshot_time"`
+	ShardFileNames   []string `json:"report_shards_file_names"`
+	RecordsProcessed int64    `json:"records_processed"`
+}
+
+// GenerateInventory returns the objects of inventoryURL.  A URL of a Storage Insights report
+// manifest (gs://<bucket>/<path>/<config>_<time>_manifest.json) returns the objects of that
+// report.  Any other gs://<bucket>/<prefix> URL lists all objects under prefix, and returns a
+// live inventory.  Either inventory is always sorted.
+func (a *Adapter) GenerateInventory(ctx context.Context, logger logging.Logger, inventoryURL string, _ bool, prefixes []string) (block.Inventory, error) {
+	if logger == nil {
+		logger = logging.Default()
+	}
+	u, err := url.Parse(inventoryURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != BlockstoreType {
+		return nil, fmt.Errorf("inventory %s: %w", inventoryURL, block.ErrInvalidNamespace)
+	}
+	bucketName := u.Host
+	key := strings.TrimPrefix(u.Path, "/")
+	if strings.HasSuffix(key, reportManifestSuffix) {
+		return a.readReport(ctx, logger, inventoryURL, bucketName, key, prefixes)
+	}
+	return a.listInventory(ctx, logger, inventoryURL, bucketName, key, prefixes)
+}
+
+func (a *Adapter) listInventory(ctx context.Context, logger logging.Logger, inventoryURL, bucketName, prefix string, prefixes []string) (block.Inventory, error) {
+	var err error
+	defer reportMetrics("GenerateInventory", time.Now(), nil, &err)
+	it := a.client.Bucket(bucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+	it.PageInfo().MaxSize = listPageSize
+	var objects []block.InventoryObject
+	for {
+		var attrs *storage.ObjectAttrs
+		attrs, err = it.Next()
+		if errors.Is(err, iterator.Done) {
+			err = nil
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing bucket '%s' prefix '%s': %w", bucketName, prefix, err)
+		}
+		updated := attrs.Updated
+		objects = append(objects, block.InventoryObject{
+			Bucket:          attrs.Bucket,
+			Key:             attrs.Name,
+			Size:            attrs.Size,
+			LastModified:    &updated,
+			Checksum:        checksum(attrs.MD5, attrs.Etag),
+			PhysicalAddress: formatPhysicalAddress(attrs.Bucket, attrs.Name),
+		})
+	}
+	logger.Debugf("listed %d objects under %s", len(objects), inventoryURL)
+	return block.NewMemoryInventory(inventoryURL, bucketName, true, objects, prefixes), nil
+}
+
+func (a *Adapter) readReport(ctx context.Context, logger logging.Logger, manifestURL, bucketName, manifestKey string, prefixes []string) (block.Inventory, error) {
+	var err error
+	defer reportMetrics("GenerateInventory", time.Now(), nil, &err)
+	manifest, err := a.readReportManifest(ctx, bucketName, manifestKey)
+	if err != nil {
+		return nil, fmt.Errorf("read inventory report manifest %s: %w", manifestURL, err)
+	}
+	objects := make([]block.InventoryObject, 0, manifest.RecordsProcessed)
+	for _, shardName := range manifest.ShardFileNames {
+		var shardObjects []block.InventoryObject
+		shardKey := path.Join(path.Dir(manifestKey), shardName)
+		shardObjects, err = a.readReportShard(ctx, manifest, bucketName, shardKey)
+		if err != nil {
+			return nil, fmt.Errorf("read inventory report shard gs://%s/%s: %w", bucketName, shardKey, err)
+		}
+		objects = append(objects, shardObjects...)
+	}
+	logger.Debugf("read %d objects from %d shards of %s", len(objects), len(manifest.ShardFileNames), manifestURL)
+	sourceBucket := manifest.ReportConfig.ObjectMetadataReportOptions.StorageFilters.Bucket
+	if sourceBucket == "" && len(objects) > 0 {
+		sourceBucket = objects[0].Bucket
+	}
+	return block.NewMemoryInventory(manifestURL, sourceBucket, false, objects, prefixes), nil
+}
+
+func (a *Adapter) readReportManifest(ctx context.Context, bucketName, key string) (*reportManifest, error) {
+	r, err := a.client.Bucket(bucketName).Object(key).NewReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+	var manifest reportManifest
+	err = json.NewDecoder(r).Decode(&manifest)
+	if err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+func (a *Adapter) readReportShard(ctx context.Context, manifest *reportManifest, bucketName, key string) ([]block.InventoryObject, error) {
+	switch {
+	case strings.HasSuffix(key, csvShardSuffix):
+		r, err := a.client.Bucket(bucketName).Object(key).NewReader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = r.Close() }()
+		return readCSVReportShard(r, manifest.ReportConfig.CSVOptions.Delimiter)
+	case strings.HasSuffix(key, parquetShardSuffix):
+		pf, err := gcs.NewGcsFileReaderWithClient(ctx, a.client, "", bucketName, key)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = pf.Close() }()
+		return readParquetReportShard(pf)
+	default:
+		return nil, ErrUnsupportedReportFormat
+	}
+}
+
+// readCSVReportShard reads the objects of a CSV shard of an inventory report.  The shard must
+// start with a header row naming the metadata fields of its columns.
+func readCSVReportShard(r io.Reader, delimiter string) ([]block.InventoryObject, error) {
+	csvReader := csv.NewReader(r)
+	if delimiter != "" {
+		csvReader.Comma = []rune(delimiter)[0]
+	}
+	header, err := csvReader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for i, field := range header {
+		columns[field] = i
+	}
+	for _, field := range []string{reportBucketField, reportNameField} {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrReportFieldNotFound, field)
+		}
+	}
+	var objects []block.InventoryObject
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]interface{}, len(columns))
+		for field, i := range columns {
+			if i < len(record) && record[i] != "" {
+				values[field] = record[i]
+			}
+		}
+		obj, err := reportObject(values, false)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// readParquetReportShard reads the objects of a Parquet shard of an inventory report.
+func readParquetReportShard(pf source.ParquetFile) ([]block.InventoryObject, error) {
+	pr, err := reader.NewParquetReader(pf, nil, parquetReaderParallelism)
+	if err != nil {
+		return nil, err
+	}
+	defer pr.ReadStop()
+	numRows := pr.GetNumRows()
+	values := make([]map[string]interface{}, numRows)
+	for i := range values {
+		values[i] = make(map[string]interface{})
+	}
+	foundFields := make(map[string]bool)
+	updatedMillis := false
+	for i, info := range pr.SchemaHandler.Infos {
+		field := info.ExName
+		if !isReportField(field) {
+			continue
+		}
+		foundFields[field] = true
+		if field == reportUpdatedField {
+			updatedMillis = isTimestampMillis(pr.SchemaHandler.SchemaElements[i])
+		}
+		columnValues, _, _, err := pr.ReadColumnByPath(pr.SchemaHandler.IndexMap[int32(i)], numRows)
+		if err != nil {
+			return nil, fmt.Errorf("read parquet column %s: %w", field, err)
+		}
+		for row, v := range columnValues {
+			if v != nil {
+				values[row][field] = v
+			}
+		}
+	}
+	for _, field := range []string{reportBucketField, reportNameField} {
+		if !foundFields[field] {
+			return nil, fmt.Errorf("%w: %s", ErrReportFieldNotFound, field)
+		}
+	}
+	objects := make([]block.InventoryObject, 0, numRows)
+	for _, rowValues := range values {
+		obj, err := reportObject(rowValues, updatedMillis)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+func isReportField(field string) bool {
+	for _, f := range reportFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func isTimestampMillis(element *parquet.SchemaElement) bool {
+	if element.ConvertedType != nil && *element.ConvertedType == parquet.ConvertedType_TIMESTAMP_MILLIS {
+		return true
+	}
+	return element.LogicalType != nil && element.LogicalType.TIMESTAMP != nil &&
+		element.LogicalType.TIMESTAMP.Unit != nil && element.LogicalType.TIMESTAMP.Unit.MILLIS != nil
+}
+
+// reportObject returns the inventory object of the metadata values of a single report row.
+// Integer update times are in microseconds, or milliseconds if updatedMillis.
+func reportObject(values map[string]interface{}, updatedMillis bool) (block.InventoryObject, error) {
+	var obj block.InventoryObject
+	var err error
+	obj.Bucket, err = cast.ToStringE(values[reportBucketField])
+	if err != nil {
+		return obj, fmt.Errorf("%w %s: %s", ErrInvalidReportField, reportBucketField, err)
+	}
+	obj.Key, err = cast.ToStringE(values[reportNameField])
+	if err != nil {
+		return obj, fmt.Errorf("%w %s: %s", ErrInvalidReportField, reportNameField, err)
+	}
+	if v, ok := values[reportSizeField]; ok {
+		obj.Size, err = cast.ToInt64E(v)
+		if err != nil {
+			return obj, fmt.Errorf("%w %s: %s", ErrInvalidReportField, reportSizeField, err)
+		}
+	}
+	if v, ok := values[reportUpdatedField]; ok {
+		var updated time.Time
+		switch t := v.(type) {
+		case string:
+			updated, err = time.Parse(time.RFC3339Nano, t)
+		case int64:
+			if updatedMillis {
+				updated = time.Unix(0, t*int64(time.Millisecond))
+			} else {
+				updated = time.Unix(0, t*int64(time.Microsecond))
+			}
+		default:
+			err = fmt.Errorf("%w: unexpected type %T", ErrInvalidReportField, v)
+		}
+		if err != nil {
+			return obj, fmt.Errorf("%w %s: %s", ErrInvalidReportField, reportUpdatedField, err)
+		}
+		obj.LastModified = &updated
+	} else {
+		obj.LastModified = &time.Time{}
+	}
+	var md5 []byte
+	if v, ok := values[reportMD5Field]; ok {
+		md5, err = base64.StdEncoding.DecodeString(cast.ToString(v))
+		if err != nil {
+			return obj, fmt.Errorf("%w %s: %s", ErrInvalidReportField, reportMD5Field, err)
+		}
+	}
+	obj.Checksum = checksum(md5, cast.ToString(values[reportETagField]))
+	obj.PhysicalAddress = formatPhysicalAddress(obj.Bucket, obj.Key)
+	return obj, nil
+}
+
+// checksum returns the checksum of an object: its hex-encoded MD5, or its ETag for composite
+// objects that have no MD5.
+func checksum(md5 []byte, etag string) string {
+	if len(md5) > 0 {
+		return hex.EncodeToString(md5)
+	}
+	return etag
+}
+
+func formatPhysicalAddress(bucketName, key string) string {
+	return BlockstoreType + "://" + bucketName + "/" + key
+}
@@ -0,0 +1,149 @@
+package gs
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// md5 of "data", base64- and hex-encoded
+const (
+	dataMD5Base64 = "jXd/OF09/siBXSD3SWAm3A=="
+	dataMD5Hex    = "8d777f385d3dfec8815d20f7496026dc"
+)
+
+func TestReadCSVReportShard(t *testing.T) {
+	cases := []struct {
+		name             string
+		delimiter        string
+		contents         string
+		expectedKeys     []string
+		expectedChecksum []string
+		expectedErr      error
+	}{
+		{
+			name: "all fields",
+			contents: "bucket,name,size,updated,md5Hash,etag\n" +
+				"src,a/1,4,2021-01-20T10:00:00.5Z," + dataMD5Base64 + ",CJ1\n" +
+				"src,a/2,4,2021-01-20T11:00:00Z,,CJ2\n",
+			expectedKeys:     []string{"a/1", "a/2"},
+			expectedChecksum: []string{dataMD5Hex, "CJ2"},
+		},
+		{
+			name:             "delimiter",
+			delimiter:        "|",
+			contents:         "name|bucket\na/1|src\n",
+			expectedKeys:     []string{"a/1"},
+			expectedChecksum: []string{""},
+		},
+		{
+			name:     "empty",
+			contents: "",
+		},
+		{
+			name:        "missing name",
+			contents:    "bucket,size\nsrc,4\n",
+			expectedErr: ErrReportFieldNotFound,
+		},
+		{
+			name:        "invalid md5",
+			contents:    "bucket,name,md5Hash\nsrc,a/1,not base64!\n",
+			expectedErr: ErrInvalidReportField,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			objects, err := readCSVReportShard(strings.NewReader(c.contents), c.delimiter)
+			if !errors.Is(err, c.expectedErr) {
+				t.Fatalf("expected error %v, got %v", c.expectedErr, err)
+			}
+			if len(objects) != len(c.expectedKeys) {
+				t.Fatalf("expected %d objects, got %+v", len(c.expectedKeys), objects)
+			}
+			for i, obj := range objects {
+				if obj.Key != c.expectedKeys[i] || obj.Checksum != c.expectedChecksum[i] {
+					t.Errorf("expected object %d to be %s with checksum %s, got %s with checksum %s", i, c.expectedKeys[i], c.expectedChecksum[i], obj.Key, obj.Checksum)
+				}
+				if obj.Bucket != "src" || obj.PhysicalAddress != "gs://src/"+obj.Key {
+					t.Errorf("unexpected bucket %s and physical address %s of %s", obj.Bucket, obj.PhysicalAddress, obj.Key)
+				}
+				if obj.LastModified == nil {
+					t.Errorf("expected last modified time of %s", obj.Key)
+				}
+			}
+		})
+	}
+}
+
+type reportRow struct {
+	Bucket  string  `parquet:"name=bucket, type=UTF8"`
+	Name    string  `parquet:"name=name, type=UTF8"`
+	Size    *int64  `parquet:"name=size, type=INT64"`
+	Updated *int64  `parquet:"name=updated, type=TIMESTAMP_MICROS"`
+	MD5Hash *string `parquet:"name=md5Hash, type=UTF8"`
+}
+
+func TestReadParquetReportShard(t *testing.T) {
+	f, err := ioutil.TempFile("", "gs-report-*.parquet")
+	if err != nil {
+		t.Fatalf("create temp file: %s", err)
+	}
+	_ = f.Close()
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	updated := time.Date(2021, 1, 20, 10, 0, 0, 0, time.UTC)
+	updatedMicros := updated.UnixNano() / int64(time.Microsecond)
+	size := int64(4)
+	md5 := dataMD5Base64
+	fw, err := local.NewLocalFileWriter(f.Name())
+	if err != nil {
+		t.Fatalf("create parquet file writer: %s", err)
+	}
+	pw, err := writer.NewParquetWriter(fw, new(reportRow), 1)
+	if err != nil {
+		t.Fatalf("create parquet writer: %s", err)
+	}
+	rows := []reportRow{
+		{Bucket: "src", Name: "b", Size: &size, Updated: &updatedMicros, MD5Hash: &md5},
+		{Bucket: "src", Name: "a", Size: &size, Updated: &updatedMicros},
+	}
+	for _, row := range rows {
+		if err := pw.Write(row); err != nil {
+			t.Fatalf("write parquet row: %s", err)
+		}
+	}
+	if err := pw.WriteStop(); err != nil {
+		t.Fatalf("stop parquet writer: %s", err)
+	}
+	_ = fw.Close()
+
+	pf, err := local.NewLocalFileReader(f.Name())
+	if err != nil {
+		t.Fatalf("open parquet file: %s", err)
+	}
+	defer func() { _ = pf.Close() }()
+	objects, err := readParquetReportShard(pf)
+	if err != nil {
+		t.Fatalf("read parquet report shard: %s", err)
+	}
+	if len(objects) != len(rows) {
+		t.Fatalf("expected %d objects, got %+v", len(rows), objects)
+	}
+	for i, obj := range objects {
+		if obj.Key != rows[i].Name || obj.Bucket != "src" || obj.Size != size {
+			t.Errorf("expected object %d to be %+v, got %+v", i, rows[i], obj)
+		}
+		if obj.LastModified == nil || !obj.LastModified.Equal(updated) {
+			t.Errorf("expected %s to be updated at %s, got %v", obj.Key, updated, obj.LastModified)
+		}
+	}
+	if objects[0].Checksum != dataMD5Hex {
+		t.Errorf("expected checksum %s of %s, got %s", dataMD5Hex, objects[0].Key, objects[0].Checksum)
+	}
+}
@@ -0,0 +1,135 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/treeverse/lakefs/logging"
+)
+
+// DefaultListingParallelism is the number of prefixes a ListingInventoryGenerator lists
+// concurrently.
+const DefaultListingParallelism = 16
+
+// ListingInventoryGenerator generates inventories by listing objects through a block adapter,
+// so it works for any storage without an inventory report.  Inventory URLs are storage
+// namespace URLs, each of whose objects is listed.  Listed inventories are live.
+type ListingInventoryGenerator struct {
+	adapter     Adapter
+	parallelism int
+}
+
+func NewListingInventoryGenerator(adapter Adapter, parallelism int) *ListingInventoryGenerator {
+	if parallelism <= 0 {
+		parallelism = DefaultListingParallelism
+	}
+	return &ListingInventoryGenerator{adapter: adapter, parallelism: parallelism}
+}
+
+// GenerateInventory lists all objects under inventoryURL, or only those with a key that starts
+// with one of prefixes.  Each prefix is listed separately, up to the generator parallelism at
+// a time.  Objects keys are relative to the bucket of inventoryURL, like keys of inventory
+// reports.  The inventory is always sorted.
+func (g *ListingInventoryGenerator) GenerateInventory(ctx context.Context, logger logging.Logger, inventoryURL string, _ bool, prefixes []string) (Inventory, error) {
+	if logger == nil {
+		logger = logging.Default()
+	}
+	inventoryURL = strings.TrimSuffix(inventoryURL, "/")
+	qualifiedKey, err := ResolveNamespace(inventoryURL, "")
+	if err != nil {
+		return nil, err
+	}
+	adapter := g.adapter.WithContext(ctx)
+	listPrefixes := listingPrefixes(qualifiedKey.Key, prefixes)
+	results := make([][]InventoryObject, len(listPrefixes))
+	errs := make([]error, len(listPrefixes))
+	tasks := make(chan int, len(listPrefixes))
+	for i := range listPrefixes {
+		tasks <- i
+	}
+	close(tasks)
+	var wg sync.WaitGroup
+	workers := g.parallelism
+	if workers > len(listPrefixes) {
+		workers = len(listPrefixes)
+	}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range tasks {
+				results[i], errs[i] = listObjects(adapter, inventoryURL, qualifiedKey, listPrefixes[i])
+			}
+		}()
+	}
+	wg.Wait()
+	var objects []InventoryObject
+	for i, result := range results {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("list %s/%s: %w", inventoryURL, listPrefixes[i], errs[i])
+		}
+		objects = append(objects, result...)
+	}
+	logger.Debugf("listed %d objects under %s in %d prefixes", len(objects), inventoryURL, len(listPrefixes))
+	return NewMemoryInventory(inventoryURL, qualifiedKey.StorageNamespace, true, objects, prefixes), nil
+}
+
+// listingPrefixes returns the identifiers, relative to a URL with key urlKey, to list to find
+// all objects with one of (bucket) key prefixes.  Each object is found under at most one of
+// the returned identifiers.
+func listingPrefixes(urlKey string, prefixes []string) []string {
+	if len(prefixes) == 0 {
+		return []string{""}
+	}
+	sorted := make([]string, len(prefixes))
+	copy(sorted, prefixes)
+	sort.Strings(sorted)
+	var res []string
+	for _, prefix := range sorted {
+		if strings.HasPrefix(urlKey, prefix) {
+			// prefix covers everything under the URL
+			return []string{""}
+		}
+		if !strings.HasPrefix(prefix, urlKey) {
+			// nothing under the URL has this prefix
+			continue
+		}
+		id := strings.TrimPrefix(prefix, urlKey)
+		if len(res) > 0 && strings.HasPrefix(id, res[len(res)-1]) {
+			// already listed by a shorter prefix
+			continue
+		}
+		res = append(res, id)
+	}
+	return res
+}
+
+func listObjects(adapter Adapter, inventoryURL string, qualifiedKey QualifiedKey, identifier string) ([]InventoryObject, error) {
+	var objects []InventoryObject
+	err := adapter.Walk(ObjectPointer{StorageNamespace: inventoryURL, Identifier: identifier}, func(entry WalkEntry) error {
+		lastModified := entry.LastModified
+		objects = append(objects, InventoryObject{
+			Bucket:          qualifiedKey.StorageNamespace,
+			Key:             qualifiedKey.Key + entry.Identifier,
+			Size:            entry.Size,
+			LastModified:    &lastModified,
+			Checksum:        listingChecksum(entry),
+			PhysicalAddress: inventoryURL + "/" + entry.Identifier,
+		})
+		return nil
+	})
+	return objects, err
+}
+
+// listingChecksum returns the checksum of a listed object: its ETag, or (for storage that
+// does not report ETags when listing) a checksum derived from its size and modification time
+// that changes whenever the object is rewritten.
+func listingChecksum(entry WalkEntry) string {
+	if entry.ETag != "" {
+		return entry.ETag
+	}
+	return fmt.Sprintf("%x-%x", entry.LastModified.UnixNano(), entry.Size)
+}
@@ -0,0 +1,66 @@
+package block_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/block/local"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestListingInventoryGenerator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testing-listing-inventory-*")
+	testutil.MustDo(t, "TempDir", err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	adapter, err := local.NewAdapter(dir)
+	testutil.MustDo(t, "NewAdapter", err)
+	for _, p := range []string{"data/b/2", "data/a/1", "data/a/2", "data/c", "other/x"} {
+		testutil.MustDo(t, "Put", adapter.Put(block.ObjectPointer{StorageNamespace: "local://bucket", Identifier: p}, 4, strings.NewReader("data"), block.PutOpts{}))
+	}
+
+	cases := []struct {
+		name         string
+		inventoryURL string
+		prefixes     []string
+		expected     []string
+	}{
+		{"bucket", "local://bucket", nil, []string{"data/a/1", "data/a/2", "data/b/2", "data/c", "other/x"}},
+		{"bucket prefix", "local://bucket/data/", nil, []string{"data/a/1", "data/a/2", "data/b/2", "data/c"}},
+		{"prefixes", "local://bucket", []string{"other/", "data/b", "data/a/"}, []string{"data/a/1", "data/a/2", "data/b/2", "other/x"}},
+		{"nested prefixes", "local://bucket/data", []string{"data/a/", "data/a/1"}, []string{"data/a/1", "data/a/2"}},
+		{"prefix covering the URL", "local://bucket/data", []string{"d"}, []string{"data/a/1", "data/a/2", "data/b/2", "data/c"}},
+		{"prefixes outside the URL", "local://bucket/data", []string{"other/"}, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			inv, err := block.NewListingInventoryGenerator(adapter, 2).GenerateInventory(context.Background(), nil, c.inventoryURL, true, c.prefixes)
+			testutil.MustDo(t, "GenerateInventory", err)
+			if !block.IsLiveInventory(inv) {
+				t.Error("expected a live inventory")
+			}
+			if inv.SourceName() != "bucket" {
+				t.Errorf("expected source bucket, got %s", inv.SourceName())
+			}
+			var got []string
+			it := inv.Iterator()
+			for it.Next() {
+				obj := it.Get()
+				got = append(got, obj.Key)
+				if obj.PhysicalAddress != "local://bucket/"+obj.Key {
+					t.Errorf("expected physical address of %s under local://bucket/, got %s", obj.Key, obj.PhysicalAddress)
+				}
+				if obj.Checksum == "" || obj.Size != 4 {
+					t.Errorf("unexpected checksum %s and size %d of %s", obj.Checksum, obj.Size, obj.Key)
+				}
+			}
+			testutil.MustDo(t, "iterate inventory", it.Err())
+			if strings.Join(got, ",") != strings.Join(c.expected, ",") {
+				t.Errorf("expected inventory %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -33,7 +34,6 @@ type Adapter struct {
 var (
 	ErrPathNotValid          = errors.New("path provided is not a valid directory")
 	ErrPathNotWritable       = errors.New("path provided is not writable")
-	ErrInventoryNotSupported = errors.New("inventory feature not implemented for local storage adapter")
 	ErrInvalidUploadIDFormat = errors.New("invalid upload id format")
 )
 
@@ -124,6 +124,10 @@ func (l *Adapter) Put(obj block.ObjectPointer, _ int64, reader io.Reader, _ bloc
 	return err
 }
 
+func (l *Adapter) GetPreSignedURL(_ block.ObjectPointer, _ block.PreSignMode) (string, error) {
+	return "", block.ErrOperationNotSupported
+}
+
 func (l *Adapter) Remove(obj block.ObjectPointer) error {
 	p, err := l.getPath(obj)
 	if err != nil {
@@ -195,12 +199,12 @@ func (l *Adapter) GetProperties(obj block.ObjectPointer) (block.Properties, erro
 	if err != nil {
 		return block.Properties{}, err
 	}
-	_, err = os.Stat(p)
+	info, err := os.Stat(p)
 	if err != nil {
 		return block.Properties{}, err
 	}
-	// No properties, just return that it exists
-	return block.Properties{}, nil
+	// No checksum is stored, only the size is known
+	return block.Properties{Size: info.Size()}, nil
 }
 
 func isDirectoryWritable(pth string) bool {
@@ -246,6 +250,28 @@ func (l *Adapter) UploadPart(obj block.ObjectPointer, _ int64, reader io.Reader,
 	return etag, err
 }
 
+func (l *Adapter) UploadCopyPart(sourceObj, destinationObj block.ObjectPointer, uploadID string, partNumber int64) (string, error) {
+	r, err := l.Get(sourceObj, 0)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return l.UploadPart(destinationObj, -1, r, uploadID, partNumber)
+}
+
+func (l *Adapter) UploadCopyPartRange(sourceObj, destinationObj block.ObjectPointer, uploadID string, partNumber, startPosition, endPosition int64) (string, error) {
+	r, err := l.GetRange(sourceObj, startPosition, endPosition)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return l.UploadPart(destinationObj, endPosition-startPosition+1, r, uploadID, partNumber)
+}
+
 func (l *Adapter) AbortMultiPartUpload(obj block.ObjectPointer, uploadID string) error {
 	if err := isValidUploadID(uploadID); err != nil {
 		return err
@@ -343,12 +369,112 @@ func (l *Adapter) getPartFiles(uploadID string, obj block.ObjectPointer) ([]stri
 	return names, nil
 }
 
+func (l *Adapter) Walk(prefix block.ObjectPointer, walkFn block.WalkFunc) error {
+	qualifiedKey, err := resolveNamespace(prefix)
+	if err != nil {
+		return err
+	}
+	bucketPath := path.Join(l.path, qualifiedKey.StorageNamespace)
+	// walk the directory holding the prefix, it may end in the middle of a file name
+	root := path.Join(bucketPath, qualifiedKey.Key)
+	if !strings.HasSuffix(qualifiedKey.Key, "/") && qualifiedKey.Key != "" {
+		root = path.Dir(root)
+	}
+	err = filepath.Walk(filepath.Clean(root), func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		key, err := filepath.Rel(bucketPath, p)
+		if err != nil {
+			return err
+		}
+		key = filepath.ToSlash(key)
+		if !strings.HasPrefix(key, qualifiedKey.Key) {
+			return nil
+		}
+		id, err := block.RelativeIdentifier(prefix.StorageNamespace, key)
+		if err != nil {
+			return err
+		}
+		return walkFn(block.WalkEntry{
+			Identifier:   id,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		// nothing was ever written under prefix
+		return nil
+	}
+	return err
+}
+
 func (l *Adapter) ValidateConfiguration(_ string) error {
 	return nil
 }
 
-func (l *Adapter) GenerateInventory(_ context.Context, _ logging.Logger, _ string, _ bool, _ []string) (block.Inventory, error) {
-	return nil, ErrInventoryNotSupported
+// GenerateInventory walks the directory of inventoryURL, a local://<namespace>/<path> URL, and
+// returns all files under it.  The inventory is always sorted, and is live: it holds the
+// files present when it was generated.
+func (l *Adapter) GenerateInventory(ctx context.Context, logger logging.Logger, inventoryURL string, _ bool, prefixes []string) (block.Inventory, error) {
+	if logger == nil {
+		logger = logging.Default()
+	}
+	u, err := url.Parse(inventoryURL)
+	if err != nil {
+		return nil, err
+	}
+	if storageType, err := block.GetStorageType(u); err != nil || storageType != block.StorageTypeLocal {
+		return nil, fmt.Errorf("inventory %s: %w", inventoryURL, block.ErrInvalidNamespace)
+	}
+	namespaceURL := BlockstoreType + "://" + u.Host
+	var objects []block.InventoryObject
+	err = l.Walk(block.ObjectPointer{StorageNamespace: namespaceURL, Identifier: strings.TrimPrefix(u.Path, "/")}, func(entry block.WalkEntry) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		obj := block.ObjectPointer{StorageNamespace: namespaceURL, Identifier: entry.Identifier}
+		checksum, err := l.checksum(obj)
+		if err != nil {
+			return fmt.Errorf("checksum %s: %w", entry.Identifier, err)
+		}
+		lastModified := entry.LastModified
+		objects = append(objects, block.InventoryObject{
+			Bucket:          u.Host,
+			Key:             entry.Identifier,
+			Size:            entry.Size,
+			LastModified:    &lastModified,
+			Checksum:        checksum,
+			PhysicalAddress: namespaceURL + "/" + entry.Identifier,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.Debugf("listed %d files under %s", len(objects), inventoryURL)
+	return block.NewMemoryInventory(inventoryURL, u.Host, true, objects, prefixes), nil
+}
+
+// checksum returns the hex-encoded MD5 of the contents of obj.
+func (l *Adapter) checksum(obj block.ObjectPointer) (string, error) {
+	p, err := l.getPath(obj)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(filepath.Clean(p))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	h := md5.New() //nolint:gosec
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 func (l *Adapter) BlockstoreType() string {
@@ -1,6 +1,7 @@
 package local_test
 
 import (
+	"context"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"io/ioutil"
@@ -120,3 +121,115 @@ func TestLocalCopy(t *testing.T) {
 		t.Errorf("expected to read \"%s\" as written, got \"%s\"", contents, string(got))
 	}
 }
+
+func TestLocalUploadCopyPart(t *testing.T) {
+	a, cleanup := makeAdapter(t)
+	defer cleanup()
+
+	testutil.MustDo(t, "Put", a.Put(makePointer("src"), 0, strings.NewReader("foo bar baz"), block.PutOpts{}))
+
+	pointer := makePointer("dst")
+	uploadID, err := a.CreateMultiPartUpload(pointer, nil, block.CreateMultiPartUploadOpts{})
+	testutil.MustDo(t, "CreateMultiPartUpload", err)
+	etag1, err := a.UploadCopyPart(makePointer("src"), pointer, uploadID, 1)
+	testutil.MustDo(t, "UploadCopyPart", err)
+	etag2, err := a.UploadCopyPartRange(makePointer("src"), pointer, uploadID, 2, 3, 7)
+	testutil.MustDo(t, "UploadCopyPartRange", err)
+	_, _, err = a.CompleteMultiPartUpload(pointer, uploadID, &block.MultipartUploadCompletion{
+		Part: []*s3.CompletedPart{
+			{ETag: aws.String(etag1), PartNumber: aws.Int64(1)},
+			{ETag: aws.String(etag2), PartNumber: aws.Int64(2)},
+		},
+	})
+	testutil.MustDo(t, "CompleteMultiPartUpload", err)
+	reader, err := a.Get(pointer, 0)
+	testutil.MustDo(t, "Get", err)
+	got, err := ioutil.ReadAll(reader)
+	testutil.MustDo(t, "ReadAll", err)
+	const expected = "foo bar baz bar "
+	if string(got) != expected {
+		t.Errorf("expected to read \"%s\" as copied, got \"%s\"", expected, string(got))
+	}
+}
+
+func TestLocalWalk(t *testing.T) {
+	a, cleanup := makeAdapter(t)
+	defer cleanup()
+
+	for _, p := range []string{"abc", "foo/bar", "foo/baz", "food"} {
+		testutil.MustDo(t, "Put", a.Put(makePointer(p), 0, strings.NewReader("data"), block.PutOpts{}))
+	}
+
+	cases := []struct {
+		name     string
+		prefix   string
+		expected []string
+	}{
+		{"all", "", []string{"abc", "foo/bar", "foo/baz", "food"}},
+		{"directory", "foo/", []string{"foo/bar", "foo/baz"}},
+		{"partial name", "foo", []string{"foo/bar", "foo/baz", "food"}},
+		{"missing", "missing/", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []string
+			err := a.Walk(makePointer(c.prefix), func(entry block.WalkEntry) error {
+				got = append(got, entry.Identifier)
+				return nil
+			})
+			testutil.MustDo(t, "Walk", err)
+			if strings.Join(got, ",") != strings.Join(c.expected, ",") {
+				t.Errorf("expected walk to return %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestLocalGenerateInventory(t *testing.T) {
+	a, cleanup := makeAdapter(t)
+	defer cleanup()
+
+	for _, p := range []string{"food", "foo/baz", "abc", "foo/bar", "other/x"} {
+		testutil.MustDo(t, "Put", a.Put(makePointer(p), 0, strings.NewReader("data"), block.PutOpts{}))
+	}
+
+	cases := []struct {
+		name         string
+		inventoryURL string
+		prefixes     []string
+		expected     []string
+	}{
+		{"all", "local://test", nil, []string{"abc", "foo/bar", "foo/baz", "food", "other/x"}},
+		{"directory", "local://test/foo/", nil, []string{"foo/bar", "foo/baz"}},
+		{"prefixes", "local://test", []string{"other/", "foo"}, []string{"foo/bar", "foo/baz", "food", "other/x"}},
+		{"missing", "local://test/missing/", nil, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			inv, err := a.GenerateInventory(context.Background(), nil, c.inventoryURL, true, c.prefixes)
+			testutil.MustDo(t, "GenerateInventory", err)
+			if !block.IsLiveInventory(inv) {
+				t.Error("expected a live inventory")
+			}
+			var got []string
+			it := inv.Iterator()
+			for it.Next() {
+				obj := it.Get()
+				got = append(got, obj.Key)
+				if obj.PhysicalAddress != "local://test/"+obj.Key {
+					t.Errorf("expected physical address of %s under local://test/, got %s", obj.Key, obj.PhysicalAddress)
+				}
+				// md5 of "data"
+				if obj.Checksum != "8d777f385d3dfec8815d20f7496026dc" {
+					t.Errorf("unexpected checksum %s of %s", obj.Checksum, obj.Key)
+				}
+			}
+			testutil.MustDo(t, "iterate inventory", it.Err())
+			if strings.Join(got, ",") != strings.Join(c.expected, ",") {
+				t.Errorf("expected inventory %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
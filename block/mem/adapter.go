@@ -3,6 +3,7 @@ package mem
 import (
 	"bytes"
 	"context"
+	"crypto/md5" //nolint:gosec
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
@@ -11,6 +12,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
@@ -107,7 +109,7 @@ func (a *Adapter) Put(obj block.ObjectPointer, sizeBytes int64, reader io.Reader
 	}
 	key := getKey(obj)
 	a.data[key] = data
-	a.properties[key] = block.Properties(opts)
+	a.properties[key] = block.Properties{StorageClass: opts.StorageClass}
 	return nil
 }
 
@@ -134,13 +136,22 @@ func (a *Adapter) GetRange(obj block.ObjectPointer, startPosition int64, endPosi
 func (a *Adapter) GetProperties(obj block.ObjectPointer) (block.Properties, error) {
 	a.mutex.RLock()
 	defer a.mutex.RUnlock()
-	props, ok := a.properties[getKey(obj)]
+	key := getKey(obj)
+	props, ok := a.properties[key]
 	if !ok {
 		return block.Properties{}, ErrNoPropertiesForKey
 	}
+	data := a.data[key]
+	checksum := md5.Sum(data) //nolint:gosec
+	props.Size = int64(len(data))
+	props.ETag = hex.EncodeToString(checksum[:])
 	return props, nil
 }
 
+func (a *Adapter) GetPreSignedURL(_ block.ObjectPointer, _ block.PreSignMode) (string, error) {
+	return "", block.ErrOperationNotSupported
+}
+
 func (a *Adapter) Remove(obj block.ObjectPointer) error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
@@ -148,6 +159,34 @@ func (a *Adapter) Remove(obj block.ObjectPointer) error {
 	return nil
 }
 
+func (a *Adapter) Walk(prefix block.ObjectPointer, walkFn block.WalkFunc) error {
+	a.mutex.RLock()
+	keyPrefix := getKey(prefix)
+	namespacePrefix := getKey(block.ObjectPointer{StorageNamespace: prefix.StorageNamespace})
+	var entries []block.WalkEntry
+	for key, data := range a.data {
+		if strings.HasPrefix(key, keyPrefix) {
+			etag := sha256.Sum256(data)
+			entries = append(entries, block.WalkEntry{
+				Identifier: strings.TrimPrefix(key, namespacePrefix),
+				Size:       int64(len(data)),
+				ETag:       hex.EncodeToString(etag[:]),
+			})
+		}
+	}
+	a.mutex.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Identifier < entries[j].Identifier
+	})
+	for _, entry := range entries {
+		if err := walkFn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *Adapter) Copy(sourceObj, destinationObj block.ObjectPointer) error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
@@ -189,6 +228,22 @@ func (a *Adapter) UploadPart(obj block.ObjectPointer, sizeBytes int64, reader io
 	return fmt.Sprintf("%x", code), nil
 }
 
+func (a *Adapter) UploadCopyPart(sourceObj, destinationObj block.ObjectPointer, uploadID string, partNumber int64) (string, error) {
+	r, err := a.Get(sourceObj, 0)
+	if err != nil {
+		return "", err
+	}
+	return a.UploadPart(destinationObj, -1, r, uploadID, partNumber)
+}
+
+func (a *Adapter) UploadCopyPartRange(sourceObj, destinationObj block.ObjectPointer, uploadID string, partNumber, startPosition, endPosition int64) (string, error) {
+	r, err := a.GetRange(sourceObj, startPosition, endPosition)
+	if err != nil {
+		return "", err
+	}
+	return a.UploadPart(destinationObj, endPosition-startPosition+1, r, uploadID, partNumber)
+}
+
 func (a *Adapter) AbortMultiPartUpload(obj block.ObjectPointer, uploadID string) error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
@@ -0,0 +1,115 @@
+package block
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/treeverse/lakefs/cmdutils"
+)
+
+// MemoryInventory is an Inventory of objects held in memory, sorted by key.  Adapters that
+// list their storage, or read inventory reports that are not sorted, create it once they
+// collected all objects.
+type MemoryInventory struct {
+	inventoryURL string
+	sourceName   string
+	live         bool
+	objects      []InventoryObject
+}
+
+// NewMemoryInventory returns an inventory of objects whose keys start with one of prefixes
+// (or all objects, if there are no prefixes), sorted by key.  Set live if objects were listed
+// from the current state of the storage: generating the same inventory URL again will list
+// the storage anew, rather than return the same objects.
+func NewMemoryInventory(inventoryURL, sourceName string, live bool, objects []InventoryObject, prefixes []string) *MemoryInventory {
+	if len(prefixes) > 0 {
+		filtered := make([]InventoryObject, 0, len(objects))
+		for _, obj := range objects {
+			if hasAnyPrefix(obj.Key, prefixes) {
+				filtered = append(filtered, obj)
+			}
+		}
+		objects = filtered
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+	return &MemoryInventory{
+		inventoryURL: inventoryURL,
+		sourceName:   sourceName,
+		live:         live,
+		objects:      objects,
+	}
+}
+
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (inv *MemoryInventory) Iterator() InventoryIterator {
+	progress := cmdutils.NewActiveProgress(fmt.Sprintf("Inventory (%s) Objects Read", inv.sourceName), cmdutils.Bar)
+	progress.SetTotal(int64(len(inv.objects)))
+	return &memoryInventoryIterator{
+		objects:  inv.objects,
+		idx:      -1,
+		progress: progress,
+	}
+}
+
+func (inv *MemoryInventory) SourceName() string {
+	return inv.sourceName
+}
+
+func (inv *MemoryInventory) InventoryURL() string {
+	return inv.inventoryURL
+}
+
+func (inv *MemoryInventory) IsLive() bool {
+	return inv.live
+}
+
+// IsLiveInventory returns true if inv was listed from the current state of the storage.  A
+// live inventory cannot be generated again to recover a previous state of the storage, so
+// imports should compare it with the objects of the previous import instead.
+func IsLiveInventory(inv Inventory) bool {
+	live, ok := inv.(interface{ IsLive() bool })
+	return ok && live.IsLive()
+}
+
+type memoryInventoryIterator struct {
+	objects  []InventoryObject
+	idx      int
+	progress *cmdutils.Progress
+}
+
+func (it *memoryInventoryIterator) Next() bool {
+	if it.idx >= len(it.objects)-1 {
+		it.idx = len(it.objects)
+		it.progress.SetCompleted(true)
+		return false
+	}
+	it.idx++
+	it.progress.SetCurrent(int64(it.idx + 1))
+	return true
+}
+
+func (it *memoryInventoryIterator) Err() error {
+	return nil
+}
+
+func (it *memoryInventoryIterator) Get() *InventoryObject {
+	if it.idx < 0 || it.idx >= len(it.objects) {
+		return nil
+	}
+	return &it.objects[it.idx]
+}
+
+func (it *memoryInventoryIterator) Progress() []*cmdutils.Progress {
+	return []*cmdutils.Progress{it.progress}
+}
@@ -14,8 +14,13 @@ const (
 	StorageTypeLocal
 	StorageTypeS3
 	StorageTypeGS
+	StorageTypeAzure
 )
 
+// azureBlobHostSuffix ends the host of https URLs of Azure Blob Storage accounts:
+// https://<account>.blob.core.windows.net/<container>/<path>
+const azureBlobHostSuffix = ".blob.core.windows.net"
+
 var (
 	ErrInvalidNamespace = errors.New("invalid namespace")
 )
@@ -24,6 +29,9 @@ type QualifiedKey struct {
 	StorageType      StorageType
 	StorageNamespace string
 	Key              string
+	// StorageAccount is the account named by the host of Azure https URLs, empty for all
+	// other URLs.
+	StorageAccount string
 }
 
 func GetStorageType(namespaceURL *url.URL) (StorageType, error) {
@@ -37,11 +45,34 @@ func GetStorageType(namespaceURL *url.URL) (StorageType, error) {
 		return StorageTypeLocal, nil
 	case "gs":
 		return StorageTypeGS, nil
+	case "azure":
+		return StorageTypeAzure, nil
+	case "https":
+		if strings.HasSuffix(namespaceURL.Host, azureBlobHostSuffix) {
+			return StorageTypeAzure, nil
+		}
+		return st, fmt.Errorf("%s: %w", namespaceURL.Host, ErrInvalidNamespace)
 	default:
 		return st, fmt.Errorf("%s: %w", namespaceURL.Scheme, ErrInvalidNamespace)
 	}
 }
 
+// namespaceAndPath returns the storage account, the storage namespace and the path within it
+// of u, a URL of storageType.  Azure https URLs name the account as the host and the
+// container as the first path element; all other URLs name no account and the storage
+// namespace as the host.
+func namespaceAndPath(u *url.URL, storageType StorageType) (string, string, string) {
+	if storageType != StorageTypeAzure || u.Scheme != "https" {
+		return "", u.Host, u.Path
+	}
+	account := strings.TrimSuffix(u.Hostname(), azureBlobHostSuffix)
+	parts := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 2)
+	if len(parts) == 1 {
+		return account, parts[0], ""
+	}
+	return account, parts[0], parts[1]
+}
+
 func formatPathWithNamespace(namespacePath, keyPath string) string {
 	namespacePath = strings.TrimPrefix(namespacePath, "/")
 	keyPath = strings.TrimPrefix(keyPath, "/")
@@ -74,11 +105,12 @@ func ResolveNamespace(defaultNamespace, key string) (QualifiedKey, error) {
 		if err != nil {
 			return qk, err
 		}
-
+		account, namespace, namespacePath := namespaceAndPath(parsedNs, storageType)
 		return QualifiedKey{
 			StorageType:      storageType,
-			StorageNamespace: parsedNs.Host,
-			Key:              formatPathWithNamespace(parsedNs.Path, key),
+			StorageNamespace: namespace,
+			Key:              formatPathWithNamespace(namespacePath, key),
+			StorageAccount:   account,
 		}, nil
 	}
 
@@ -87,9 +119,25 @@ func ResolveNamespace(defaultNamespace, key string) (QualifiedKey, error) {
 	if err != nil {
 		return qk, err
 	}
+	account, namespace, keyPath := namespaceAndPath(parsedKey, storageType)
 	return QualifiedKey{
 		StorageType:      storageType,
-		StorageNamespace: parsedKey.Host,
-		Key:              formatPathWithNamespace("", parsedKey.Path),
+		StorageNamespace: namespace,
+		Key:              formatPathWithNamespace("", keyPath),
+		StorageAccount:   account,
 	}, nil
 }
+
+// RelativeIdentifier returns the identifier of the object stored at key of the qualified
+// storage namespace storageNamespace, relative to that namespace.  It is the inverse of
+// resolving an identifier with ResolveNamespace.
+func RelativeIdentifier(storageNamespace, key string) (string, error) {
+	qk, err := ResolveNamespace(storageNamespace, "")
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(key, qk.Key) {
+		return "", fmt.Errorf("key %s not under %s: %w", key, storageNamespace, ErrInvalidNamespace)
+	}
+	return strings.TrimPrefix(key, qk.Key), nil
+}
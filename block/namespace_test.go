@@ -60,6 +60,45 @@ func TestResolveNamespace(t *testing.T) {
 				Key:              "bar/baz",
 			},
 		},
+		{
+			Name:             "valid_namespace_azure",
+			DefaultNamespace: "azure://container/repo",
+			Key:              "bar/baz",
+			Expected: block.QualifiedKey{
+				StorageType:      block.StorageTypeAzure,
+				StorageNamespace: "container",
+				Key:              "repo/bar/baz",
+			},
+		},
+		{
+			Name:             "valid_namespace_azure_https",
+			DefaultNamespace: "https://account.blob.core.windows.net/container/repo",
+			Key:              "bar/baz",
+			Expected: block.QualifiedKey{
+				StorageType:      block.StorageTypeAzure,
+				StorageNamespace: "container",
+				Key:              "repo/bar/baz",
+				StorageAccount:   "account",
+			},
+		},
+		{
+			Name:             "valid_fq_key_azure_https",
+			DefaultNamespace: "mem://foo/",
+			Key:              "https://account.blob.core.windows.net/container/bar/baz",
+			Expected: block.QualifiedKey{
+				StorageType:      block.StorageTypeAzure,
+				StorageNamespace: "container",
+				Key:              "bar/baz",
+				StorageAccount:   "account",
+			},
+		},
+		{
+			Name:             "invalid_namespace_https_not_azure",
+			DefaultNamespace: "https://example.com/container/repo",
+			Key:              "bar/baz",
+			ExpectedErr:      block.ErrInvalidNamespace,
+			Expected:         block.QualifiedKey{},
+		},
 		{
 			Name:             "invalid_namespace_wrong_scheme",
 			DefaultNamespace: "memzzzz://foo/",
@@ -95,3 +134,50 @@ func TestResolveNamespace(t *testing.T) {
 		})
 	}
 }
+
+func TestRelativeIdentifier(t *testing.T) {
+	cases := []struct {
+		Name             string
+		StorageNamespace string
+		Key              string
+		ExpectedErr      error
+		Expected         string
+	}{
+		{
+			Name:             "bucket_namespace",
+			StorageNamespace: "s3://foo",
+			Key:              "bar/baz",
+			Expected:         "bar/baz",
+		},
+		{
+			Name:             "path_namespace",
+			StorageNamespace: "s3://foo/repo",
+			Key:              "repo/bar/baz",
+			Expected:         "bar/baz",
+		},
+		{
+			Name:             "azure_https_namespace",
+			StorageNamespace: "https://account.blob.core.windows.net/container/repo",
+			Key:              "repo/bar/baz",
+			Expected:         "bar/baz",
+		},
+		{
+			Name:             "key_outside_namespace",
+			StorageNamespace: "s3://foo/repo",
+			Key:              "other/bar/baz",
+			ExpectedErr:      block.ErrInvalidNamespace,
+		},
+	}
+
+	for _, cas := range cases {
+		t.Run(cas.Name, func(t *testing.T) {
+			id, err := block.RelativeIdentifier(cas.StorageNamespace, cas.Key)
+			if !errors.Is(err, cas.ExpectedErr) {
+				t.Fatalf("got unexpected error :%v - expected %v", err, cas.ExpectedErr)
+			}
+			if id != cas.Expected {
+				t.Fatalf("expected %s got %s", cas.Expected, id)
+			}
+		})
+	}
+}
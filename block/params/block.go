@@ -12,6 +12,7 @@ type AdapterConfig interface {
 	GetBlockAdapterLocalParams() (Local, error)
 	GetBlockAdapterS3Params() (S3, error)
 	GetBlockAdapterGSParams() (GS, error)
+	GetBlockAdapterAzureParams() (Azure, error)
 }
 
 type Mem struct{}
@@ -30,3 +31,11 @@ type GS struct {
 	CredentialsFile string
 	CredentialsJSON string
 }
+
+type Azure struct {
+	StorageAccount   string
+	StorageAccessKey string
+	// Endpoint is the blob service URL of the storage account, e.g. of an Azurite emulator
+	Endpoint   string
+	TryTimeout time.Duration
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -156,6 +157,47 @@ func (a *Adapter) UploadPart(obj block.ObjectPointer, sizeBytes int64, reader io
 	return etag, nil
 }
 
+func (a *Adapter) UploadCopyPart(sourceObj, destinationObj block.ObjectPointer, uploadID string, partNumber int64) (string, error) {
+	return a.uploadCopyPart("UploadCopyPart", sourceObj, destinationObj, uploadID, partNumber, nil)
+}
+
+func (a *Adapter) UploadCopyPartRange(sourceObj, destinationObj block.ObjectPointer, uploadID string, partNumber, startPosition, endPosition int64) (string, error) {
+	copySourceRange := aws.String(fmt.Sprintf("bytes=%d-%d", startPosition, endPosition))
+	return a.uploadCopyPart("UploadCopyPartRange", sourceObj, destinationObj, uploadID, partNumber, copySourceRange)
+}
+
+func (a *Adapter) uploadCopyPart(operation string, sourceObj, destinationObj block.ObjectPointer, uploadID string, partNumber int64, copySourceRange *string) (string, error) {
+	var err error
+	defer reportMetrics(operation, time.Now(), nil, &err)
+	qualifiedDestinationKey, err := resolveNamespace(destinationObj)
+	if err != nil {
+		return "", err
+	}
+	qualifiedSourceKey, err := resolveNamespace(sourceObj)
+	if err != nil {
+		return "", err
+	}
+	uploadID = a.uploadIDTranslator.TranslateUploadID(uploadID)
+	uploadPartCopyObject := s3.UploadPartCopyInput{
+		Bucket:          aws.String(qualifiedDestinationKey.StorageNamespace),
+		Key:             aws.String(qualifiedDestinationKey.Key),
+		PartNumber:      aws.Int64(partNumber),
+		UploadId:        aws.String(uploadID),
+		CopySource:      aws.String(qualifiedSourceKey.StorageNamespace + "/" + qualifiedSourceKey.Key),
+		CopySourceRange: copySourceRange,
+	}
+	resp, err := a.s3.UploadPartCopy(&uploadPartCopyObject)
+	if err != nil {
+		a.log().WithError(err).Error("failed to copy S3 object part")
+		return "", err
+	}
+	if resp.CopyPartResult == nil || resp.CopyPartResult.ETag == nil {
+		err = ErrMissingETag
+		return "", err
+	}
+	return *resp.CopyPartResult.ETag, nil
+}
+
 func (a *Adapter) streamToS3(sdkRequest *request.Request, sizeBytes int64, reader io.Reader) (string, error) {
 	sigTime := time.Now()
 	log := a.log().WithField("operation", "PutObject")
@@ -297,7 +339,48 @@ func (a *Adapter) GetProperties(obj block.ObjectPointer) (block.Properties, erro
 	if err != nil {
 		return block.Properties{}, err
 	}
-	return block.Properties{StorageClass: s3Props.StorageClass}, nil
+	return block.Properties{
+		StorageClass: s3Props.StorageClass,
+		Size:         aws.Int64Value(s3Props.ContentLength),
+		ETag:         strings.Trim(aws.StringValue(s3Props.ETag), "\""),
+	}, nil
+}
+
+func (a *Adapter) GetPreSignedURL(obj block.ObjectPointer, mode block.PreSignMode) (string, error) {
+	var err error
+	defer reportMetrics("GetPreSignedURL", time.Now(), nil, &err)
+	qualifiedKey, err := resolveNamespace(obj)
+	if err != nil {
+		return "", err
+	}
+	log := a.log().WithFields(logging.Fields{
+		"operation":  "GetPreSignedURL",
+		"namespace":  obj.StorageNamespace,
+		"identifier": obj.Identifier,
+		"mode":       mode,
+	})
+	var req *request.Request
+	switch mode {
+	case block.PreSignModeRead:
+		req, _ = a.s3.GetObjectRequest(&s3.GetObjectInput{
+			Bucket: aws.String(qualifiedKey.StorageNamespace),
+			Key:    aws.String(qualifiedKey.Key),
+		})
+	case block.PreSignModeWrite:
+		req, _ = a.s3.PutObjectRequest(&s3.PutObjectInput{
+			Bucket: aws.String(qualifiedKey.StorageNamespace),
+			Key:    aws.String(qualifiedKey.Key),
+		})
+	default:
+		err = block.ErrOperationNotSupported
+		return "", err
+	}
+	signedURL, err := req.Presign(block.DefaultPreSignExpiry)
+	if err != nil {
+		log.WithError(err).Error("could not presign request")
+		return "", err
+	}
+	return signedURL, nil
 }
 
 func (a *Adapter) Remove(obj block.ObjectPointer) error {
@@ -323,6 +406,45 @@ func (a *Adapter) Remove(obj block.ObjectPointer) error {
 	return err
 }
 
+func (a *Adapter) Walk(prefix block.ObjectPointer, walkFn block.WalkFunc) error {
+	var err error
+	defer reportMetrics("Walk", time.Now(), nil, &err)
+	qualifiedKey, err := resolveNamespace(prefix)
+	if err != nil {
+		return err
+	}
+	listObjectsInput := &s3.ListObjectsV2Input{
+		Bucket: aws.String(qualifiedKey.StorageNamespace),
+		Prefix: aws.String(qualifiedKey.Key),
+	}
+	var walkErr error
+	err = a.s3.ListObjectsV2PagesWithContext(a.ctx, listObjectsInput, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			var id string
+			id, walkErr = block.RelativeIdentifier(prefix.StorageNamespace, aws.StringValue(obj.Key))
+			if walkErr != nil {
+				return false
+			}
+			walkErr = walkFn(block.WalkEntry{
+				Identifier:   id,
+				Size:         aws.Int64Value(obj.Size),
+				LastModified: aws.TimeValue(obj.LastModified),
+				ETag:         strings.Trim(aws.StringValue(obj.ETag), `"`),
+			})
+			if walkErr != nil {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		a.log().WithError(err).Error("failed to list S3 objects")
+		return err
+	}
+	err = walkErr
+	return err
+}
+
 func (a *Adapter) Copy(sourceObj, destinationObj block.ObjectPointer) error {
 	var err error
 	defer reportMetrics("Copy", time.Now(), nil, &err)
@@ -57,10 +57,19 @@ func (a *Adapter) GetProperties(_ block.ObjectPointer) (block.Properties, error)
 	return block.Properties{}, nil
 }
 
+func (a *Adapter) GetPreSignedURL(_ block.ObjectPointer, _ block.PreSignMode) (string, error) {
+	return "", block.ErrOperationNotSupported
+}
+
 func (a *Adapter) Remove(_ block.ObjectPointer) error {
 	return nil
 }
 
+func (a *Adapter) Walk(_ block.ObjectPointer, _ block.WalkFunc) error {
+	// nothing is ever stored
+	return nil
+}
+
 func (a *Adapter) Copy(_, _ block.ObjectPointer) error {
 	return nil
 }
@@ -85,6 +94,16 @@ func (a *Adapter) UploadPart(obj block.ObjectPointer, sizeBytes int64, reader io
 	return hex.EncodeToString(code), nil
 }
 
+func (a *Adapter) UploadCopyPart(_, destinationObj block.ObjectPointer, uploadID string, partNumber int64) (string, error) {
+	const dataSize = 1024
+	return a.UploadPart(destinationObj, dataSize, &io.LimitedReader{R: rand.Reader, N: dataSize}, uploadID, partNumber)
+}
+
+func (a *Adapter) UploadCopyPartRange(_, destinationObj block.ObjectPointer, uploadID string, partNumber, startPosition, endPosition int64) (string, error) {
+	n := endPosition - startPosition + 1
+	return a.UploadPart(destinationObj, n, &io.LimitedReader{R: rand.Reader, N: n}, uploadID, partNumber)
+}
+
 func (a *Adapter) AbortMultiPartUpload(block.ObjectPointer, string) error {
 	return nil
 }
@@ -0,0 +1,149 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/treeverse/lakefs/db"
+)
+
+// BranchProtectionBlockedAction is an operation on a branch that a protection rule may forbid
+type BranchProtectionBlockedAction string
+
+const (
+	// BranchProtectionBlockedActionStagingWrite forbids writing, deleting and resetting objects
+	// directly on the branch
+	BranchProtectionBlockedActionStagingWrite BranchProtectionBlockedAction = "staging_write"
+	// BranchProtectionBlockedActionCommit forbids committing directly on the branch.  Merges
+	// into the branch are still allowed.
+	BranchProtectionBlockedActionCommit BranchProtectionBlockedAction = "commit"
+	// BranchProtectionBlockedActionDelete forbids deleting the branch
+	BranchProtectionBlockedActionDelete BranchProtectionBlockedAction = "delete"
+)
+
+func (a BranchProtectionBlockedAction) IsValid() bool {
+	switch a {
+	case BranchProtectionBlockedActionStagingWrite,
+		BranchProtectionBlockedActionCommit,
+		BranchProtectionBlockedActionDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// BranchProtectionRule forbids BlockedActions on all branches of a repository whose name
+// matches Pattern.  Patterns use path.Match syntax.
+type BranchProtectionRule struct {
+	Pattern        string         `db:"pattern"`
+	BlockedActions pq.StringArray `db:"blocked_actions"`
+	CreationDate   time.Time      `db:"created_at"`
+}
+
+// Blocks returns true if the rule forbids action on branch
+func (r *BranchProtectionRule) Blocks(branch string, action BranchProtectionBlockedAction) bool {
+	matched, err := path.Match(r.Pattern, branch)
+	if err != nil || !matched {
+		return false
+	}
+	for _, blocked := range r.BlockedActions {
+		if BranchProtectionBlockedAction(blocked) == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the pattern is well formed and all blocked actions are known
+func (r *BranchProtectionRule) Validate() error {
+	if r.Pattern == "" {
+		return fmt.Errorf("%w: empty pattern", ErrInvalidValue)
+	}
+	if _, err := path.Match(r.Pattern, ""); err != nil {
+		return fmt.Errorf("%w: pattern %s: %s", ErrInvalidValue, r.Pattern, err)
+	}
+	if len(r.BlockedActions) == 0 {
+		return fmt.Errorf("%w: no blocked actions", ErrInvalidValue)
+	}
+	for _, action := range r.BlockedActions {
+		if !BranchProtectionBlockedAction(action).IsValid() {
+			return fmt.Errorf("%w: blocked action %s", ErrInvalidValue, action)
+		}
+	}
+	return nil
+}
+
+// BranchProtectionManager stores branch protection rules of repositories and checks branch
+// operations against them.  Rules are kept by repository name so catalogers of all types
+// share them.
+type BranchProtectionManager struct {
+	db db.Database
+}
+
+func NewBranchProtectionManager(db db.Database) *BranchProtectionManager {
+	return &BranchProtectionManager{db: db}
+}
+
+func (m *BranchProtectionManager) GetRules(ctx context.Context, repository string) ([]*BranchProtectionRule, error) {
+	var rules []*BranchProtectionRule
+	err := m.db.WithContext(ctx).Select(&rules,
+		`SELECT pattern, blocked_actions, created_at
+                 FROM catalog_branch_protection_rules
+                 WHERE repository = $1
+                 ORDER BY pattern`, repository)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// SetRule creates the rule, or replaces the blocked actions of an existing rule with the same
+// pattern
+func (m *BranchProtectionManager) SetRule(ctx context.Context, repository string, rule *BranchProtectionRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	_, err := m.db.WithContext(ctx).Exec(
+		`INSERT INTO catalog_branch_protection_rules (repository, pattern, blocked_actions, created_at)
+                 VALUES ($1, $2, $3, $4)
+                 ON CONFLICT (repository, pattern)
+                 DO UPDATE SET (blocked_actions, created_at) = (EXCLUDED.blocked_actions, EXCLUDED.created_at)`,
+		repository, rule.Pattern, rule.BlockedActions, time.Now())
+	return err
+}
+
+func (m *BranchProtectionManager) DeleteRule(ctx context.Context, repository string, pattern string) error {
+	res, err := m.db.WithContext(ctx).Exec(
+		`DELETE FROM catalog_branch_protection_rules WHERE repository = $1 AND pattern = $2`,
+		repository, pattern)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() != 1 {
+		return ErrProtectionRuleNotFound
+	}
+	return nil
+}
+
+// DeleteRepositoryRules deletes all rules of repository
+func (m *BranchProtectionManager) DeleteRepositoryRules(ctx context.Context, repository string) error {
+	_, err := m.db.WithContext(ctx).Exec(`DELETE FROM catalog_branch_protection_rules WHERE repository = $1`, repository)
+	return err
+}
+
+// Check returns ErrBranchProtected if a rule of repository forbids action on branch
+func (m *BranchProtectionManager) Check(ctx context.Context, repository string, branch string, action BranchProtectionBlockedAction) error {
+	rules, err := m.GetRules(ctx, repository)
+	if err != nil {
+		return fmt.Errorf("get branch protection rules: %w", err)
+	}
+	for _, rule := range rules {
+		if rule.Blocks(branch, action) {
+			return fmt.Errorf("%w: %s on branch %s blocked by rule %s", ErrBranchProtected, action, branch, rule.Pattern)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package catalog_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/treeverse/lakefs/catalog"
+)
+
+func TestBranchProtectionRule_Blocks(t *testing.T) {
+	rule := &catalog.BranchProtectionRule{
+		Pattern:        "release-*",
+		BlockedActions: pq.StringArray{"staging_write", "commit"},
+	}
+	tests := []struct {
+		name     string
+		branch   string
+		action   catalog.BranchProtectionBlockedAction
+		expected bool
+	}{
+		{name: "write", branch: "release-1", action: catalog.BranchProtectionBlockedActionStagingWrite, expected: true},
+		{name: "commit", branch: "release-1", action: catalog.BranchProtectionBlockedActionCommit, expected: true},
+		{name: "delete", branch: "release-1", action: catalog.BranchProtectionBlockedActionDelete, expected: false},
+		{name: "no_match", branch: "master", action: catalog.BranchProtectionBlockedActionCommit, expected: false},
+		{name: "prefix_only", branch: "release", action: catalog.BranchProtectionBlockedActionCommit, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if blocks := rule.Blocks(tt.branch, tt.action); blocks != tt.expected {
+				t.Errorf("Blocks(%s, %s)=%t, expected %t", tt.branch, tt.action, blocks, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBranchProtectionRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    catalog.BranchProtectionRule
+		wantErr bool
+	}{
+		{name: "valid", rule: catalog.BranchProtectionRule{Pattern: "master", BlockedActions: pq.StringArray{"staging_write", "commit", "delete"}}},
+		{name: "empty_pattern", rule: catalog.BranchProtectionRule{BlockedActions: pq.StringArray{"commit"}}, wantErr: true},
+		{name: "bad_pattern", rule: catalog.BranchProtectionRule{Pattern: "release-[", BlockedActions: pq.StringArray{"commit"}}, wantErr: true},
+		{name: "no_actions", rule: catalog.BranchProtectionRule{Pattern: "master"}, wantErr: true},
+		{name: "unknown_action", rule: catalog.BranchProtectionRule{Pattern: "master", BlockedActions: pq.StringArray{"merge"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.wantErr && !errors.Is(err, catalog.ErrInvalidValue) {
+				t.Errorf("Validate() err=%v, expected %s", err, catalog.ErrInvalidValue)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Validate() unexpected err=%s", err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"time"
 
@@ -15,6 +16,13 @@ const (
 	DefaultPathDelimiter    = "/"
 )
 
+// Merge strategies used to resolve conflicts, an empty strategy fails on conflicts
+const (
+	MergeStrategyFail       = "fail"
+	MergeStrategySourceWins = "source-wins"
+	MergeStrategyDestWins   = "dest-wins"
+)
+
 type DedupReport struct {
 	Repository         string
 	StorageNamespace   string
@@ -59,8 +67,21 @@ type GetEntryParams struct {
 	ReturnExpired bool
 }
 
+// EntryCondition checks the current entry of a path before CreateEntry replaces it.  current
+// is nil if the path has no entry.  A non-nil error fails CreateEntry with that error.
+type EntryCondition func(current *Entry) error
+
 type CreateEntryParams struct {
 	Dedup DedupParams
+	// If set, Condition is checked atomically with creating the entry, allowing concurrent
+	// writers to create entries only if absent or to compare-and-swap them.
+	Condition EntryCondition
+}
+
+// ConditionalWriter is implemented by catalogers whose CreateEntry supports a Condition.
+// Other catalogers fail conditional writes with ErrFeatureNotSupported.
+type ConditionalWriter interface {
+	SupportsConditionalWrites() bool
 }
 
 type Cataloger interface {
@@ -84,6 +105,11 @@ type Cataloger interface {
 	GetBranchReference(ctx context.Context, repository, branch string) (string, error)
 	ResetBranch(ctx context.Context, repository, branch string) error
 
+	CreateTag(ctx context.Context, repository, tagID string, ref string) (string, error)
+	DeleteTag(ctx context.Context, repository, tagID string) error
+	ListTags(ctx context.Context, repository string, limit int, after string) ([]*Tag, bool, error)
+	GetTag(ctx context.Context, repository, tagID string) (string, error)
+
 	// GetEntry returns the current entry for path in repository branch reference.  Returns
 	// the entry with ExpiredError if it has expired from underlying storage.
 	GetEntry(ctx context.Context, repository, reference string, path string, params GetEntryParams) (*Entry, error)
@@ -118,15 +144,23 @@ type Cataloger interface {
 	Commit(ctx context.Context, repository, branch string, message string, committer string, metadata Metadata) (*CommitLog, error)
 	GetCommit(ctx context.Context, repository, reference string) (*CommitLog, error)
 	ListCommits(ctx context.Context, repository, branch string, fromReference string, limit int) ([]*CommitLog, bool, error)
+	ListCommitsByPath(ctx context.Context, repository, branch string, path string, fromReference string, limit int) ([]*CommitLog, bool, error)
 	RollbackCommit(ctx context.Context, repository, branch string, reference string) error
+	CherryPick(ctx context.Context, repository, reference, branch string, committer string) (*CommitLog, error)
 
 	Diff(ctx context.Context, repository, leftReference string, rightReference string, params DiffParams) (Differences, bool, error)
 	DiffUncommitted(ctx context.Context, repository, branch string, limit int, after string) (Differences, bool, error)
 
-	Merge(ctx context.Context, repository, leftBranch, rightBranch, committer, message string, metadata Metadata) (*MergeResult, error)
+	Merge(ctx context.Context, repository, leftBranch, rightBranch, committer, message string, metadata Metadata, strategy string) (*MergeResult, error)
 
 	Hooks() *CatalogerHooks
 
+	GetBranchProtectionRules(ctx context.Context, repository string) ([]*BranchProtectionRule, error)
+	SetBranchProtectionRule(ctx context.Context, repository string, rule *BranchProtectionRule) error
+	DeleteBranchProtectionRule(ctx context.Context, repository string, pattern string) error
+	// CheckBranchProtection returns ErrBranchProtected if a protection rule forbids action on branch
+	CheckBranchProtection(ctx context.Context, repository, branch string, action BranchProtectionBlockedAction) error
+
 	GetExportConfigurationForBranch(repository string, branch string) (ExportConfiguration, error)
 	GetExportConfigurations() ([]ExportConfigurationForBranch, error)
 	PutExportConfiguration(repository string, branch string, conf *ExportConfiguration) error
@@ -166,9 +200,53 @@ type ExportConfigurationForBranch struct {
 type PostCommitFunc func(ctx context.Context, repo, branch string, commitLog CommitLog) error
 type PostMergeFunc func(ctx context.Context, repo, branch string, mergeResult MergeResult) error
 
-// CatalogerHooks describes the hooks available for some operations on the catalog.  Hooks are
-// called after the transaction ends; if they return an error they do not affect commit/merge.
+// HookDifferencesLimit is the maximal number of differences passed to pre-commit and pre-merge
+// hooks
+const HookDifferencesLimit = 1000
+
+// PreCommitEvent describes a commit about to be made on a branch
+type PreCommitEvent struct {
+	Committer string
+	Message   string
+	Metadata  Metadata
+	// Differences are the uncommitted changes on the branch, up to HookDifferencesLimit of them
+	Differences Differences
+	// DifferencesTruncated is true if the branch has more than HookDifferencesLimit changes
+	DifferencesTruncated bool
+}
+
+// PreMergeEvent describes a merge about to be made into a branch
+type PreMergeEvent struct {
+	SourceRef string
+	Committer string
+	Message   string
+	Metadata  Metadata
+	// Differences are the changes the merge applies to the destination branch, up to
+	// HookDifferencesLimit of them
+	Differences Differences
+	// DifferencesTruncated is true if the merge has more than HookDifferencesLimit changes
+	DifferencesTruncated bool
+}
+
+// PreCommitFunc is called before a commit on branch of repo.  Returning an error rejects the
+// commit, the error is the reason for rejecting it.
+type PreCommitFunc func(ctx context.Context, repo, branch string, event PreCommitEvent) error
+
+// PreMergeFunc is called before a merge into branch of repo.  Returning an error rejects the
+// merge, the error is the reason for rejecting it.
+type PreMergeFunc func(ctx context.Context, repo, branch string, event PreMergeEvent) error
+
+// CatalogerHooks describes the hooks available for some operations on the catalog.  Post hooks
+// are called after the transaction ends; if they return an error they do not affect
+// commit/merge.  Pre hooks are called before the transaction starts; if they return an error
+// the commit/merge fails with ErrHookRejected.
 type CatalogerHooks struct {
+	// PreCommit hooks are called before a commit.
+	PreCommit []PreCommitFunc
+
+	// PreMerge hooks are called before a merge.
+	PreMerge []PreMergeFunc
+
 	// PostCommit hooks are called at the end of a commit.
 	PostCommit []PostCommitFunc
 
@@ -176,6 +254,16 @@ type CatalogerHooks struct {
 	PostMerge []PostMergeFunc
 }
 
+func (h *CatalogerHooks) AddPreCommit(f PreCommitFunc) *CatalogerHooks {
+	h.PreCommit = append(h.PreCommit, f)
+	return h
+}
+
+func (h *CatalogerHooks) AddPreMerge(f PreMergeFunc) *CatalogerHooks {
+	h.PreMerge = append(h.PreMerge, f)
+	return h
+}
+
 func (h *CatalogerHooks) AddPostCommit(f PostCommitFunc) *CatalogerHooks {
 	h.PostCommit = append(h.PostCommit, f)
 	return h
@@ -185,3 +273,24 @@ func (h *CatalogerHooks) AddPostMerge(f PostMergeFunc) *CatalogerHooks {
 	h.PostMerge = append(h.PostMerge, f)
 	return h
 }
+
+// RunPreCommit calls PreCommit hooks in order, and stops at the first hook that rejects the
+// commit
+func (h *CatalogerHooks) RunPreCommit(ctx context.Context, repo, branch string, event PreCommitEvent) error {
+	for _, hook := range h.PreCommit {
+		if err := hook(ctx, repo, branch, event); err != nil {
+			return fmt.Errorf("%w: %s", ErrHookRejected, err)
+		}
+	}
+	return nil
+}
+
+// RunPreMerge calls PreMerge hooks in order, and stops at the first hook that rejects the merge
+func (h *CatalogerHooks) RunPreMerge(ctx context.Context, repo, branch string, event PreMergeEvent) error {
+	for _, hook := range h.PreMerge {
+		if err := hook(ctx, repo, branch, event); err != nil {
+			return fmt.Errorf("%w: %s", ErrHookRejected, err)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package catalog
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/treeverse/lakefs/db"
 )
@@ -28,9 +29,26 @@ var (
 	ErrInvalidLockValue            = errors.New("invalid lock value")
 	ErrNoDifferenceWasFound        = errors.New("no difference was found")
 	ErrConflictFound               = errors.New("conflict found")
+	ErrInvalidMergeStrategy        = fmt.Errorf("merge strategy: %w", ErrInvalidValue)
 	ErrUnsupportedRelation         = errors.New("unsupported relation")
 	ErrUnsupportedDelimiter        = errors.New("unsupported delimiter")
 	ErrBadTypeConversion           = errors.New("bad type")
 	ErrExportFailed                = errors.New("export failed")
 	ErrRollbackWithActiveBranch    = fmt.Errorf("%w: rollback with active branch", ErrFeatureNotSupported)
+	ErrBranchProtected             = errors.New("branch is protected")
+	ErrProtectionRuleNotFound      = fmt.Errorf("branch protection rule %w", db.ErrNotFound)
+	ErrHookRejected                = errors.New("rejected by hook")
 )
+
+// ConflictError is returned by operations that failed because of conflicting paths
+type ConflictError struct {
+	Paths []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrConflictFound, strings.Join(e.Paths, ", "))
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrConflictFound
+}
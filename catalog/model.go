@@ -50,6 +50,11 @@ type Branch struct {
 	Name       string `db:"name"`
 }
 
+type Tag struct {
+	ID       string
+	CommitID string
+}
+
 func (j Metadata) Value() (driver.Value, error) {
 	if j == nil {
 		return json.Marshal(struct{}{})
@@ -31,6 +31,7 @@ type cataloger struct {
 	dedupReportCh        chan *catalog.DedupReport
 	readEntryRequestChan chan *readRequest
 	hooks                catalog.CatalogerHooks
+	branchProtection     *catalog.BranchProtectionManager
 }
 
 const (
@@ -106,6 +107,7 @@ func NewCataloger(db db.Database, options ...CatalogerOption) catalog.Cataloger
 		db:                 db,
 		dedupCh:            make(chan *dedupRequest, dedupChannelSize),
 		dedupReportEnabled: true,
+		branchProtection:   catalog.NewBranchProtectionManager(db),
 		Catalog: params.Catalog{
 			BatchRead: params.BatchRead{
 				EntryMaxWait:  defaultBatchReadEntryMaxWait,
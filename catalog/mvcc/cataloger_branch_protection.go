@@ -0,0 +1,41 @@
+package mvcc
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/catalog"
+)
+
+func (c *cataloger) GetBranchProtectionRules(ctx context.Context, repository string) ([]*catalog.BranchProtectionRule, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+	}); err != nil {
+		return nil, err
+	}
+	return c.branchProtection.GetRules(ctx, repository)
+}
+
+func (c *cataloger) SetBranchProtectionRule(ctx context.Context, repository string, rule *catalog.BranchProtectionRule) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+	}); err != nil {
+		return err
+	}
+	if _, err := c.GetRepository(ctx, repository); err != nil {
+		return err
+	}
+	return c.branchProtection.SetRule(ctx, repository, rule)
+}
+
+func (c *cataloger) DeleteBranchProtectionRule(ctx context.Context, repository string, pattern string) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+	}); err != nil {
+		return err
+	}
+	return c.branchProtection.DeleteRule(ctx, repository, pattern)
+}
+
+func (c *cataloger) CheckBranchProtection(ctx context.Context, repository string, branch string, action catalog.BranchProtectionBlockedAction) error {
+	return c.branchProtection.Check(ctx, repository, branch, action)
+}
@@ -0,0 +1,11 @@
+package mvcc
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/catalog"
+)
+
+func (c *cataloger) CherryPick(_ context.Context, _, _, _ string, _ string) (*catalog.CommitLog, error) {
+	return nil, catalog.ErrFeatureNotSupported
+}
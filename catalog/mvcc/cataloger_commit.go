@@ -17,6 +17,12 @@ func (c *cataloger) Commit(ctx context.Context, repository, branch string, messa
 	}); err != nil {
 		return nil, err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionCommit); err != nil {
+		return nil, err
+	}
+	if err := c.runPreCommitHooks(ctx, repository, branch, committer, message, metadata); err != nil {
+		return nil, err
+	}
 
 	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
 		branchID, err := getBranchID(tx, repository, branch, LockTypeUpdate)
@@ -92,6 +98,25 @@ func (c *cataloger) Commit(ctx context.Context, repository, branch string, messa
 	return commitLog, nil
 }
 
+// runPreCommitHooks passes the uncommitted changes on branch to the PreCommit hooks, it returns
+// an error if any hook rejects the commit
+func (c *cataloger) runPreCommitHooks(ctx context.Context, repository, branch string, committer string, message string, metadata catalog.Metadata) error {
+	if len(c.Hooks().PreCommit) == 0 {
+		return nil
+	}
+	differences, hasMore, err := c.DiffUncommitted(ctx, repository, branch, catalog.HookDifferencesLimit, "")
+	if err != nil {
+		return fmt.Errorf("diff uncommitted for pre-commit hooks: %w", err)
+	}
+	return c.Hooks().RunPreCommit(ctx, repository, branch, catalog.PreCommitEvent{
+		Committer:            committer,
+		Message:              message,
+		Metadata:             metadata,
+		Differences:          differences,
+		DifferencesTruncated: hasMore,
+	})
+}
+
 func commitUpdateCommittedEntriesWithMaxCommit(tx db.Tx, branchID int64, commitID CommitID) (int64, error) {
 	res, err := tx.Exec(`UPDATE catalog_entries_v SET max_commit = $2
 			WHERE branch_id = $1 AND is_committed
@@ -19,6 +19,9 @@ func (c *cataloger) CreateEntries(ctx context.Context, repository, branch string
 	}); err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionStagingWrite); err != nil {
+		return err
+	}
 
 	// nothing to do in case we don't have entries
 	if len(entries) == 0 {
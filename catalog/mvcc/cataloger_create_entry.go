@@ -17,6 +17,12 @@ func (c *cataloger) CreateEntry(ctx context.Context, repository, branch string,
 	}); err != nil {
 		return err
 	}
+	if params.Condition != nil {
+		return fmt.Errorf("%w: conditional create entry", catalog.ErrFeatureNotSupported)
+	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionStagingWrite); err != nil {
+		return err
+	}
 
 	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
 		branchID, err := c.getBranchIDCache(tx, repository, branch)
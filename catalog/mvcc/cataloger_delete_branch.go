@@ -16,6 +16,9 @@ func (c *cataloger) DeleteBranch(ctx context.Context, repository, branch string)
 	}); err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionDelete); err != nil {
+		return err
+	}
 
 	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
 		branchID, err := getBranchID(tx, repository, branch, LockTypeUpdate)
@@ -17,6 +17,9 @@ func (c *cataloger) DeleteEntry(ctx context.Context, repository, branch string,
 	}); err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionStagingWrite); err != nil {
+		return err
+	}
 	if path == "" {
 		return db.ErrNotFound
 	}
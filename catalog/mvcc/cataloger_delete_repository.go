@@ -26,5 +26,8 @@ func (c *cataloger) DeleteRepository(ctx context.Context, repository string) err
 		}
 		return nil, nil
 	}, c.txOpts(ctx)...)
-	return err
+	if err != nil {
+		return err
+	}
+	return c.branchProtection.DeleteRepositoryRules(ctx, repository)
 }
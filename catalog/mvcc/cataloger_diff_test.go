@@ -241,7 +241,7 @@ func TestCataloger_Diff_SameBranchDiffMergedChanges(t *testing.T) {
 	// commit and merge changes
 	_, err := c.Commit(ctx, repository, catalog.DefaultBranchName, "commit changes to "+catalog.DefaultBranchName, "tester", nil)
 	testutil.MustDo(t, "initial branch commit", err)
-	firstCommit, err := c.Merge(ctx, repository, catalog.DefaultBranchName, "branch1", "tester", "merge changes from master to branch1", nil)
+	firstCommit, err := c.Merge(ctx, repository, catalog.DefaultBranchName, "branch1", "tester", "merge changes from master to branch1", nil, "")
 	testutil.MustDo(t, "merge changes from master to branch1", err)
 
 	// delete
@@ -257,7 +257,7 @@ func TestCataloger_Diff_SameBranchDiffMergedChanges(t *testing.T) {
 	// commit and merge changes
 	_, err = c.Commit(ctx, repository, catalog.DefaultBranchName, "commit changes", "tester", nil)
 	testutil.MustDo(t, "commit branch changes", err)
-	secondCommit, err := c.Merge(ctx, repository, catalog.DefaultBranchName, "branch1", "tester", "merge more changes from master to branch1", nil)
+	secondCommit, err := c.Merge(ctx, repository, catalog.DefaultBranchName, "branch1", "tester", "merge more changes from master to branch1", nil, "")
 	testutil.MustDo(t, "merge more changes from master to branch1", err)
 
 	// diff changes between second and first commit
@@ -344,10 +344,10 @@ func TestCataloger_Diff_FromChildThreeBranches(t *testing.T) {
 	testutil.MustDo(t, "second commit to branch2", err)
 
 	// merge the above up to master (from branch2)
-	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil, "")
 	testutil.MustDo(t, "Merge changes from branch2 to branch1", err)
 	// merge the changes from branch1 to master
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, "")
 	testutil.MustDo(t, "Merge changes from branch1 to master", err)
 
 	if !IsValidReference(res.Reference) {
@@ -153,7 +153,7 @@ func TestCataloger_GetMergeCommit(t *testing.T) {
 	testutil.MustDo(t, "commit to b1", err)
 
 	// merge b1 to master
-	res, err := c.Merge(ctx, repo, "b1", "master", "tester", "merge b1 to master", nil)
+	res, err := c.Merge(ctx, repo, "b1", "master", "tester", "merge b1 to master", nil, "")
 	testutil.MustDo(t, "merge b1 to master", err)
 
 	// test commit on master got two parents
@@ -79,3 +79,7 @@ func convertRawCommits(rawCommits []commitLogRaw) []*catalog.CommitLog {
 	}
 	return commits
 }
+
+func (c *cataloger) ListCommitsByPath(_ context.Context, _, _ string, _ string, _ string, _ int) ([]*catalog.CommitLog, bool, error) {
+	return nil, false, catalog.ErrFeatureNotSupported
+}
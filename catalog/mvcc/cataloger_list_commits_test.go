@@ -189,7 +189,7 @@ func TestCataloger_ListCommits(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Commit for list repository commits failed '%s': %s", "master commit failed", err)
 	}
-	_, err = c.Merge(ctx, repository, "master", "br_1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "master", "br_1", "tester", "", nil, "")
 	testutil.Must(t, err)
 	_, _, err = c.ListCommits(ctx, repository, "br_2", "", 100)
 	testutil.Must(t, err)
@@ -259,7 +259,7 @@ func TestCataloger_ListCommits_Lineage(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Commit for list repository commits failed '%s': %s", "master commit failed", err)
 	}
-	_, err = c.Merge(ctx, repository, "master", "br_1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "master", "br_1", "tester", "", nil, "")
 	testutil.MustDo(t, "merge master  into br_1", err)
 
 	got, _, err := c.ListCommits(ctx, repository, "br_2", "", 100)
@@ -316,7 +316,7 @@ func TestCataloger_ListCommits_LineageFromChild(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Commit for list repository commits failed '%s': %s", "master commit failed", err)
 	}
-	_, err = c.Merge(ctx, repository, "master", "br_1_1", "tester", "merge master to br_1_1", nil)
+	_, err = c.Merge(ctx, repository, "master", "br_1_1", "tester", "merge master to br_1_1", nil, "")
 	testutil.MustDo(t, "merge master  into br_1_1", err)
 
 	got, _, err := c.ListCommits(ctx, repository, "br_1_2", "", 100)
@@ -350,7 +350,7 @@ func TestCataloger_ListCommits_LineageFromChild(t *testing.T) {
 	br22List, _, err := c.ListCommits(ctx, repository, "br_2_2", "", 100)
 	testutil.MustDo(t, "list br_2_2  commits", err)
 	_ = br22List
-	_, err = c.Merge(ctx, repository, "br_2_2", "br_2_1", "tester", "merge br_2_2 to br_2_1", nil)
+	_, err = c.Merge(ctx, repository, "br_2_2", "br_2_1", "tester", "merge br_2_2 to br_2_1", nil, "")
 	testutil.MustDo(t, "merge br_2_2  into br_2_1", err)
 	br21List, _, err := c.ListCommits(ctx, repository, "br_2_1", "", 100)
 	testutil.MustDo(t, "list br_2_1  commits", err)
@@ -360,7 +360,7 @@ func TestCataloger_ListCommits_LineageFromChild(t *testing.T) {
 	if diff := deep.Equal(masterCommits, masterList); diff != nil {
 		t.Error("master commits changed before merge", diff)
 	}
-	merge2, err := c.Merge(ctx, repository, "br_2_1", "master", "tester", "merge br_2_1 to master", nil)
+	merge2, err := c.Merge(ctx, repository, "br_2_1", "master", "tester", "merge br_2_1 to master", nil, "")
 	testutil.MustDo(t, "merge br_2_1  into master", err)
 	commitLog, err := c.GetCommit(ctx, repository, merge2.Reference)
 	testutil.MustDo(t, "get merge commit reference", err)
@@ -391,7 +391,7 @@ func TestCataloger_ListCommits_LineageFromChild(t *testing.T) {
 	if diff := deep.Equal(br11BaseList, br11List); diff != nil {
 		t.Error("br_1_1 commits changed before merge", diff)
 	}
-	_, err = c.Merge(ctx, repository, "master", "br_1_1", "tester", "merge master to br_1_1", nil)
+	_, err = c.Merge(ctx, repository, "master", "br_1_1", "tester", "merge master to br_1_1", nil, "")
 	testutil.MustDo(t, "merge master  into br_1_1", err)
 	br11List, _, err = c.ListCommits(ctx, repository, "br_1_1", "", 100)
 	testutil.MustDo(t, "list br_1_1 commits", err)
@@ -414,7 +414,7 @@ func TestCataloger_ListCommits_LineageFromChild(t *testing.T) {
 	if err != nil {
 		t.Fatalf("no-propagate-Commit for list repository commits failed '%s': %s", "br_2_2  commit failed", err)
 	}
-	_, err = c.Merge(ctx, repository, "br_2_2", "br_2_1", "tester", "merge br_2_2 to br_2_1", nil)
+	_, err = c.Merge(ctx, repository, "br_2_2", "br_2_1", "tester", "merge br_2_2 to br_2_1", nil, "")
 	testutil.MustDo(t, "second merge br_2_2  into br_2_1", err)
 	newBr21List, _, err := c.ListCommits(ctx, repository, "br_2_1", "", 100)
 	testutil.MustDo(t, "second list br_2_1 commits", err)
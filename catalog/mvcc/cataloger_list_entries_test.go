@@ -802,7 +802,7 @@ func TestCataloger_ListEntries_IgnoreDeleteByLineage(t *testing.T) {
 		t.Fatalf("expected 100 entries on br_1, read %d", len(got))
 	}
 	// now merge master to br_1
-	_, err = c.Merge(ctx, repo, "master", "br_1", "tester", "merge deletions", nil)
+	_, err = c.Merge(ctx, repo, "master", "br_1", "tester", "merge deletions", nil, "")
 	testutil.Must(t, err)
 	got, _, err = c.ListEntries(ctx, repo, "br_1", "", "", catalog.DefaultPathDelimiter, -1)
 	testutil.Must(t, err)
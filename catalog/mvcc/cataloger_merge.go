@@ -21,7 +21,7 @@ type mergeBatchRecords []*catalog.DiffResultRecord
 // It uses the cataloger diff internal API to produce a temporary table that we delete at the end of a successful merge
 // the table holds entry ctid to reference entries in case of changed/added and source branch in case of delete.
 // That information is used to address cases where we need to create new entry or tombstone as part of the merge
-func (c *cataloger) Merge(ctx context.Context, repository, leftBranch, rightBranch, committer, message string, metadata catalog.Metadata) (*catalog.MergeResult, error) {
+func (c *cataloger) Merge(ctx context.Context, repository, leftBranch, rightBranch, committer, message string, metadata catalog.Metadata, strategy string) (*catalog.MergeResult, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
 		{Name: "leftBranch", IsValid: ValidateBranchName(leftBranch)},
@@ -30,6 +30,13 @@ func (c *cataloger) Merge(ctx context.Context, repository, leftBranch, rightBran
 	}); err != nil {
 		return nil, err
 	}
+	if strategy != "" && strategy != catalog.MergeStrategyFail {
+		// conflicts are resolved only by the graveler based cataloger
+		return nil, fmt.Errorf("%w: merge strategy %s", catalog.ErrFeatureNotSupported, strategy)
+	}
+	if err := c.runPreMergeHooks(ctx, repository, leftBranch, rightBranch, committer, message, metadata); err != nil {
+		return nil, err
+	}
 
 	mergeResult := &catalog.MergeResult{
 		Summary: make(map[catalog.DifferenceType]int),
@@ -104,6 +111,28 @@ func (c *cataloger) Merge(ctx context.Context, repository, leftBranch, rightBran
 	return mergeResult, err
 }
 
+// runPreMergeHooks passes the changes merging leftBranch brings into rightBranch to the PreMerge
+// hooks, it returns an error if any hook rejects the merge.  As in the merge, these are the
+// committed changes of leftBranch since it last merged with rightBranch.
+func (c *cataloger) runPreMergeHooks(ctx context.Context, repository, leftBranch, rightBranch, committer, message string, metadata catalog.Metadata) error {
+	if len(c.Hooks().PreMerge) == 0 {
+		return nil
+	}
+	leftReference := MakeReference(leftBranch, CommittedID)
+	differences, hasMore, err := c.Diff(ctx, repository, leftReference, rightBranch, catalog.DiffParams{Limit: catalog.HookDifferencesLimit})
+	if err != nil {
+		return fmt.Errorf("diff for pre-merge hooks: %w", err)
+	}
+	return c.Hooks().RunPreMerge(ctx, repository, rightBranch, catalog.PreMergeEvent{
+		SourceRef:            leftBranch,
+		Committer:            committer,
+		Message:              message,
+		Metadata:             metadata,
+		Differences:          differences,
+		DifferencesTruncated: hasMore,
+	})
+}
+
 func (c *cataloger) doMerge(ctx context.Context, tx db.Tx, params doDiffParams, mergeResult *catalog.MergeResult, previousMaxCommitID CommitID, nextCommitID CommitID, relation RelationType) (int, error) {
 	mergeCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
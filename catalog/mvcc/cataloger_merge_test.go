@@ -50,7 +50,7 @@ func TestCataloger_Merge_FromParentNoChangesInChild(t *testing.T) {
 	}
 
 	// merge master to branch1
-	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, "")
 	if err != nil {
 		t.Fatal("Merge from master to branch1 failed:", err)
 	}
@@ -77,7 +77,7 @@ func TestCataloger_Merge_FromParentNoChangesInChild(t *testing.T) {
 		t.Fatal("Merge Summary", diff)
 	}
 	// merge again - nothing should happen
-	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, "")
 	if err != catalog.ErrNoDifferenceWasFound {
 		t.Fatal("Merge() expected ErrNoDifferenceWasFound, got:", err)
 	}
@@ -132,7 +132,7 @@ func TestCataloger_Merge_FromParentConflicts(t *testing.T) {
 	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", overFilename, nil, "seed2")
 
 	// merge should identify conflicts on pending changes
-	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, "")
 
 	// expected to find 2 conflicts on the files we update/created with the same path
 	if !errors.Is(err, catalog.ErrConflictFound) {
@@ -152,7 +152,7 @@ func TestCataloger_Merge_FromParentNoChangesInParent(t *testing.T) {
 	c := testCataloger(t)
 	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
 	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
-	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, "")
 	expectedErr := catalog.ErrNoDifferenceWasFound
 	if !errors.Is(err, expectedErr) {
 		t.Errorf("Merge err = %s, expected %s", err, expectedErr)
@@ -213,7 +213,7 @@ func TestCataloger_Merge_FromParentChangesInBoth(t *testing.T) {
 	testutil.MustDo(t, "first commit on branch1", err)
 
 	// merge should work and grab all the changes from master
-	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, "")
 	if err != nil {
 		t.Fatal("Merge from master to branch1 failed:", err)
 	}
@@ -292,10 +292,10 @@ func TestCataloger_Merge_FromParentThreeBranches(t *testing.T) {
 	testutil.MustDo(t, "second commit to master", err)
 
 	// merge the above down (from master) to branch1
-	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, "")
 	testutil.MustDo(t, "Merge changes from master to branch1", err)
 	// merge the changes from branch1 to branch2
-	res, err := c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil, "")
 	testutil.MustDo(t, "Merge changes from master to branch1", err)
 
 	// verify valid commit id
@@ -350,7 +350,7 @@ func TestCataloger_Merge_FromChildNoChanges(t *testing.T) {
 	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
 
 	// merge empty branch into master
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, "")
 	expectedErr := catalog.ErrNoDifferenceWasFound
 	if !errors.Is(err, expectedErr) {
 		t.Fatalf("Merge from branch1 to master err=%s, expected=%s", err, expectedErr)
@@ -395,7 +395,7 @@ func TestCataloger_Merge_FromChildChangesOnChild(t *testing.T) {
 	testutil.MustDo(t, "First commit to branch1", err)
 
 	// merge empty branch into master
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, "")
 	if err != nil {
 		t.Fatalf("Merge from branch1 to master err=%s, expected none", err)
 	}
@@ -470,7 +470,7 @@ func TestCataloger_Merge_FromChildThreeBranches(t *testing.T) {
 	testutil.MustDo(t, "second commit to branch2", err)
 
 	// merge the above up to master (from branch2)
-	res, err := c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil, "")
 	testutil.MustDo(t, "Merge changes from branch2 to branch1", err)
 
 	if !IsValidReference(res.Reference) {
@@ -513,7 +513,7 @@ func TestCataloger_Merge_FromChildThreeBranches(t *testing.T) {
 	})
 
 	// merge the changes from branch1 to master
-	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, "")
 	testutil.MustDo(t, "Merge changes from branch1 to master", err)
 
 	// verify valid commit id
@@ -569,7 +569,7 @@ func TestCataloger_Merge_FromChildNewDelSameEntry(t *testing.T) {
 	testutil.MustDo(t, "add new file to branch", err)
 
 	// merge branch to master
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, "")
 	if err != nil {
 		t.Fatalf("Merge from branch1 to master err=%s, expected none", err)
 	}
@@ -604,7 +604,7 @@ func TestCataloger_Merge_FromChildNewDelSameEntry(t *testing.T) {
 	testutil.MustDo(t, "Commit with deleted file", err)
 
 	// merge branch to master
-	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, "")
 	if err != nil {
 		t.Fatalf("Merge from branch1 to master err=%s, expected none", err)
 	}
@@ -648,7 +648,7 @@ func TestCataloger_Merge_FromChildNewEntrySameEntry(t *testing.T) {
 	testutil.MustDo(t, "add new file to branch", err)
 
 	// merge branch to master
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, "")
 	if err != nil {
 		t.Fatalf("Merge from branch1 to master err=%s, expected none", err)
 	}
@@ -683,7 +683,7 @@ func TestCataloger_Merge_FromChildNewEntrySameEntry(t *testing.T) {
 	testutil.MustDo(t, "add same file to branch", err)
 
 	// merge branch to master
-	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, "")
 	if err != nil {
 		t.Fatalf("Merge from branch1 to master err=%s, expected none", err)
 	}
@@ -733,7 +733,7 @@ func TestCataloger_Merge_FromChildDelModifyGrandparentFiles(t *testing.T) {
 	testutil.MustDo(t, "Commit with deleted file", err)
 
 	// merge changes from branch2 to branch1
-	res, err := c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil, "")
 	if err != nil {
 		t.Fatalf("Merge from branch2 to branch1 err=%s, expected none", err)
 	}
@@ -790,7 +790,7 @@ func TestCataloger_Merge_FromChildConflicts(t *testing.T) {
 	testutil.MustDo(t, "modify /file0 on master", err)
 
 	// merge changes from branch to master should find the conflict
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, "")
 	if !errors.Is(err, catalog.ErrConflictFound) {
 		t.Fatalf("Merge from branch1 to master err=%s, expected conflict", err)
 	}
@@ -852,10 +852,10 @@ func TestCataloger_Merge_FromParentThreeBranchesExtended1(t *testing.T) {
 	testutil.MustDo(t, "second commit to master", err)
 
 	// merge the above down (from master) to branch1
-	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, "")
 	testutil.MustDo(t, "Merge changes from master to branch1", err)
 	// merge the changes from branch1 to branch2
-	res, err := c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil, "")
 	testutil.MustDo(t, "Merge changes from master to branch1", err)
 
 	// verify valid commit id
@@ -901,14 +901,14 @@ func TestCataloger_Merge_FromParentThreeBranchesExtended1(t *testing.T) {
 	testCatalogerGetEntry(t, ctx, c, repository, "branch1", "/file0", true)
 	testCatalogerGetEntry(t, ctx, c, repository, "master", "/file0", false)
 
-	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, "")
 	testutil.MustDo(t, "merge to master to branch1", err)
 
 	testCatalogerGetEntry(t, ctx, c, repository, "branch2", "/file0", true)
 	testCatalogerGetEntry(t, ctx, c, repository, "branch1", "/file0", false)
 	testCatalogerGetEntry(t, ctx, c, repository, "master", "/file0", false)
 
-	_, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil, "")
 	testutil.MustDo(t, "merge branch1 to branch2", err)
 
 	testCatalogerGetEntry(t, ctx, c, repository, "branch2", "/file0", false)
@@ -921,7 +921,7 @@ func TestCataloger_Merge_FromParentThreeBranchesExtended1(t *testing.T) {
 	_, _ = c.Commit(ctx, repository, "branch2", "commit file0 creation", "tester", nil)
 	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "seed1")
 	_, _ = c.Commit(ctx, repository, "master", "commit file0 creation", "tester", nil)
-	res, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	res, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, "")
 	testutil.MustDo(t, "merge master to branch1", err)
 	if res.Reference == "" {
 		t.Fatal("No merge reference")
@@ -945,7 +945,7 @@ func TestCataloger_Merge_FromParentThreeBranchesExtended1(t *testing.T) {
 	//if !differences.Equal(expectedDifferences) {
 	//	t.Errorf("Merge differences = %s, expected %s", spew.Sdump(differences), spew.Sdump(expectedDifferences))
 	//}
-	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil, "")
 	testutil.MustDo(t, "merge branch1 to branch2", err)
 	if res.Reference == "" {
 		t.Fatal("No merge results")
@@ -970,13 +970,13 @@ func TestCataloger_Merge_FromParentThreeBranchesExtended1(t *testing.T) {
 		c.DeleteEntry(ctx, repository, "master", "/file0"))
 	_, err = c.Commit(ctx, repository, "master", "commit file0 deletion", "tester", nil)
 	testutil.MustDo(t, "commit file0 delete", err)
-	res, err = c.Merge(ctx, repository, "master", "branch1", "tester", "bubling /file0 deletion up", nil)
+	res, err = c.Merge(ctx, repository, "master", "branch1", "tester", "bubling /file0 deletion up", nil, "")
 	testutil.MustDo(t, "merge master to branch1", err)
 	if res.Reference == "" {
 		t.Fatal("No merge reference")
 	}
 
-	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "forcing file0 on branch2 to delete", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "forcing file0 on branch2 to delete", nil, "")
 	testutil.MustDo(t, "merge master to branch1", err)
 	if res.Reference == "" {
 		t.Fatal("No merge reference")
@@ -998,10 +998,10 @@ func TestCataloger_Merge_FromParentThreeBranchesExtended1(t *testing.T) {
 	//}
 
 	//identical entries created in child and grandparent do not create conflict - even when grandparent is uncommitted
-	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "empty updates", nil)
+	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "empty updates", nil, "")
 	testutil.MustDo(t, "merge branch2 to branch1", err)
 
-	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "empty updates", nil)
+	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "empty updates", nil, "")
 	testutil.MustDo(t, "merge branch1 to master", err)
 
 	testCatalogerCreateEntry(t, ctx, c, repository, "branch2", "/file111", nil, "seed1")
@@ -1009,10 +1009,10 @@ func TestCataloger_Merge_FromParentThreeBranchesExtended1(t *testing.T) {
 	testutil.MustDo(t, "commit file0 creation to branch2", err)
 
 	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file111", nil, "seed2")
-	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "pushing /file111 down", nil)
+	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "pushing /file111 down", nil, "")
 	testutil.MustDo(t, "merge branch2 to branch1", err)
 
-	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "pushing /file111 down", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "pushing /file111 down", nil, "")
 	if !errors.Is(err, catalog.ErrConflictFound) {
 		t.Fatalf("Merge err=%s, expected conflict", err)
 	}
@@ -1027,7 +1027,7 @@ func TestCataloger_Merge_FromParentThreeBranchesExtended1(t *testing.T) {
 		c.DeleteEntry(ctx, repository, "master", "/file111"))
 
 	// push file111 delete
-	_, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "delete /file111 up", nil)
+	_, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "delete /file111 up", nil, "")
 	testutil.Must(t, err)
 
 	testutil.MustDo(t, "delete committed file on branch1",
@@ -1035,7 +1035,7 @@ func TestCataloger_Merge_FromParentThreeBranchesExtended1(t *testing.T) {
 	_, err = c.Commit(ctx, repository, "branch1", "commit file111 deletion", "tester", nil)
 	testutil.MustDo(t, "commit file111 to branch1", err)
 
-	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "delete /file111 up", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "delete /file111 up", nil, "")
 	testutil.MustDo(t, "merge branch1 to branch2", err)
 	if res.Reference == "" {
 		t.Fatal("No merge results")
@@ -1081,14 +1081,14 @@ func TestCataloger_MergeOverDeletedEntries(t *testing.T) {
 	if !errors.Is(err, catalog.ErrEntryNotFound) {
 		t.Fatal("expected entry not found, got", err)
 	}
-	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge changes from master to b1 part 2", nil)
+	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge changes from master to b1 part 2", nil, "")
 	testutil.MustDo(t, "merge master to b1 part 2", err)
 
 	// create and commit the same file, different content, on 'master', merge to 'b1' and check that we get the file on 'b1'
 	testCatalogerCreateEntry(t, ctx, c, repository, "master", "fileX", nil, "master2")
 	_, err = c.Commit(ctx, repository, "master", "fileX", "tester", nil)
 	testutil.MustDo(t, "commit on master", err)
-	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge changes from master to b1", nil)
+	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge changes from master to b1", nil, "")
 	testutil.MustDo(t, "merge master to b1", err)
 	ent, err := c.GetEntry(ctx, repository, "b1", "fileX", catalog.GetEntryParams{})
 	testutil.MustDo(t, "get entry again from b1", err)
@@ -1108,7 +1108,7 @@ func TestCataloger_MergeWithoutDiff(t *testing.T) {
 	testutil.MustDo(t, "commit file first time on master", err)
 	_, err = c.CreateBranch(ctx, repository, "b1", "master")
 	testutil.MustDo(t, "create branch b1", err)
-	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge nothing from master to b1", nil)
+	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge nothing from master to b1", nil, "")
 	if !errors.Is(err, catalog.ErrNoDifferenceWasFound) {
 		t.Fatalf("Merge expected err=%s, expected=%s", err, catalog.ErrNoDifferenceWasFound)
 	}
@@ -1119,11 +1119,11 @@ func TestCataloger_MergeWithoutDiff(t *testing.T) {
 	testutil.MustDo(t, "delete dummy_file on master", err)
 	_, err = c.Commit(ctx, repository, "master", "file_dummy delete", "tester", nil)
 	testutil.MustDo(t, "commit dummy file  deletion", err)
-	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge nothing from master to b1", nil)
+	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge nothing from master to b1", nil, "")
 	if err != nil {
 		t.Fatalf("error on merge with no changes:%+v", err)
 	}
-	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge nothing from master to b1", nil)
+	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge nothing from master to b1", nil, "")
 	if !errors.Is(err, catalog.ErrNoDifferenceWasFound) {
 		t.Fatalf("Merge expected err=%s, expected=%s", err, catalog.ErrNoDifferenceWasFound)
 	}
@@ -1141,7 +1141,7 @@ func TestCataloger_MergeFromChildAfterMergeFromParent(t *testing.T) {
 	testutil.MustDo(t, "commit file first time on master", err)
 	_, err = c.CreateBranch(ctx, repository, "b1", "master")
 	testutil.MustDo(t, "create branch b1", err)
-	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge nothing from master to b1", nil)
+	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge nothing from master to b1", nil, "")
 	if !errors.Is(err, catalog.ErrNoDifferenceWasFound) {
 		t.Fatalf("merge err=%s, expected ErrNoDifferenceWasFound", err)
 	}
@@ -1153,7 +1153,7 @@ func TestCataloger_MergeFromChildAfterMergeFromParent(t *testing.T) {
 	_, err = c.Commit(ctx, repository, "master", "fileY and fileZ", "tester", nil)
 	testutil.MustDo(t, "commit fileY  master", err)
 	// merge them into child
-	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge fileY from master to b1", nil)
+	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge fileY from master to b1", nil, "")
 	testutil.MustDo(t, "merge into branch b1", err)
 	// delete one of those files in b1
 	err = c.DeleteEntry(ctx, repository, "b1", "fileY")
@@ -1161,7 +1161,7 @@ func TestCataloger_MergeFromChildAfterMergeFromParent(t *testing.T) {
 	testCatalogerCreateEntry(t, ctx, c, repository, "b1", "fileZ", nil, "master1")
 	_, err = c.Commit(ctx, repository, "b1", "fileY and fileZ", "tester", nil)
 	testutil.MustDo(t, "commit fileY b1", err)
-	_, err = c.Merge(ctx, repository, "b1", "master", "tester", "merge nothing from master to b1", nil)
+	_, err = c.Merge(ctx, repository, "b1", "master", "tester", "merge nothing from master to b1", nil, "")
 	if err != nil {
 		t.Fatalf("Merge err=%s, expected none", err)
 	}
@@ -1173,7 +1173,7 @@ func TestCataloger_MergeFromChildAfterMergeFromParent(t *testing.T) {
 	_, err = c.Commit(ctx, repository, "master", "fileYY and fileZZ", "tester", nil)
 	testutil.MustDo(t, "commit fileYY  master", err)
 	// merge them into child
-	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge fileYY from master to b1", nil)
+	_, err = c.Merge(ctx, repository, "master", "b1", "tester", "merge fileYY from master to b1", nil, "")
 	testutil.MustDo(t, "merge into branch b1", err)
 	// delete one of those files in b1
 	err = c.DeleteEntry(ctx, repository, "b1", "fileYY")
@@ -1181,7 +1181,7 @@ func TestCataloger_MergeFromChildAfterMergeFromParent(t *testing.T) {
 	testCatalogerCreateEntry(t, ctx, c, repository, "b1", "fileZZ", nil, "master1")
 	_, err = c.Commit(ctx, repository, "b1", "fileYY and fileZZ", "tester", nil)
 	testutil.MustDo(t, "commit fileYY b1", err)
-	_, err = c.Merge(ctx, repository, "b1", "master", "tester", "merge nothing from master to b1", nil)
+	_, err = c.Merge(ctx, repository, "b1", "master", "tester", "merge nothing from master to b1", nil, "")
 	if err != nil {
 		t.Fatalf("Merge err=%s, expected none", err)
 	}
@@ -1238,7 +1238,7 @@ func TestCataloger_Merge_Hooks(t *testing.T) {
 			_, err := c.Commit(ctx, repository, "branch1", "commit to master", "tester", nil)
 			testutil.MustDo(t, "commit to branch1", err)
 
-			res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+			res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, "")
 
 			if !errors.Is(err, tt.err) {
 				t.Error("hook did not fail merge: ", err)
@@ -1307,7 +1307,7 @@ func TestCataloger_Merge_FromParentConflicts_WithSkip(t *testing.T) {
 	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", overFilename, nil, "seed2")
 
 	// merge should identify conflicts on pending changes
-	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, "")
 
 	// expected to find 2 conflicts on the files we update/created with the same path
 	if !errors.Is(err, catalog.ErrConflictFound) {
@@ -1317,3 +1317,38 @@ func TestCataloger_Merge_FromParentConflicts_WithSkip(t *testing.T) {
 		t.Errorf("Merge reference = %s, expected to be empty", res.Reference)
 	}
 }
+
+func TestCataloger_Merge_PreMergeHookDifferences(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	var events []catalog.PreMergeEvent
+	c.Hooks().AddPreMerge(func(_ context.Context, repo, branch string, event catalog.PreMergeEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+
+	// change the source
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/source_file", nil, "")
+	_, err := c.Commit(ctx, repository, "branch1", "commit to branch1", "tester", nil)
+	testutil.MustDo(t, "commit to branch1", err)
+	// uncommitted changes of the source are not merged
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/uncommitted_file", nil, "")
+
+	// diverge the destination
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/dest_file", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+
+	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, "")
+	testutil.MustDo(t, "merge branch1 into master", err)
+
+	if len(events) != 1 {
+		t.Fatalf("expected a single pre-merge event, got %d", len(events))
+	}
+	differences := events[0].Differences
+	if len(differences) != 1 || differences[0].Type != catalog.DifferenceTypeAdded || differences[0].Path != "/source_file" {
+		t.Errorf("pre-merge hook received unexpected differences %+v, expected only /source_file added", differences)
+	}
+}
@@ -3,6 +3,7 @@ package mvcc
 import (
 	"context"
 
+	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/db"
 )
 
@@ -13,6 +14,9 @@ func (c *cataloger) ResetEntries(ctx context.Context, repository, branch string,
 	}); err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionStagingWrite); err != nil {
+		return err
+	}
 	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
 		branchID, err := c.getBranchIDCache(tx, repository, branch)
 		if err != nil {
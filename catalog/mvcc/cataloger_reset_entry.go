@@ -14,6 +14,9 @@ func (c *cataloger) ResetEntry(ctx context.Context, repository, branch string, p
 	}); err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionStagingWrite); err != nil {
+		return err
+	}
 	if path == "" {
 		return db.ErrNotFound
 	}
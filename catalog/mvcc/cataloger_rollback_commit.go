@@ -15,6 +15,9 @@ func (c *cataloger) RollbackCommit(ctx context.Context, repository, branch, refe
 	}); err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionCommit); err != nil {
+		return err
+	}
 
 	ref, err := ParseRef(reference)
 	if err != nil {
@@ -75,7 +75,7 @@ func TestCataloger_RollbackCommit_BlockedByBranch(t *testing.T) {
 	testutil.MustDo(t, "commit file x", err)
 
 	// merge changes into the branch1
-	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "sync file x", nil)
+	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "sync file x", nil, "")
 	testutil.MustDo(t, "merge master to branch1", err)
 
 	// rollback to initial commit should fail
@@ -113,7 +113,7 @@ func TestCataloger_RollbackCommit_AfterMerge(t *testing.T) {
 	testutil.MustDo(t, "commit changes to branch1", err)
 
 	// merge changes from branch1 to master
-	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "sync branch1 to master", nil)
+	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "sync branch1 to master", nil, "")
 	testutil.MustDo(t, "merge branch1 to master", err)
 
 	// rollback to first commit
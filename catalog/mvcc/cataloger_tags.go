@@ -0,0 +1,23 @@
+package mvcc
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/catalog"
+)
+
+func (c *cataloger) CreateTag(_ context.Context, _ string, _ string, _ string) (string, error) {
+	return "", catalog.ErrFeatureNotSupported
+}
+
+func (c *cataloger) DeleteTag(_ context.Context, _ string, _ string) error {
+	return catalog.ErrFeatureNotSupported
+}
+
+func (c *cataloger) ListTags(_ context.Context, _ string, _ int, _ string) ([]*catalog.Tag, bool, error) {
+	return nil, false, catalog.ErrFeatureNotSupported
+}
+
+func (c *cataloger) GetTag(_ context.Context, _ string, _ string) (string, error) {
+	return "", catalog.ErrFeatureNotSupported
+}
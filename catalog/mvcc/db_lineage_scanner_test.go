@@ -105,7 +105,7 @@ func TestDBLineageScanner(t *testing.T) {
 		return nil, nil
 	})
 
-	_, err = c.Merge(ctx, repository, "b1", "b2", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "b1", "b2", "tester", "", nil, "")
 	testutil.MustDo(t, "merge b1 into b2", err)
 	_, _ = conn.Transact(func(tx db.Tx) (interface{}, error) {
 		lineageScannerB2U := NewDBLineageScanner(tx, b2BranchID, UncommittedID, scannerOpts)
@@ -143,7 +143,7 @@ func TestDBLineageScanner(t *testing.T) {
 		return nil, nil
 	})
 
-	_, err = c.Merge(ctx, repository, "b1", "b2", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "b1", "b2", "tester", "", nil, "")
 	testutil.MustDo(t, "merge b1 into b2", err)
 	_, _ = conn.Transact(func(tx db.Tx) (interface{}, error) {
 		lineageScannerB2U := NewDBLineageScanner(tx, b2BranchID, UncommittedID, scannerOpts)
@@ -156,7 +156,7 @@ func TestDBLineageScanner(t *testing.T) {
 	testCatalogerCreateEntry(t, ctx, c, repository, "b1", "Obj-0004", nil, "sd2")
 	_, err = c.Commit(ctx, repository, "b1", "commit to b1", "tester", nil)
 	testutil.MustDo(t, "commit to b1", err)
-	_, err = c.Merge(ctx, repository, "b1", "b2", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "b1", "b2", "tester", "", nil, "")
 	testutil.MustDo(t, "merge b1 into b2", err)
 	testutil.MustDo(t, "delete committed file on b2",
 		c.DeleteEntry(ctx, repository, "b2", "Obj-0004"))
@@ -181,17 +181,17 @@ func TestDBLineageScanner(t *testing.T) {
 	testCatalogerCreateEntry(t, ctx, c, repository, "b0", "Obj-00041", nil, "sd4")
 	_, err = c.Commit(ctx, repository, "b0", "commit to b0", "tester", nil)
 	testutil.MustDo(t, "commit to b0", err)
-	_, err = c.Merge(ctx, repository, "b0", "b1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "b0", "b1", "tester", "", nil, "")
 	testutil.MustDo(t, "merge b0 into b1", err)
-	_, err = c.Merge(ctx, repository, "b1", "b2", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "b1", "b2", "tester", "", nil, "")
 	testutil.MustDo(t, "merge b1 into b2", err)
 
 	testCatalogerCreateEntry(t, ctx, c, repository, "b0", "Obj-0004", nil, "sd3")
 	_, err = c.Commit(ctx, repository, "b0", "commit to b0", "tester", nil)
 	testutil.MustDo(t, "commit to b0", err)
-	_, err = c.Merge(ctx, repository, "b0", "b1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "b0", "b1", "tester", "", nil, "")
 	testutil.MustDo(t, "merge b0 into b1", err)
-	_, err = c.Merge(ctx, repository, "b1", "b2", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "b1", "b2", "tester", "", nil, "")
 	testutil.MustDo(t, "merge b1 into b2", err)
 	_, _ = conn.Transact(func(tx db.Tx) (interface{}, error) {
 		lineageScannerB2C := NewDBLineageScanner(tx, b2BranchID, CommittedID, scannerOpts)
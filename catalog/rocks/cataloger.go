@@ -2,6 +2,7 @@ package rocks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -9,19 +10,22 @@ import (
 	"github.com/treeverse/lakefs/config"
 	"github.com/treeverse/lakefs/db"
 	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/graveler/gc"
 	"github.com/treeverse/lakefs/logging"
 )
 
 type cataloger struct {
-	EntryCatalog *EntryCatalog
-	log          logging.Logger
-	dummyDedupCh chan *catalog.DedupReport
-	hooks        catalog.CatalogerHooks
+	EntryCatalog     *EntryCatalog
+	log              logging.Logger
+	dummyDedupCh     chan *catalog.DedupReport
+	hooks            catalog.CatalogerHooks
+	branchProtection *catalog.BranchProtectionManager
 }
 
 const (
 	ListRepositoriesLimitMax = 1000
 	ListBranchesLimitMax     = 1000
+	ListTagsLimitMax         = 1000
 	DiffLimitMax             = 1000
 	ListEntriesLimitMax      = 10000
 )
@@ -32,10 +36,11 @@ func NewCataloger(db db.Database, cfg *config.Config) (catalog.Cataloger, error)
 		return nil, err
 	}
 	return &cataloger{
-		EntryCatalog: entryCatalog,
-		log:          logging.Default(),
-		dummyDedupCh: make(chan *catalog.DedupReport),
-		hooks:        catalog.CatalogerHooks{},
+		EntryCatalog:     entryCatalog,
+		log:              logging.Default(),
+		dummyDedupCh:     make(chan *catalog.DedupReport),
+		hooks:            catalog.CatalogerHooks{},
+		branchProtection: catalog.NewBranchProtectionManager(db),
 	}, nil
 }
 
@@ -91,7 +96,10 @@ func (c *cataloger) DeleteRepository(ctx context.Context, repository string) err
 	if err != nil {
 		return err
 	}
-	return c.EntryCatalog.DeleteRepository(ctx, repositoryID)
+	if err := c.EntryCatalog.DeleteRepository(ctx, repositoryID); err != nil {
+		return err
+	}
+	return c.branchProtection.DeleteRepositoryRules(ctx, repository)
 }
 
 // ListRepositories list repositories information, the bool returned is true when more repositories can be listed.
@@ -183,6 +191,9 @@ func (c *cataloger) DeleteBranch(ctx context.Context, repository string, branch
 	if err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionDelete); err != nil {
+		return err
+	}
 	return c.EntryCatalog.DeleteBranch(ctx, repositoryID, branchID)
 }
 
@@ -272,9 +283,105 @@ func (c *cataloger) ResetBranch(ctx context.Context, repository string, branch s
 	if err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionStagingWrite); err != nil {
+		return err
+	}
 	return c.EntryCatalog.Reset(ctx, repositoryID, branchID)
 }
 
+func (c *cataloger) CreateTag(ctx context.Context, repository string, tagID string, ref string) (string, error) {
+	repositoryID, err := graveler.NewRepositoryID(repository)
+	if err != nil {
+		return "", err
+	}
+	tag, err := graveler.NewTagID(tagID)
+	if err != nil {
+		return "", err
+	}
+	r, err := graveler.NewRef(ref)
+	if err != nil {
+		return "", err
+	}
+	commitID, err := c.EntryCatalog.Dereference(ctx, repositoryID, r)
+	if err != nil {
+		return "", err
+	}
+	err = c.EntryCatalog.CreateTag(ctx, repositoryID, tag, commitID)
+	if err != nil {
+		return "", err
+	}
+	return commitID.String(), nil
+}
+
+func (c *cataloger) DeleteTag(ctx context.Context, repository string, tagID string) error {
+	repositoryID, err := graveler.NewRepositoryID(repository)
+	if err != nil {
+		return err
+	}
+	tag, err := graveler.NewTagID(tagID)
+	if err != nil {
+		return err
+	}
+	return c.EntryCatalog.DeleteTag(ctx, repositoryID, tag)
+}
+
+func (c *cataloger) ListTags(ctx context.Context, repository string, limit int, after string) ([]*catalog.Tag, bool, error) {
+	if limit < 0 || limit > ListTagsLimitMax {
+		limit = ListTagsLimitMax
+	}
+	repositoryID, err := graveler.NewRepositoryID(repository)
+	if err != nil {
+		return nil, false, err
+	}
+	afterTagID := graveler.TagID(after)
+	it, err := c.EntryCatalog.ListTags(ctx, repositoryID, afterTagID)
+	if err != nil {
+		return nil, false, err
+	}
+	defer it.Close()
+
+	var tags []*catalog.Tag
+	for it.Next() {
+		v := it.Value()
+		if v.TagID == afterTagID {
+			continue
+		}
+		tags = append(tags, &catalog.Tag{
+			ID:       v.TagID.String(),
+			CommitID: v.CommitID.String(),
+		})
+		if len(tags) >= limit+1 {
+			break
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, false, err
+	}
+	// return results (optional trimmed) and hasMore
+	hasMore := false
+	if len(tags) > limit {
+		hasMore = true
+		tags = tags[:limit]
+	}
+	return tags, hasMore, nil
+}
+
+func (c *cataloger) GetTag(ctx context.Context, repository string, tagID string) (string, error) {
+	repositoryID, err := graveler.NewRepositoryID(repository)
+	if err != nil {
+		return "", err
+	}
+	tag, err := graveler.NewTagID(tagID)
+	if err != nil {
+		return "", err
+	}
+	commitID, err := c.EntryCatalog.GetTag(ctx, repositoryID, tag)
+	if err != nil {
+		return "", err
+	}
+	return commitID.String(), nil
+}
+
 // GetEntry returns the current entry for path in repository branch reference.  Returns
 // the entry with ExpiredError if it has expired from underlying storage.
 func (c *cataloger) GetEntry(ctx context.Context, repository string, reference string, path string, _ catalog.GetEntryParams) (*catalog.Entry, error) {
@@ -298,7 +405,7 @@ func (c *cataloger) GetEntry(ctx context.Context, repository string, reference s
 	return &catalogEntry, nil
 }
 
-func (c *cataloger) CreateEntry(ctx context.Context, repository string, branch string, entry catalog.Entry, _ catalog.CreateEntryParams) error {
+func (c *cataloger) CreateEntry(ctx context.Context, repository string, branch string, entry catalog.Entry, params catalog.CreateEntryParams) error {
 	repositoryID, err := graveler.NewRepositoryID(repository)
 	if err != nil {
 		return err
@@ -307,6 +414,9 @@ func (c *cataloger) CreateEntry(ctx context.Context, repository string, branch s
 	if err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionStagingWrite); err != nil {
+		return err
+	}
 	p, err := NewPath(entry.Path)
 	if err != nil {
 		return err
@@ -317,9 +427,22 @@ func (c *cataloger) CreateEntry(ctx context.Context, repository string, branch s
 		ETag:     entry.Checksum,
 		Size:     entry.Size,
 	}
+	if params.Condition != nil {
+		return c.EntryCatalog.SetEntryIf(ctx, repositoryID, branchID, p, ent, func(current *Entry) error {
+			if current == nil {
+				return params.Condition(nil)
+			}
+			currentEntry := newCatalogEntryFromEntry(false, p.String(), current)
+			return params.Condition(&currentEntry)
+		})
+	}
 	return c.EntryCatalog.SetEntry(ctx, repositoryID, branchID, p, ent)
 }
 
+func (c *cataloger) SupportsConditionalWrites() bool {
+	return true
+}
+
 func (c *cataloger) CreateEntries(ctx context.Context, repository string, branch string, entries []catalog.Entry) error {
 	repositoryID, err := graveler.NewRepositoryID(repository)
 	if err != nil {
@@ -329,6 +452,9 @@ func (c *cataloger) CreateEntries(ctx context.Context, repository string, branch
 	if err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionStagingWrite); err != nil {
+		return err
+	}
 	for _, entry := range entries {
 		p, err := NewPath(entry.Path)
 		if err != nil {
@@ -356,6 +482,9 @@ func (c *cataloger) DeleteEntry(ctx context.Context, repository string, branch s
 	if err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionStagingWrite); err != nil {
+		return err
+	}
 	p, err := NewPath(path)
 	if err != nil {
 		return err
@@ -409,6 +538,9 @@ func (c *cataloger) ResetEntry(ctx context.Context, repository string, branch st
 	if err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionStagingWrite); err != nil {
+		return err
+	}
 	entryPath, err := NewPath(path)
 	if err != nil {
 		return err
@@ -425,6 +557,9 @@ func (c *cataloger) ResetEntries(ctx context.Context, repository string, branch
 	if err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionStagingWrite); err != nil {
+		return err
+	}
 	prefixPath, err := NewPath(prefix)
 	if err != nil {
 		return err
@@ -475,6 +610,12 @@ func (c *cataloger) Commit(ctx context.Context, repository string, branch string
 	if err != nil {
 		return nil, err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionCommit); err != nil {
+		return nil, err
+	}
+	if err := c.runPreCommitHooks(ctx, repository, branch, committer, message, metadata); err != nil {
+		return nil, err
+	}
 	commitID, err := c.EntryCatalog.Commit(ctx, repositoryID, branchID, committer, message, map[string]string(metadata))
 	if err != nil {
 		return nil, err
@@ -540,6 +681,31 @@ func (c *cataloger) ListCommits(ctx context.Context, repository string, branch s
 	if err != nil {
 		return nil, false, err
 	}
+	defer it.Close()
+	return c.listCommits(ctx, repositoryID, it, fromReference, limit)
+}
+
+// ListCommitsByPath lists the commits reachable from branch that changed an object whose path
+// starts with path
+func (c *cataloger) ListCommitsByPath(ctx context.Context, repository string, branch string, path string, fromReference string, limit int) ([]*catalog.CommitLog, bool, error) {
+	repositoryID, err := graveler.NewRepositoryID(repository)
+	if err != nil {
+		return nil, false, err
+	}
+	branchCommitID, err := c.EntryCatalog.Dereference(ctx, repositoryID, graveler.Ref(branch))
+	if err != nil {
+		return nil, false, fmt.Errorf("branch ref: %w", err)
+	}
+	it, err := c.EntryCatalog.LogByPrefix(ctx, repositoryID, branchCommitID, Path(path))
+	if err != nil {
+		return nil, false, err
+	}
+	defer it.Close()
+	return c.listCommits(ctx, repositoryID, it, fromReference, limit)
+}
+
+// listCommits returns up to limit commits of it following fromReference, and whether there are more
+func (c *cataloger) listCommits(ctx context.Context, repositoryID graveler.RepositoryID, it graveler.CommitIterator, fromReference string, limit int) ([]*catalog.CommitLog, bool, error) {
 	// skip until 'fromReference' if needed
 	if fromReference != "" {
 		fromCommitID, err := c.EntryCatalog.Dereference(ctx, repositoryID, graveler.Ref(fromReference))
@@ -596,14 +762,62 @@ func (c *cataloger) RollbackCommit(ctx context.Context, repository string, branc
 	if err != nil {
 		return err
 	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionCommit); err != nil {
+		return err
+	}
 	ref, err := graveler.NewRef(reference)
 	if err != nil {
 		return err
 	}
-	_, err = c.EntryCatalog.Revert(ctx, repositoryID, branchID, ref)
+	_, err = c.EntryCatalog.Revert(ctx, repositoryID, branchID, ref, catalog.DefaultCommitter)
 	return err
 }
 
+func (c *cataloger) CherryPick(ctx context.Context, repository string, reference string, branch string, committer string) (*catalog.CommitLog, error) {
+	repositoryID, err := graveler.NewRepositoryID(repository)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := graveler.NewRef(reference)
+	if err != nil {
+		return nil, err
+	}
+	branchID, err := graveler.NewBranchID(branch)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.CheckBranchProtection(ctx, repository, branch, catalog.BranchProtectionBlockedActionCommit); err != nil {
+		return nil, err
+	}
+	commitID, err := c.EntryCatalog.CherryPick(ctx, repositoryID, ref, branchID, committer)
+	var conflictErr *graveler.ConflictError
+	if errors.As(err, &conflictErr) {
+		paths := make([]string, len(conflictErr.Keys))
+		for i, key := range conflictErr.Keys {
+			paths[i] = key.String()
+		}
+		return nil, &catalog.ConflictError{Paths: paths}
+	}
+	if err != nil {
+		return nil, err
+	}
+	commit, err := c.EntryCatalog.GetCommit(ctx, repositoryID, commitID)
+	if err != nil {
+		return nil, err
+	}
+	catalogCommitLog := &catalog.CommitLog{
+		Reference:    commitID.String(),
+		Committer:    commit.Committer,
+		Message:      commit.Message,
+		CreationDate: commit.CreationDate,
+		Metadata:     catalog.Metadata(commit.Metadata),
+	}
+	for _, parent := range commit.Parents {
+		catalogCommitLog.Parents = append(catalogCommitLog.Parents, parent.String())
+	}
+	return catalogCommitLog, nil
+}
+
 func (c *cataloger) Diff(ctx context.Context, repository string, leftReference string, rightReference string, params catalog.DiffParams) (catalog.Differences, bool, error) {
 	it, err := c.EntryCatalog.Diff(ctx, graveler.RepositoryID(repository), graveler.Ref(leftReference), graveler.Ref(rightReference))
 	if err != nil {
@@ -651,12 +865,25 @@ func listDiffHelper(it EntryDiffIterator, limit int, after string) (catalog.Diff
 	return diffs, hasMore, nil
 }
 
-func (c *cataloger) Merge(ctx context.Context, repository string, leftBranch string, rightBranch string, committer string, message string, metadata catalog.Metadata) (*catalog.MergeResult, error) {
+func (c *cataloger) Merge(ctx context.Context, repository string, leftBranch string, rightBranch string, committer string, message string, metadata catalog.Metadata, strategy string) (*catalog.MergeResult, error) {
 	repositoryID := graveler.RepositoryID(repository)
 	leftRef := graveler.Ref(leftBranch)
 	rightBranchID := graveler.BranchID(rightBranch)
 	meta := graveler.Metadata(metadata)
-	commitID, err := c.EntryCatalog.Merge(ctx, repositoryID, leftRef, rightBranchID, committer, message, meta)
+	mergeStrategy, err := mergeStrategyFromString(strategy)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.runPreMergeHooks(ctx, repository, leftBranch, rightBranch, committer, message, metadata); err != nil {
+		return nil, err
+	}
+	commitID, err := c.EntryCatalog.Merge(ctx, repositoryID, leftRef, rightBranchID, committer, message, meta, mergeStrategy)
+	var conflictErr *graveler.ConflictError
+	if errors.As(err, &conflictErr) {
+		return &catalog.MergeResult{
+			Summary: map[catalog.DifferenceType]int{catalog.DifferenceTypeConflict: len(conflictErr.Keys)},
+		}, catalog.ErrConflictFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -667,10 +894,97 @@ func (c *cataloger) Merge(ctx context.Context, repository string, leftBranch str
 	}, nil
 }
 
+// runPreCommitHooks passes the uncommitted changes on branch to the PreCommit hooks, it returns
+// an error if any hook rejects the commit
+func (c *cataloger) runPreCommitHooks(ctx context.Context, repository, branch string, committer string, message string, metadata catalog.Metadata) error {
+	if len(c.hooks.PreCommit) == 0 {
+		return nil
+	}
+	differences, hasMore, err := c.DiffUncommitted(ctx, repository, branch, catalog.HookDifferencesLimit, "")
+	if err != nil {
+		return fmt.Errorf("diff uncommitted for pre-commit hooks: %w", err)
+	}
+	return c.hooks.RunPreCommit(ctx, repository, branch, catalog.PreCommitEvent{
+		Committer:            committer,
+		Message:              message,
+		Metadata:             metadata,
+		Differences:          differences,
+		DifferencesTruncated: hasMore,
+	})
+}
+
+// runPreMergeHooks passes the changes merging leftBranch brings into rightBranch, from their
+// merge base to leftBranch, to the PreMerge hooks, it returns an error if any hook rejects the merge
+func (c *cataloger) runPreMergeHooks(ctx context.Context, repository, leftBranch, rightBranch, committer, message string, metadata catalog.Metadata) error {
+	if len(c.hooks.PreMerge) == 0 {
+		return nil
+	}
+	it, err := c.EntryCatalog.Compare(ctx, graveler.RepositoryID(repository), graveler.Ref(leftBranch), graveler.Ref(rightBranch))
+	if err != nil {
+		return fmt.Errorf("diff for pre-merge hooks: %w", err)
+	}
+	differences, hasMore, err := listDiffHelper(it, catalog.HookDifferencesLimit, "")
+	if err != nil {
+		return fmt.Errorf("diff for pre-merge hooks: %w", err)
+	}
+	return c.hooks.RunPreMerge(ctx, repository, rightBranch, catalog.PreMergeEvent{
+		SourceRef:            leftBranch,
+		Committer:            committer,
+		Message:              message,
+		Metadata:             metadata,
+		Differences:          differences,
+		DifferencesTruncated: hasMore,
+	})
+}
+
+func mergeStrategyFromString(strategy string) (graveler.MergeStrategy, error) {
+	switch strategy {
+	case "", catalog.MergeStrategyFail:
+		return graveler.MergeStrategyFail, nil
+	case catalog.MergeStrategySourceWins:
+		return graveler.MergeStrategySourceWins, nil
+	case catalog.MergeStrategyDestWins:
+		return graveler.MergeStrategyDestWins, nil
+	default:
+		return graveler.MergeStrategyFail, catalog.ErrInvalidMergeStrategy
+	}
+}
+
 func (c *cataloger) Hooks() *catalog.CatalogerHooks {
 	return &c.hooks
 }
 
+func (c *cataloger) GetBranchProtectionRules(ctx context.Context, repository string) ([]*catalog.BranchProtectionRule, error) {
+	repositoryID, err := graveler.NewRepositoryID(repository)
+	if err != nil {
+		return nil, err
+	}
+	return c.branchProtection.GetRules(ctx, repositoryID.String())
+}
+
+func (c *cataloger) SetBranchProtectionRule(ctx context.Context, repository string, rule *catalog.BranchProtectionRule) error {
+	repositoryID, err := graveler.NewRepositoryID(repository)
+	if err != nil {
+		return err
+	}
+	if _, err := c.EntryCatalog.GetRepository(ctx, repositoryID); err != nil {
+		return err
+	}
+	return c.branchProtection.SetRule(ctx, repositoryID.String(), rule)
+}
+
+func (c *cataloger) DeleteBranchProtectionRule(ctx context.Context, repository string, pattern string) error {
+	repositoryID, err := graveler.NewRepositoryID(repository)
+	if err != nil {
+		return err
+	}
+	return c.branchProtection.DeleteRule(ctx, repositoryID.String(), pattern)
+}
+
+func (c *cataloger) CheckBranchProtection(ctx context.Context, repository string, branch string, action catalog.BranchProtectionBlockedAction) error {
+	return c.branchProtection.Check(ctx, repository, branch, action)
+}
+
 func (c *cataloger) GetExportConfigurationForBranch(repository string, branch string) (catalog.ExportConfiguration, error) {
 	panic("not implemented") // TODO: Implement
 }
@@ -692,6 +1006,11 @@ func (c *cataloger) GetExportState(repo string, branch string) (catalog.ExportSt
 	panic("not implemented") // TODO: Implement
 }
 
+// CollectRanges removes ranges and metaranges that no commit of the repository references
+func (c *cataloger) CollectRanges(ctx context.Context, repositoryID graveler.RepositoryID, params gc.Params) (*gc.Report, error) {
+	return c.EntryCatalog.CollectRanges(ctx, repositoryID, params)
+}
+
 func (c *cataloger) Close() error {
 	close(c.dummyDedupCh)
 	return nil
@@ -9,7 +9,9 @@ import (
 
 	"github.com/go-test/deep"
 	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/db"
 	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/graveler/testutil"
 )
 
 func Test_cataloger_ListRepositories(t *testing.T) {
@@ -206,6 +208,89 @@ func TestCataloger_ListBranches(t *testing.T) {
 	}
 }
 
+func TestCataloger_ListTags(t *testing.T) {
+	gravelerData := []*graveler.TagRecord{
+		{TagID: "t1", CommitID: "c1"},
+		{TagID: "t2", CommitID: "c2"},
+		{TagID: "t3", CommitID: "c3"},
+	}
+	tests := []struct {
+		name        string
+		limit       int
+		after       string
+		want        []*catalog.Tag
+		wantHasMore bool
+	}{
+		{
+			name:  "all",
+			limit: -1,
+			want: []*catalog.Tag{
+				{ID: "t1", CommitID: "c1"},
+				{ID: "t2", CommitID: "c2"},
+				{ID: "t3", CommitID: "c3"},
+			},
+		},
+		{
+			name:        "first",
+			limit:       1,
+			want:        []*catalog.Tag{{ID: "t1", CommitID: "c1"}},
+			wantHasMore: true,
+		},
+		{
+			name:        "second",
+			limit:       1,
+			after:       "t1",
+			want:        []*catalog.Tag{{ID: "t2", CommitID: "c2"}},
+			wantHasMore: true,
+		},
+		{
+			name:  "last2",
+			limit: 10,
+			after: "t1",
+			want: []*catalog.Tag{
+				{ID: "t2", CommitID: "c2"},
+				{ID: "t3", CommitID: "c3"},
+			},
+		},
+		{
+			name:  "exact",
+			limit: 3,
+			want: []*catalog.Tag{
+				{ID: "t1", CommitID: "c1"},
+				{ID: "t2", CommitID: "c2"},
+				{ID: "t3", CommitID: "c3"},
+			},
+		},
+		{
+			name:  "not found",
+			limit: 10,
+			after: "zzz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gravelerMock := &FakeGraveler{
+				TagIterator: NewFakeTagIterator(gravelerData),
+			}
+			c := &cataloger{
+				EntryCatalog: &EntryCatalog{
+					store: gravelerMock,
+				},
+			}
+			got, hasMore, err := c.ListTags(context.Background(), "repo", tt.limit, tt.after)
+			if err != nil {
+				t.Fatalf("ListTags() error = %v", err)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error("ListTags() found diff", diff)
+			}
+			if hasMore != tt.wantHasMore {
+				t.Errorf("ListTags() hasMore = %t, want %t", hasMore, tt.wantHasMore)
+			}
+		})
+	}
+}
+
 func TestCataloger_ListEntries(t *testing.T) {
 	// prepare branch data
 	now := time.Now()
@@ -329,3 +414,98 @@ func TestCataloger_ListEntries(t *testing.T) {
 		})
 	}
 }
+
+func TestCataloger_PreMergeHookDifferences(t *testing.T) {
+	now := time.Now()
+	sourceEntry := MustEntryToValue(&Entry{Address: "source", LastModified: timestamppb.New(now), Size: 1, ETag: "01"})
+	destEntry := MustEntryToValue(&Entry{Address: "dest", LastModified: timestamppb.New(now), Size: 2, ETag: "02"})
+	gravelerMock := &FakeGraveler{
+		// the destination diverged from the source: a two-way diff also holds its changes
+		DiffIterator: testutil.NewDiffIter([]graveler.Diff{
+			{Type: graveler.DiffTypeRemoved, Key: graveler.Key("dest_file"), Value: destEntry},
+			{Type: graveler.DiffTypeAdded, Key: graveler.Key("source_file"), Value: sourceEntry},
+		}),
+		// changes of the source since the merge base
+		CompareIterator: testutil.NewDiffIter([]graveler.Diff{
+			{Type: graveler.DiffTypeAdded, Key: graveler.Key("source_file"), Value: sourceEntry},
+		}),
+	}
+	c := &cataloger{
+		EntryCatalog: &EntryCatalog{
+			store: gravelerMock,
+		},
+	}
+	var events []catalog.PreMergeEvent
+	c.hooks.AddPreMerge(func(_ context.Context, repo, branch string, event catalog.PreMergeEvent) error {
+		events = append(events, event)
+		return nil
+	})
+
+	err := c.runPreMergeHooks(context.Background(), "repo", "feature", "master", "tester", "merge", nil)
+	if err != nil {
+		t.Fatalf("runPreMergeHooks() failed: %s", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected a single pre-merge event, got %d", len(events))
+	}
+	differences := events[0].Differences
+	if len(differences) != 1 || differences[0].Type != catalog.DifferenceTypeAdded || differences[0].Path != "source_file" {
+		t.Errorf("pre-merge hook received unexpected differences %+v, expected only source_file added", differences)
+	}
+}
+
+// noRulesDB is a database holding no branch protection rules
+type noRulesDB struct {
+	db.Database
+}
+
+func (d noRulesDB) WithContext(context.Context) db.Database {
+	return d
+}
+
+func (d noRulesDB) Select(interface{}, string, ...interface{}) error {
+	return nil
+}
+
+func TestCataloger_CherryPick(t *testing.T) {
+	const (
+		pickedCommitID = graveler.CommitID("picked")
+		parentCommitID = graveler.CommitID("parent")
+		headCommitID   = graveler.CommitID("head")
+		newCommitID    = graveler.CommitID("new")
+	)
+	refManager := &testutil.RefsFake{
+		RefType:     graveler.ReferenceTypeCommit,
+		RefCommitID: pickedCommitID,
+		CommitID:    newCommitID,
+		Branch:      &graveler.Branch{CommitID: headCommitID},
+		Commits: map[graveler.CommitID]*graveler.Commit{
+			pickedCommitID: {Message: "fix", MetaRangeID: "picked", Parents: graveler.CommitParents{parentCommitID}},
+			parentCommitID: {MetaRangeID: "parent"},
+			headCommitID:   {MetaRangeID: "head"},
+			newCommitID:    {Message: "fix", Committer: "tester"},
+		},
+	}
+	committedManager := &testutil.CommittedFake{
+		MetaRangeID:  "new",
+		DiffIterator: testutil.NewDiffIter(nil),
+	}
+	stagingManager := &testutil.StagingFake{ValueIterator: testutil.NewValueIteratorFake(nil)}
+	c := &cataloger{
+		EntryCatalog: &EntryCatalog{
+			store: graveler.NewGraveler(committedManager, stagingManager, refManager),
+		},
+		branchProtection: catalog.NewBranchProtectionManager(noRulesDB{}),
+	}
+
+	commitLog, err := c.CherryPick(context.Background(), "repo", pickedCommitID.String(), "master", "tester")
+	if err != nil {
+		t.Fatalf("CherryPick() failed: %s", err)
+	}
+	if commitLog.Reference != newCommitID.String() {
+		t.Errorf("CherryPick() reference = %s, expected %s", commitLog.Reference, newCommitID)
+	}
+	if refManager.Branch.CommitID != newCommitID {
+		t.Errorf("branch points at commit %s after cherry-pick, expected %s", refManager.Branch.CommitID, newCommitID)
+	}
+}
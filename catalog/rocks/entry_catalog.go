@@ -2,18 +2,28 @@ package rocks
 
 import (
 	"context"
+	"crypto"
+	_ "crypto/sha256"
 	"fmt"
 
+	pebblesst "github.com/cockroachdb/pebble/sstable"
+	lru "github.com/treeverse/lakefs/cache"
 	"github.com/treeverse/lakefs/config"
 	"github.com/treeverse/lakefs/db"
 	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/graveler/committed"
+	"github.com/treeverse/lakefs/graveler/gc"
 	"github.com/treeverse/lakefs/graveler/ref"
+	"github.com/treeverse/lakefs/graveler/sstable"
 	"github.com/treeverse/lakefs/graveler/staging"
+	"github.com/treeverse/lakefs/logging"
 	"github.com/treeverse/lakefs/pyramid"
 )
 
 type Path string
 
+const sstableCacheShards = 16
+
 type EntryRecord struct {
 	Path Path
 	*Entry
@@ -64,7 +74,8 @@ func (id Path) String() string {
 }
 
 type EntryCatalog struct {
-	store graveler.Graveler
+	store          graveler.Graveler
+	rangeCollector gc.Collector
 }
 
 func NewEntryCatalog(cfg *config.Config, db db.Database) (*EntryCatalog, error) {
@@ -76,18 +87,29 @@ func NewEntryCatalog(cfg *config.Config, db db.Database) (*EntryCatalog, error)
 	if err != nil {
 		return nil, fmt.Errorf("create tiered FS for committed: %w", err)
 	}
-	_ = fs // silence error
-	// TODO(ariels): Create a CommittedManager on top of fs.
-	var committedManager graveler.CommittedManager
+	sstableCache := sstable.NewCache(lru.ParamsWithDisposal{
+		Name:   "sstable",
+		Logger: logging.Default().WithField("module", "sstable"),
+		Size:   cfg.GetCommittedSSTableCacheSize(),
+		Shards: sstableCacheShards,
+	}, fs, pebblesst.ReaderOptions{})
+	sstableManager := sstable.NewPebbleSSTableManager(sstableCache, fs, crypto.SHA256)
+	metaRangeManager := committed.NewMetaRangeManager(sstableManager, sstableManager, cfg.GetCommittedApproximateRangeSizeBytes())
+	committedManager := committed.NewCommittedManager(metaRangeManager)
 
 	stagingManager := staging.NewManager(db)
 	refManager := ref.NewPGRefManager(db)
 
 	return &EntryCatalog{
-		store: graveler.NewGraveler(committedManager, stagingManager, refManager),
+		store:          graveler.NewGraveler(committedManager, stagingManager, refManager),
+		rangeCollector: gc.NewRangeCollector(refManager, metaRangeManager, fs),
 	}, nil
 }
 
+func (e *EntryCatalog) CollectRanges(ctx context.Context, repositoryID graveler.RepositoryID, params gc.Params) (*gc.Report, error) {
+	return e.rangeCollector.CollectRanges(ctx, repositoryID, params)
+}
+
 func (e *EntryCatalog) CommitExistingMetaRange(ctx context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID, metaRangeID graveler.MetaRangeID, committer string, message string, metadata graveler.Metadata) (graveler.CommitID, error) {
 	return e.store.CommitExistingMetaRange(ctx, repositoryID, branchID, metaRangeID, committer, message, metadata)
 }
@@ -147,6 +169,10 @@ func (e *EntryCatalog) Log(ctx context.Context, repositoryID graveler.Repository
 	return e.store.Log(ctx, repositoryID, commitID)
 }
 
+func (e *EntryCatalog) LogByPrefix(ctx context.Context, repositoryID graveler.RepositoryID, commitID graveler.CommitID, prefix Path) (graveler.CommitIterator, error) {
+	return e.store.LogByPrefix(ctx, repositoryID, commitID, graveler.Key(prefix))
+}
+
 func (e *EntryCatalog) ListBranches(ctx context.Context, repositoryID graveler.RepositoryID) (graveler.BranchIterator, error) {
 	return e.store.ListBranches(ctx, repositoryID)
 }
@@ -185,12 +211,16 @@ func (e *EntryCatalog) ResetPrefix(ctx context.Context, repositoryID graveler.Re
 	return e.store.ResetPrefix(ctx, repositoryID, branchID, keyPrefix)
 }
 
-func (e *EntryCatalog) Revert(ctx context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID, ref graveler.Ref) (graveler.CommitID, error) {
-	return e.store.Revert(ctx, repositoryID, branchID, ref)
+func (e *EntryCatalog) CherryPick(ctx context.Context, repositoryID graveler.RepositoryID, ref graveler.Ref, branchID graveler.BranchID, committer string) (graveler.CommitID, error) {
+	return e.store.CherryPick(ctx, repositoryID, ref, branchID, committer)
 }
 
-func (e *EntryCatalog) Merge(ctx context.Context, repositoryID graveler.RepositoryID, from graveler.Ref, to graveler.BranchID, committer string, message string, metadata graveler.Metadata) (graveler.CommitID, error) {
-	return e.store.Merge(ctx, repositoryID, from, to, committer, message, metadata)
+func (e *EntryCatalog) Revert(ctx context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID, ref graveler.Ref, committer string) (graveler.CommitID, error) {
+	return e.store.Revert(ctx, repositoryID, branchID, ref, committer)
+}
+
+func (e *EntryCatalog) Merge(ctx context.Context, repositoryID graveler.RepositoryID, from graveler.Ref, to graveler.BranchID, committer string, message string, metadata graveler.Metadata, strategy graveler.MergeStrategy) (graveler.CommitID, error) {
+	return e.store.Merge(ctx, repositoryID, from, to, committer, message, metadata, strategy)
 }
 
 func (e *EntryCatalog) DiffUncommitted(ctx context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID) (EntryDiffIterator, error) {
@@ -209,6 +239,14 @@ func (e *EntryCatalog) Diff(ctx context.Context, repositoryID graveler.Repositor
 	return NewEntryDiffIterator(iter), nil
 }
 
+func (e *EntryCatalog) Compare(ctx context.Context, repositoryID graveler.RepositoryID, from, to graveler.Ref) (EntryDiffIterator, error) {
+	iter, err := e.store.Compare(ctx, repositoryID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return NewEntryDiffIterator(iter), nil
+}
+
 func (e *EntryCatalog) GetEntry(ctx context.Context, repositoryID graveler.RepositoryID, ref graveler.Ref, path Path) (*Entry, error) {
 	val, err := e.store.Get(ctx, repositoryID, ref, graveler.Key(path))
 	if err != nil {
@@ -226,6 +264,26 @@ func (e *EntryCatalog) SetEntry(ctx context.Context, repositoryID graveler.Repos
 	return e.store.Set(ctx, repositoryID, branchID, key, *value)
 }
 
+// SetEntryIf sets entry on path of branch if condition accepts the current entry of path, nil
+// if it has none.
+func (e *EntryCatalog) SetEntryIf(ctx context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID, path Path, entry *Entry, condition func(current *Entry) error) error {
+	key := graveler.Key(path)
+	value, err := EntryToValue(entry)
+	if err != nil {
+		return err
+	}
+	return e.store.SetIf(ctx, repositoryID, branchID, key, *value, func(current *graveler.Value) error {
+		if current == nil {
+			return condition(nil)
+		}
+		currentEntry, err := ValueToEntry(current)
+		if err != nil {
+			return err
+		}
+		return condition(currentEntry)
+	})
+}
+
 func (e *EntryCatalog) DeleteEntry(ctx context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID, path Path) error {
 	key := graveler.Key(path)
 	return e.store.Delete(ctx, repositoryID, branchID, key)
@@ -13,8 +13,10 @@ type FakeGraveler struct {
 	Err                error
 	ListIterator       graveler.ValueIterator
 	DiffIterator       graveler.DiffIterator
+	CompareIterator    graveler.DiffIterator
 	RepositoryIterator graveler.RepositoryIterator
 	BranchIterator     graveler.BranchIterator
+	TagIterator        graveler.TagIterator
 }
 
 func fakeGravelerBuildKey(repositoryID graveler.RepositoryID, ref graveler.Ref, key graveler.Key) string {
@@ -42,6 +44,18 @@ func (g *FakeGraveler) Set(_ context.Context, repositoryID graveler.RepositoryID
 	return nil
 }
 
+func (g *FakeGraveler) SetIf(_ context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID, key graveler.Key, value graveler.Value, condition graveler.ValueCondition) error {
+	if g.Err != nil {
+		return g.Err
+	}
+	k := fakeGravelerBuildKey(repositoryID, graveler.Ref(branchID.String()), key)
+	if err := condition(g.KeyValue[k]); err != nil {
+		return err
+	}
+	g.KeyValue[k] = &value
+	return nil
+}
+
 func (g *FakeGraveler) Delete(ctx context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID, key graveler.Key) error {
 	panic("implement me")
 }
@@ -96,14 +110,21 @@ func (g *FakeGraveler) DeleteTag(ctx context.Context, repositoryID graveler.Repo
 	panic("implement me")
 }
 
-func (g *FakeGraveler) ListTags(ctx context.Context, repositoryID graveler.RepositoryID) (graveler.TagIterator, error) {
-	panic("implement me")
+func (g *FakeGraveler) ListTags(_ context.Context, _ graveler.RepositoryID) (graveler.TagIterator, error) {
+	if g.Err != nil {
+		return nil, g.Err
+	}
+	return g.TagIterator, nil
 }
 
 func (g *FakeGraveler) Log(ctx context.Context, repositoryID graveler.RepositoryID, commitID graveler.CommitID) (graveler.CommitIterator, error) {
 	panic("implement me")
 }
 
+func (g *FakeGraveler) LogByPrefix(ctx context.Context, repositoryID graveler.RepositoryID, commitID graveler.CommitID, prefix graveler.Key) (graveler.CommitIterator, error) {
+	panic("implement me")
+}
+
 func (g *FakeGraveler) ListBranches(_ context.Context, _ graveler.RepositoryID) (graveler.BranchIterator, error) {
 	if g.Err != nil {
 		return nil, g.Err
@@ -139,11 +160,15 @@ func (g *FakeGraveler) ResetPrefix(ctx context.Context, repositoryID graveler.Re
 	panic("implement me")
 }
 
-func (g *FakeGraveler) Revert(ctx context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID, ref graveler.Ref) (graveler.CommitID, error) {
+func (g *FakeGraveler) CherryPick(ctx context.Context, repositoryID graveler.RepositoryID, ref graveler.Ref, branchID graveler.BranchID, committer string) (graveler.CommitID, error) {
+	panic("implement me")
+}
+
+func (g *FakeGraveler) Revert(ctx context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID, ref graveler.Ref, committer string) (graveler.CommitID, error) {
 	panic("implement me")
 }
 
-func (g *FakeGraveler) Merge(ctx context.Context, repositoryID graveler.RepositoryID, from graveler.Ref, to graveler.BranchID, committer string, message string, metadata graveler.Metadata) (graveler.CommitID, error) {
+func (g *FakeGraveler) Merge(ctx context.Context, repositoryID graveler.RepositoryID, from graveler.Ref, to graveler.BranchID, committer string, message string, metadata graveler.Metadata, strategy graveler.MergeStrategy) (graveler.CommitID, error) {
 	panic("implement me")
 }
 
@@ -161,6 +186,13 @@ func (g *FakeGraveler) Diff(_ context.Context, _ graveler.RepositoryID, _, _ gra
 	return g.DiffIterator, nil
 }
 
+func (g *FakeGraveler) Compare(_ context.Context, _ graveler.RepositoryID, _, _ graveler.Ref) (graveler.DiffIterator, error) {
+	if g.Err != nil {
+		return nil, g.Err
+	}
+	return g.CompareIterator, nil
+}
+
 func (g *FakeGraveler) CommitExistingMetaRange(_ context.Context, _ graveler.RepositoryID, _ graveler.BranchID, _ graveler.MetaRangeID, _ string, _ string, _ graveler.Metadata) (graveler.CommitID, error) {
 	panic("implement me")
 }
@@ -311,3 +343,40 @@ func (m *FakeBranchIterator) Err() error {
 }
 
 func (m *FakeBranchIterator) Close() {}
+
+type FakeTagIterator struct {
+	Data  []*graveler.TagRecord
+	Index int
+}
+
+func NewFakeTagIterator(data []*graveler.TagRecord) *FakeTagIterator {
+	return &FakeTagIterator{Data: data, Index: -1}
+}
+
+func (m *FakeTagIterator) Next() bool {
+	if m.Index >= len(m.Data) {
+		return false
+	}
+	m.Index++
+	return m.Index < len(m.Data)
+}
+
+func (m *FakeTagIterator) SeekGE(id graveler.TagID) {
+	m.Index = len(m.Data)
+	for i, tag := range m.Data {
+		if tag.TagID >= id {
+			m.Index = i - 1
+			return
+		}
+	}
+}
+
+func (m *FakeTagIterator) Value() *graveler.TagRecord {
+	return m.Data[m.Index]
+}
+
+func (m *FakeTagIterator) Err() error {
+	return nil
+}
+
+func (m *FakeTagIterator) Close() {}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/api/gen/models"
+	"github.com/treeverse/lakefs/cmdutils"
+	"github.com/treeverse/lakefs/uri"
+)
+
+// actionsCmd represents the actions command
+var actionsCmd = &cobra.Command{
+	Use:   "actions",
+	Short: "manage runs of actions",
+	Long: `Actions are YAML files stored under _lakefs_actions/ in a branch.  Before a commit to the branch,
+or a merge from it, the hooks of all matching actions run and the operation is rejected if any of
+them fails.`,
+}
+
+var actionsRunsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "explore runs of actions",
+}
+
+var actionsRunsListTemplate = `{{.RunsTable | table -}}
+{{.Pagination | paginate }}
+`
+
+var actionsRunsListCmd = &cobra.Command{
+	Use:     "list <repository uri>",
+	Short:   "list runs of actions, newest first",
+	Example: "lakectl actions runs list lakefs://<repository> --branch master",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(1),
+		cmdutils.FuncValidator(0, uri.ValidateRepoURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		amount, _ := cmd.Flags().GetInt("amount")
+		after, _ := cmd.Flags().GetString("after")
+		branch, _ := cmd.Flags().GetString("branch")
+
+		u := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		runs, pagination, err := client.ListRuns(context.Background(), u.Repository, branch, after, amount)
+		if err != nil {
+			DieErr(err)
+		}
+
+		rows := make([][]interface{}, len(runs))
+		for i, run := range runs {
+			rows[i] = []interface{}{
+				swag.StringValue(run.RunID),
+				swag.StringValue(run.EventType),
+				swag.StringValue(run.Branch),
+				time.Unix(swag.Int64Value(run.StartTime), 0).String(),
+				time.Unix(swag.Int64Value(run.EndTime), 0).String(),
+				swag.StringValue(run.Status),
+			}
+		}
+		ctx := struct {
+			RunsTable  *Table
+			Pagination *Pagination
+		}{
+			RunsTable: &Table{
+				Headers: []interface{}{"Run ID", "Event", "Branch", "Start Time", "End Time", "Status"},
+				Rows:    rows,
+			},
+		}
+		if pagination != nil && swag.BoolValue(pagination.HasMore) {
+			ctx.Pagination = &Pagination{
+				Amount:  amount,
+				HasNext: true,
+				After:   pagination.NextOffset,
+			}
+		}
+		Write(actionsRunsListTemplate, ctx)
+	},
+}
+
+var actionsRunsDescribeTemplate = `Run ID: {{.RunID|yellow}}
+Event: {{.Run.EventType}}
+Branch: {{.Run.Branch}}{{if .Run.SourceRef}}
+Source Ref: {{.Run.SourceRef}}{{end}}
+Committer: {{.Run.Committer}}
+Message: {{.Run.CommitMessage}}
+Start Time: {{.StartTime|date}}
+End Time: {{.EndTime|date}}
+Status: {{.Status}}
+{{range $hook := .Hooks}}
+Hook: {{$hook.Action}}/{{$hook.HookID}}
+Status: {{if eq $hook.Status "failed"}}{{$hook.Status|red}}{{else}}{{$hook.Status}}{{end}}
+{{$hook.Log}}{{end}}
+`
+
+type actionsHookRun struct {
+	Action string
+	HookID string
+	Status string
+	Log    string
+}
+
+var actionsRunsDescribeCmd = &cobra.Command{
+	Use:     "describe <repository uri> <run id>",
+	Short:   "describe a run of actions, with the logs of its hooks",
+	Example: "lakectl actions runs describe lakefs://<repository> <run id>",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(2),
+		cmdutils.FuncValidator(0, uri.ValidateRepoURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		u := uri.Must(uri.Parse(args[0]))
+		runID := args[1]
+		client := getClient()
+		ctx := context.Background()
+		run, err := client.GetRun(ctx, u.Repository, runID)
+		if err != nil {
+			DieErr(err)
+		}
+		hookRuns, err := client.ListRunHooks(ctx, u.Repository, runID)
+		if err != nil {
+			DieErr(err)
+		}
+
+		hooks := make([]*actionsHookRun, len(hookRuns))
+		for i, h := range hookRuns {
+			hooks[i] = &actionsHookRun{
+				Action: swag.StringValue(h.Action),
+				HookID: swag.StringValue(h.HookID),
+				Status: swag.StringValue(h.Status),
+				Log:    h.Log,
+			}
+		}
+		Write(actionsRunsDescribeTemplate, struct {
+			Run       *models.ActionRun
+			RunID     string
+			StartTime int64
+			EndTime   int64
+			Status    string
+			Hooks     []*actionsHookRun
+		}{
+			Run:       run,
+			RunID:     swag.StringValue(run.RunID),
+			StartTime: swag.Int64Value(run.StartTime),
+			EndTime:   swag.Int64Value(run.EndTime),
+			Status:    swag.StringValue(run.Status),
+			Hooks:     hooks,
+		})
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCmd.AddCommand(actionsCmd)
+	actionsCmd.AddCommand(actionsRunsCmd)
+	actionsRunsCmd.AddCommand(actionsRunsListCmd)
+	actionsRunsCmd.AddCommand(actionsRunsDescribeCmd)
+
+	actionsRunsListCmd.Flags().String("branch", "", "list only runs on this branch")
+	actionsRunsListCmd.Flags().Int("amount", -1, "how many results to return, or-1 for all results (used for pagination)")
+	actionsRunsListCmd.Flags().String("after", "", "show results after this value (used for pagination)")
+}
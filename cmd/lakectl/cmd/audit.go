@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/api"
+)
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "explore the audit log of operations that changed lakeFS",
+}
+
+var auditListTemplate = `{{.EntriesTable | table -}}
+{{.Pagination | paginate }}
+`
+
+var auditListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "list audit log entries, newest first",
+	Example: "lakectl audit list --repository my-repo --since 24h",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		amount, _ := cmd.Flags().GetInt("amount")
+		after, _ := cmd.Flags().GetString("after")
+		filter := &api.AuditFilter{}
+		filter.User, _ = cmd.Flags().GetString("user")
+		filter.Action, _ = cmd.Flags().GetString("action")
+		filter.Repository, _ = cmd.Flags().GetString("repository")
+		filter.Ref, _ = cmd.Flags().GetString("ref")
+		filter.Result, _ = cmd.Flags().GetString("result")
+		since, _ := cmd.Flags().GetString("since")
+		until, _ := cmd.Flags().GetString("until")
+		var err error
+		if filter.Since, err = parseAuditTime(since); err != nil {
+			DieFmt("invalid --since: %s", err)
+		}
+		if filter.Until, err = parseAuditTime(until); err != nil {
+			DieFmt("invalid --until: %s", err)
+		}
+
+		client := getClient()
+		entries, pagination, err := client.ListAuditEntries(context.Background(), filter, after, amount)
+		if err != nil {
+			DieErr(err)
+		}
+
+		rows := make([][]interface{}, len(entries))
+		for i, entry := range entries {
+			rows[i] = []interface{}{
+				swag.StringValue(entry.ID),
+				time.Unix(swag.Int64Value(entry.Time), 0).String(),
+				swag.StringValue(entry.User),
+				swag.StringValue(entry.Source),
+				swag.StringValue(entry.Action),
+				entry.Repository,
+				entry.Ref,
+				swag.StringValue(entry.Result),
+			}
+		}
+		ctx := struct {
+			EntriesTable *Table
+			Pagination   *Pagination
+		}{
+			EntriesTable: &Table{
+				Headers: []interface{}{"ID", "Time", "User", "Source", "Action", "Repository", "Ref", "Result"},
+				Rows:    rows,
+			},
+		}
+		if pagination != nil && swag.BoolValue(pagination.HasMore) {
+			ctx.Pagination = &Pagination{
+				Amount:  amount,
+				HasNext: true,
+				After:   pagination.NextOffset,
+			}
+		}
+		Write(auditListTemplate, ctx)
+	},
+}
+
+// parseAuditTime returns the unix time of s, either an RFC3339 time or a duration before
+// now.  Returns 0 for an empty s.
+func parseAuditTime(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d).Unix(), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return 0, fmt.Errorf("%s: expected a duration (24h) or an RFC3339 time", s)
+	}
+	return t.Unix(), nil
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditListCmd)
+
+	auditListCmd.Flags().String("user", "", "list only operations of this user")
+	auditListCmd.Flags().String("action", "", "list only operations requiring this permission action (e.g. fs:DeleteBranch)")
+	auditListCmd.Flags().String("repository", "", "list only operations on this repository")
+	auditListCmd.Flags().String("ref", "", "list only operations on this branch or reference")
+	auditListCmd.Flags().String("result", "", "list only operations with this result (success or failure)")
+	auditListCmd.Flags().String("since", "", "list only operations since this time: an RFC3339 time or a duration before now (e.g. 24h)")
+	auditListCmd.Flags().String("until", "", "list only operations before this time: an RFC3339 time or a duration before now")
+	auditListCmd.Flags().Int("amount", -1, "how many results to return, or-1 for all results (used for pagination)")
+	auditListCmd.Flags().String("after", "", "show results after this value (used for pagination)")
+}
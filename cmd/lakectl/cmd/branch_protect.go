@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/api/gen/models"
+	"github.com/treeverse/lakefs/cmdutils"
+	"github.com/treeverse/lakefs/uri"
+)
+
+const blockedActionsFlagName = "blocked-actions"
+
+// branchProtectCmd represents the branch-protect command
+var branchProtectCmd = &cobra.Command{
+	Use:   "branch-protect",
+	Short: "create and manage branch protection rules",
+	Long: `Create, delete and list branch protection rules of a lakeFS repository.
+A rule forbids actions on all branches whose name matches its pattern, so that protected
+branches can only change through merges.`,
+}
+
+var branchProtectListTemplate = `{{.RuleTable | table -}}
+`
+
+var branchProtectListCmd = &cobra.Command{
+	Use:     "list <repository uri>",
+	Short:   "list branch protection rules",
+	Example: "lakectl branch-protect list lakefs://<repository>",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(1),
+		cmdutils.FuncValidator(0, uri.ValidateRepoURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		u := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		rules, err := client.GetBranchProtectionRules(context.Background(), u.Repository)
+		if err != nil {
+			DieErr(err)
+		}
+
+		rows := make([][]interface{}, len(rules))
+		for i, rule := range rules {
+			ts := time.Unix(rule.CreationDate, 0).String()
+			rows[i] = []interface{}{swag.StringValue(rule.Pattern), strings.Join(rule.BlockedActions, ","), ts}
+		}
+		Write(branchProtectListTemplate, struct {
+			RuleTable *Table
+		}{
+			RuleTable: &Table{
+				Headers: []interface{}{"Pattern", "Blocked Actions", "Creation Date"},
+				Rows:    rows,
+			},
+		})
+	},
+}
+
+var branchProtectAddCmd = &cobra.Command{
+	Use:   "add <repository uri> <pattern>",
+	Short: "add a branch protection rule, or replace the blocked actions of an existing rule",
+	Long: `Add a branch protection rule for branches matching pattern.
+Pattern uses shell file name matching, e.g. "master" or "release-*".
+Blocked actions are staging_write (upload, delete and reset objects), commit and delete (the branch).`,
+	Example: "lakectl branch-protect add lakefs://<repository> master --blocked-actions staging_write,commit,delete",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(2),
+		cmdutils.FuncValidator(0, uri.ValidateRepoURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		u := uri.Must(uri.Parse(args[0]))
+		blockedActions, _ := cmd.Flags().GetStringSlice(blockedActionsFlagName)
+		client := getClient()
+		err := client.SetBranchProtectionRule(context.Background(), u.Repository, &models.BranchProtectionRule{
+			Pattern:        swag.String(args[1]),
+			BlockedActions: blockedActions,
+		})
+		if err != nil {
+			DieErr(err)
+		}
+		Fmt("Branches matching '%s' are protected from: %s\n", args[1], strings.Join(blockedActions, ", "))
+	},
+}
+
+var branchProtectDeleteCmd = &cobra.Command{
+	Use:     "delete <repository uri> <pattern>",
+	Short:   "delete a branch protection rule",
+	Example: "lakectl branch-protect delete lakefs://<repository> master",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(2),
+		cmdutils.FuncValidator(0, uri.ValidateRepoURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		confirmation, err := confirm(cmd.Flags(), "Are you sure you want to delete branch protection rule")
+		if err != nil || !confirmation {
+			Die("Delete branch protection rule aborted", 1)
+		}
+		u := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		err = client.DeleteBranchProtectionRule(context.Background(), u.Repository, args[1])
+		if err != nil {
+			DieErr(err)
+		}
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCmd.AddCommand(branchProtectCmd)
+	branchProtectCmd.AddCommand(branchProtectListCmd)
+	branchProtectCmd.AddCommand(branchProtectAddCmd)
+	branchProtectCmd.AddCommand(branchProtectDeleteCmd)
+
+	branchProtectAddCmd.Flags().StringSlice(blockedActionsFlagName, []string{"staging_write", "commit"}, "actions to forbid on matching branches: staging_write, commit, delete")
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/api/gen/models"
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/cmdutils"
+	"github.com/treeverse/lakefs/uri"
+)
+
+var cherryPickCreateTemplate = `Cherry-pick of "{{.Ref.Ref}}" onto branch "{{.Branch.Ref}}" completed.
+
+ID: {{.Commit.ID|yellow}}
+Message: {{.Commit.Message}}
+Timestamp: {{.Commit.CreationDate|date}}
+Parents: {{.Commit.Parents|join ", "}}
+
+`
+
+var cherryPickCmd = &cobra.Command{
+	Use:   "cherry-pick <commit uri> <branch uri>",
+	Short: "apply the changes introduced by an existing commit onto a branch",
+	Long:  "apply the changes of a commit relative to its first parent and commit them on the given branch",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(2),
+		cmdutils.FuncValidator(0, uri.ValidateRefURI),
+		cmdutils.FuncValidator(1, uri.ValidateRefURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		refURI := uri.Must(uri.Parse(args[0]))
+		branchURI := uri.Must(uri.Parse(args[1]))
+		if refURI.Repository != branchURI.Repository {
+			Die("both references must belong to the same repository", 1)
+		}
+
+		client := getClient()
+		commit, err := client.CherryPick(context.Background(), branchURI.Repository, refURI.Ref, branchURI.Ref)
+		var conflictErr *catalog.ConflictError
+		if errors.As(err, &conflictErr) {
+			_, _ = fmt.Printf("Conflicts: %d\n", len(conflictErr.Paths))
+			for _, p := range conflictErr.Paths {
+				_, _ = fmt.Printf("\t%s\n", p)
+			}
+			Die(catalog.ErrConflictFound.Error(), 1)
+		}
+		if err != nil {
+			DieErr(err)
+		}
+
+		Write(cherryPickCreateTemplate, struct {
+			Ref    *uri.URI
+			Branch *uri.URI
+			Commit *models.Commit
+		}{refURI, branchURI, commit})
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCmd.AddCommand(cherryPickCmd)
+}
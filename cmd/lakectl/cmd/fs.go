@@ -2,11 +2,16 @@ package cmd
 
 import (
 	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/swag"
 	"github.com/spf13/cobra"
@@ -21,8 +26,16 @@ Modified Time: {{.Mtime|date}}
 Size: {{ .SizeBytes }} bytes
 Human Size: {{ .SizeBytes|human_bytes }}
 Checksum: {{.Checksum}}
+{{ if .PhysicalAddressURL }}Physical Address URL: {{ .PhysicalAddressURL }}
+{{ end -}}
 `
 
+var (
+	ErrDirectUploadNotSupported = errors.New("blockstore does not support direct upload")
+	ErrDirectUploadStdin        = errors.New("direct upload requires a file source")
+	ErrDirectUploadFailed       = errors.New("direct upload failed")
+)
+
 const fsRecursiveTemplate = `Files: {{.Count}}
 Total Size: {{.Bytes}} bytes
 Human Total Size: {{.Bytes|human_bytes}}
@@ -37,8 +50,9 @@ var fsStatCmd = &cobra.Command{
 	),
 	Run: func(cmd *cobra.Command, args []string) {
 		pathURI := uri.Must(uri.Parse(args[0]))
+		presign, _ := cmd.Flags().GetBool("presign")
 		client := getClient()
-		stat, err := client.StatObject(context.Background(), pathURI.Repository, pathURI.Ref, pathURI.Path)
+		stat, err := client.StatObject(context.Background(), pathURI.Repository, pathURI.Ref, pathURI.Path, presign)
 		if err != nil {
 			DieErr(err)
 		}
@@ -99,7 +113,10 @@ var fsCatCmd = &cobra.Command{
 	},
 }
 
-func upload(client api.Client, sourcePathname string, destURI *uri.URI) (*models.ObjectStats, error) {
+func upload(client api.Client, sourcePathname string, destURI *uri.URI, direct bool) (*models.ObjectStats, error) {
+	if direct {
+		return uploadDirect(client, sourcePathname, destURI)
+	}
 	var fp io.Reader
 	if strings.EqualFold(sourcePathname, "-") {
 		// upload from stdin
@@ -121,6 +138,56 @@ func upload(client api.Client, sourcePathname string, destURI *uri.URI) (*models
 	return stat, err
 }
 
+// uploadDirect uploads sourcePathname straight to the underlying storage of the repository
+// through a presigned URL, and then links the uploaded object to destURI.
+func uploadDirect(client api.Client, sourcePathname string, destURI *uri.URI) (*models.ObjectStats, error) {
+	if strings.EqualFold(sourcePathname, "-") {
+		return nil, ErrDirectUploadStdin
+	}
+	ctx := context.Background()
+	location, err := client.GetPhysicalAddress(ctx, destURI.Repository, destURI.Ref, destURI.Path)
+	if err != nil {
+		return nil, err
+	}
+	if location.PresignedURL == nil {
+		return nil, ErrDirectUploadNotSupported
+	}
+
+	file, err := os.Open(sourcePathname)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	hash := md5.New() //nolint:gosec
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, *location.PresignedURL, io.TeeReader(file, hash))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = info.Size()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%w: %s", ErrDirectUploadFailed, resp.Status)
+	}
+
+	return client.LinkPhysicalAddress(ctx, destURI.Repository, destURI.Ref, destURI.Path, &models.StagingMetadata{
+		Staging:   location,
+		Checksum:  swag.String(hex.EncodeToString(hash.Sum(nil))),
+		SizeBytes: swag.Int64(info.Size()),
+	})
+}
+
 var fsUploadCmd = &cobra.Command{
 	Use:   "upload <path uri>",
 	Short: "upload a local file to the specified URI",
@@ -133,8 +200,9 @@ var fsUploadCmd = &cobra.Command{
 		pathURI := uri.Must(uri.Parse(args[0]))
 		source, _ := cmd.Flags().GetString("source")
 		recursive, _ := cmd.Flags().GetBool("recursive")
+		direct, _ := cmd.Flags().GetBool("direct")
 		if !recursive {
-			stat, err := upload(client, source, pathURI)
+			stat, err := upload(client, source, pathURI, direct)
 			if err != nil {
 				DieErr(err)
 			}
@@ -156,7 +224,7 @@ var fsUploadCmd = &cobra.Command{
 			relPath := strings.TrimPrefix(path, source)
 			uri := *pathURI
 			uri.Path = filepath.Join(uri.Path, relPath)
-			stat, err := upload(client, path, &uri)
+			stat, err := upload(client, path, &uri, direct)
 			if err != nil {
 				return fmt.Errorf("upload %s: %w", path, err)
 			}
@@ -188,6 +256,55 @@ var fsRmCmd = &cobra.Command{
 	},
 }
 
+var fsCpCmd = &cobra.Command{
+	Use:   "cp <source path uri> <destination path uri>",
+	Short: "copy an object, possibly from another repository, to a branch",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(2),
+		cmdutils.FuncValidator(0, uri.ValidatePathURI),
+		cmdutils.FuncValidator(1, uri.ValidatePathURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		srcURI := uri.Must(uri.Parse(args[0]))
+		dstURI := uri.Must(uri.Parse(args[1]))
+		client := getClient()
+		stat, err := client.CopyObject(context.Background(), dstURI.Repository, dstURI.Ref, dstURI.Path, &models.ObjectCopyCreation{
+			SrcRepository: srcURI.Repository,
+			SrcRef:        swag.String(srcURI.Ref),
+			SrcPath:       swag.String(srcURI.Path),
+		})
+		if err != nil {
+			DieErr(err)
+		}
+		Write(fsStatTemplate, stat)
+	},
+}
+
+const fsPresignTemplate = `{{.URL}}
+`
+
+const fsPresignDefaultExpires = time.Hour
+
+var fsPresignCmd = &cobra.Command{
+	Use:   "presign <path uri>",
+	Short: "create a URL to read an object through the S3 gateway without credentials",
+	Long:  "create a presigned S3 gateway URL that reads an object, signed with your credentials.  Anyone with the URL can read the object until it expires.",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(1),
+		cmdutils.FuncValidator(0, uri.ValidatePathURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		pathURI := uri.Must(uri.Parse(args[0]))
+		expires, _ := cmd.Flags().GetDuration("expires")
+		client := getClient()
+		presigned, err := client.PresignObject(context.Background(), pathURI.Repository, pathURI.Ref, pathURI.Path, expires)
+		if err != nil {
+			DieErr(err)
+		}
+		Write(fsPresignTemplate, struct{ URL string }{swag.StringValue(presigned.URL)})
+	},
+}
+
 // fsCmd represents the fs command
 var fsCmd = &cobra.Command{
 	Use:   "fs",
@@ -202,8 +319,15 @@ func init() {
 	fsCmd.AddCommand(fsCatCmd)
 	fsCmd.AddCommand(fsUploadCmd)
 	fsCmd.AddCommand(fsRmCmd)
+	fsCmd.AddCommand(fsCpCmd)
+	fsCmd.AddCommand(fsPresignCmd)
 
 	fsUploadCmd.Flags().StringP("source", "s", "", "local file to upload, or \"-\" for stdin")
 	fsUploadCmd.Flags().BoolP("recursive", "r", false, "recursively copy all files under local source")
+	fsUploadCmd.Flags().Bool("direct", false, "upload directly to the underlying storage through a presigned URL, and then link the object")
 	_ = fsUploadCmd.MarkFlagRequired("source")
+
+	fsStatCmd.Flags().Bool("presign", false, "return a presigned URL that reads the object directly from the underlying storage")
+
+	fsPresignCmd.Flags().Duration("expires", fsPresignDefaultExpires, "validity of the URL, at most a week")
 }
@@ -44,9 +44,13 @@ var logCmd = &cobra.Command{
 		if err != nil {
 			DieErr(err)
 		}
+		path, err := cmd.Flags().GetString("path")
+		if err != nil {
+			DieErr(err)
+		}
 		client := getClient()
 		branchURI := uri.Must(uri.Parse(args[0]))
-		commits, pagination, err := client.GetCommitLog(context.Background(), branchURI.Repository, branchURI.Ref, after, amount)
+		commits, pagination, err := client.GetCommitLog(context.Background(), branchURI.Repository, branchURI.Ref, path, after, amount)
 		ctx := struct {
 			Commits    []*models.Commit
 			Pagination *Pagination
@@ -72,4 +76,5 @@ func init() {
 	rootCmd.AddCommand(logCmd)
 	logCmd.Flags().Int("amount", -1, "how many results to return, or-1 for all results (used for pagination)")
 	logCmd.Flags().String("after", "", "show results after this value (used for pagination)")
+	logCmd.Flags().String("path", "", "show only commits that changed objects under this path prefix")
 }
@@ -35,7 +35,8 @@ var mergeCmd = &cobra.Command{
 			Die("both references must belong to the same repository", 1)
 		}
 
-		result, err := client.Merge(context.Background(), leftRefURI.Repository, leftRefURI.Ref, rightRefURI.Ref)
+		strategy, _ := cmd.Flags().GetString("strategy")
+		result, err := client.Merge(context.Background(), leftRefURI.Repository, leftRefURI.Ref, rightRefURI.Ref, strategy)
 		if errors.Is(err, catalog.ErrConflictFound) {
 			_, _ = fmt.Printf("Conflicts: %d\n", result.Summary.Conflict)
 			return
@@ -50,4 +51,5 @@ var mergeCmd = &cobra.Command{
 //nolint:gochecknoinits
 func init() {
 	rootCmd.AddCommand(mergeCmd)
+	mergeCmd.Flags().String("strategy", "", "conflict resolution strategy: fail, source-wins or dest-wins (default fail)")
 }
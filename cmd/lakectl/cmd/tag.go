@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/go-openapi/swag"
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/cmdutils"
+	"github.com/treeverse/lakefs/uri"
+)
+
+// tagCmd represents the tag command
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "create and manage tags within a repository",
+	Long:  `Create delete and list tags within a lakeFS repository`,
+}
+
+var tagListTemplate = `{{.TagTable | table -}}
+{{.Pagination | paginate }}
+`
+
+var tagListCmd = &cobra.Command{
+	Use:     "list <repository uri>",
+	Short:   "list tags in a repository",
+	Example: "lakectl tag list lakefs://<repository>",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(1),
+		cmdutils.FuncValidator(0, uri.ValidateRepoURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		amount, _ := cmd.Flags().GetInt("amount")
+		after, _ := cmd.Flags().GetString("after")
+
+		u := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		response, pagination, err := client.ListTags(context.Background(), u.Repository, after, amount)
+		if err != nil {
+			DieErr(err)
+		}
+
+		rows := make([][]interface{}, len(response))
+		for i, row := range response {
+			rows[i] = []interface{}{swag.StringValue(row.ID), swag.StringValue(row.CommitID)}
+		}
+
+		ctx := struct {
+			TagTable   *Table
+			Pagination *Pagination
+		}{
+			TagTable: &Table{
+				Headers: []interface{}{"Tag", "Commit ID"},
+				Rows:    rows,
+			},
+		}
+		if pagination != nil && swag.BoolValue(pagination.HasMore) {
+			ctx.Pagination = &Pagination{
+				Amount:  amount,
+				HasNext: true,
+				After:   pagination.NextOffset,
+			}
+		}
+
+		Write(tagListTemplate, ctx)
+	},
+}
+
+var tagCreateCmd = &cobra.Command{
+	Use:   "create <tag uri> <commit ref>",
+	Short: "create a new tag in a repository",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(2),
+		cmdutils.FuncValidator(0, uri.ValidateRefURI),
+		cmdutils.FuncValidator(1, uri.ValidateRefURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		tagURI := uri.Must(uri.Parse(args[0]))
+		commitURI := uri.Must(uri.Parse(args[1]))
+		if commitURI.Repository != tagURI.Repository {
+			Die("both references must belong to the same repository", 1)
+		}
+
+		client := getClient()
+		tag, err := client.CreateTag(context.Background(), tagURI.Repository, tagURI.Ref, commitURI.Ref)
+		if err != nil {
+			DieErr(err)
+		}
+		Fmt("Created tag '%s' (%s)\n", swag.StringValue(tag.ID), swag.StringValue(tag.CommitID))
+	},
+}
+
+var tagDeleteCmd = &cobra.Command{
+	Use:   "delete <tag uri>",
+	Short: "delete a tag from a repository",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(1),
+		cmdutils.FuncValidator(0, uri.ValidateRefURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		confirmation, err := confirm(cmd.Flags(), "Are you sure you want to delete tag")
+		if err != nil || !confirmation {
+			Die("Delete tag aborted", 1)
+		}
+		client := getClient()
+		u := uri.Must(uri.Parse(args[0]))
+		err = client.DeleteTag(context.Background(), u.Repository, u.Ref)
+		if err != nil {
+			DieErr(err)
+		}
+	},
+}
+
+var tagShowCmd = &cobra.Command{
+	Use:   "show <tag uri>",
+	Short: "show the commit a tag points at",
+	Args: cmdutils.ValidationChain(
+		cobra.ExactArgs(1),
+		cmdutils.FuncValidator(0, uri.ValidateRefURI),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getClient()
+		u := uri.Must(uri.Parse(args[0]))
+		tag, err := client.GetTag(context.Background(), u.Repository, u.Ref)
+		if err != nil {
+			DieErr(err)
+		}
+		Fmt("%s\n", swag.StringValue(tag.CommitID))
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCmd.AddCommand(tagCmd)
+	tagCmd.AddCommand(tagCreateCmd)
+	tagCmd.AddCommand(tagDeleteCmd)
+	tagCmd.AddCommand(tagListCmd)
+	tagCmd.AddCommand(tagShowCmd)
+
+	tagListCmd.Flags().Int("amount", -1, "how many results to return, or-1 for all results (used for pagination)")
+	tagListCmd.Flags().String("after", "", "show results after this value (used for pagination)")
+}
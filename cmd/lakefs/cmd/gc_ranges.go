@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	catalogfactory "github.com/treeverse/lakefs/catalog/factory"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/graveler/gc"
+	"github.com/treeverse/lakefs/parade"
+	"github.com/treeverse/lakefs/uri"
+)
+
+const (
+	GracePeriodFlagName = "grace-period"
+	BackgroundFlagName  = "background"
+)
+
+var gcRangesCmd = &cobra.Command{
+	Use:   "gc-ranges [repository uri]",
+	Short: "Remove ranges and metaranges no longer referenced by any commit",
+	Long: `Remove ranges and metaranges that are not referenced by any commit reachable from a branch or a tag.
+Collects all repositories unless a repository is given.`,
+	Example: "lakefs gc-ranges lakefs://example-repo --dry-run",
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		dryRun, _ := flags.GetBool(DryRunFlagName)
+		gracePeriod, _ := flags.GetDuration(GracePeriodFlagName)
+		background, _ := flags.GetBool(BackgroundFlagName)
+
+		ctx := context.Background()
+		err := db.ValidateSchemaUpToDate(cfg.GetDatabaseParams())
+		if errors.Is(err, db.ErrSchemaNotCompatible) {
+			fmt.Println("Migration version mismatch, for more information see https://docs.lakefs.io/deploying/upgrade.html")
+			os.Exit(1)
+		}
+		if err != nil {
+			fmt.Printf("%s\n", err)
+			os.Exit(1)
+		}
+		dbPool := db.BuildDatabaseConnection(cfg.GetDatabaseParams())
+		defer dbPool.Close()
+
+		cataloger, err := catalogfactory.BuildCataloger(dbPool, cfg)
+		if err != nil {
+			fmt.Printf("Failed to create cataloger: %s\n", err)
+			os.Exit(1)
+		}
+		defer func() { _ = cataloger.Close() }()
+
+		collector, ok := cataloger.(gc.Collector)
+		if !ok {
+			fmt.Println("Configured cataloger does not store ranges, nothing to collect")
+			os.Exit(1)
+		}
+
+		var repositories []string
+		if len(args) > 0 {
+			if err := uri.ValidateRepoURI(args[0]); err != nil {
+				fmt.Printf("Invalid repository: %s\n", err)
+				os.Exit(1)
+			}
+			repositories = append(repositories, uri.Must(uri.Parse(args[0])).Repository)
+		} else {
+			repos, _, err := cataloger.ListRepositories(ctx, -1, "")
+			if err != nil {
+				fmt.Printf("Failed to list repositories: %s\n", err)
+				os.Exit(1)
+			}
+			for _, repo := range repos {
+				repositories = append(repositories, repo.Name)
+			}
+		}
+
+		params := gc.Params{
+			DryRun:      dryRun,
+			GracePeriod: gracePeriod,
+		}
+		if background {
+			tasks := make([]parade.TaskData, 0, len(repositories))
+			for _, repository := range repositories {
+				task, err := gc.NewCollectRangesTask(graveler.RepositoryID(repository), params)
+				if err != nil {
+					fmt.Printf("Failed to create task for %s: %s\n", repository, err)
+					os.Exit(1)
+				}
+				tasks = append(tasks, task)
+			}
+			if err := parade.NewParadeDB(dbPool.Pool()).InsertTasks(ctx, tasks); err != nil {
+				fmt.Printf("Failed to schedule collection: %s\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Scheduled collection of %d repositories\n", len(tasks))
+			return
+		}
+
+		if dryRun {
+			fmt.Print("Starting dry run. Will not remove any files.\n\n")
+		}
+		failed := false
+		for _, repository := range repositories {
+			report, err := collector.CollectRanges(ctx, graveler.RepositoryID(repository), params)
+			if err != nil {
+				fmt.Printf("Failed to collect %s: %s\n", repository, err)
+				failed = true
+				continue
+			}
+			fmt.Printf("Repository: %s\n", report.RepositoryID)
+			fmt.Printf("  Live metaranges: %d\n", report.LiveMetaRanges)
+			fmt.Printf("  Live ranges: %d\n", report.LiveRanges)
+			if dryRun {
+				fmt.Printf("  Files to remove: %d\n", len(report.Removed))
+				for _, filename := range report.Removed {
+					fmt.Printf("    %s\n", filename)
+				}
+			} else {
+				fmt.Printf("  Removed files: %d\n", len(report.Removed))
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCmd.AddCommand(gcRangesCmd)
+	gcRangesCmd.Flags().Bool(DryRunFlagName, false, "only report the files to remove")
+	gcRangesCmd.Flags().Duration(GracePeriodFlagName, gc.DefaultGracePeriod, "do not remove files newer than this")
+	gcRangesCmd.Flags().Bool(BackgroundFlagName, false, "schedule the collection on the lakeFS server instead of running it")
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/jedib0t/go-pretty/text"
 	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/block/factory"
 	"github.com/treeverse/lakefs/catalog"
 	catalogfactory "github.com/treeverse/lakefs/catalog/factory"
@@ -23,20 +24,25 @@ import (
 )
 
 const (
-	DryRunFlagName       = "dry-run"
-	WithMergeFlagName    = "with-merge"
-	HideProgressFlagName = "hide-progress"
-	ManifestURLFlagName  = "manifest"
-	PrefixesFileFlagName = "prefix-file"
-	ManifestURLFormat    = "s3://example-bucket/inventory/YYYY-MM-DDT00-00Z/manifest.json"
-	ImportCmdNumArgs     = 1
-	CommitterName        = "lakefs"
+	DryRunFlagName          = "dry-run"
+	WithMergeFlagName       = "with-merge"
+	HideProgressFlagName    = "hide-progress"
+	ManifestURLFlagName     = "manifest"
+	ListURLFlagName         = "list"
+	PrefixesFileFlagName    = "prefix-file"
+	ManifestURLFormat       = "s3://example-bucket/inventory/YYYY-MM-DDT00-00Z/manifest.json"
+	GSInventoryURLFormat    = "gs://example-bucket/path/to/import or gs://example-bucket/reports/config_YYYY-MM-DDT00:00_manifest.json"
+	LocalInventoryURLFormat = "local://example-namespace/path/to/import"
+	ImportCmdNumArgs        = 1
+	CommitterName           = "lakefs"
 )
 
 var importCmd = &cobra.Command{
-	Use:   "import <repository uri> --manifest <s3 uri to manifest.json>",
-	Short: "Import data from S3 to a lakeFS repository",
-	Long:  "Import from an S3 inventory to lakeFS without copying the data.",
+	Use:   "import <repository uri> {--manifest <inventory uri> | --list <storage uri>}",
+	Short: "Import data from S3, GS or local storage to a lakeFS repository",
+	Long: `Import from an S3 inventory, a GS Storage Insights inventory report, or a listing of a GS
+bucket prefix or a local directory, to lakeFS without copying the data.
+With --list, import any storage prefix by listing it through the configured block adapter.`,
 	Args: cmdutils.ValidationChain(
 		cobra.ExactArgs(ImportCmdNumArgs),
 		cmdutils.FuncValidator(0, uri.ValidateRepoURI),
@@ -45,6 +51,7 @@ var importCmd = &cobra.Command{
 		flags := cmd.Flags()
 		dryRun, _ := flags.GetBool(DryRunFlagName)
 		manifestURL, _ := flags.GetString(ManifestURLFlagName)
+		listURL, _ := flags.GetString(ListURLFlagName)
 		withMerge, _ := flags.GetBool(WithMergeFlagName)
 		hideProgress, _ := flags.GetBool(HideProgressFlagName)
 		prefixFile, _ := flags.GetString(PrefixesFileFlagName)
@@ -77,15 +84,25 @@ var importCmd = &cobra.Command{
 			fmt.Printf("Failed to create block adapter: %s\n", err)
 			os.Exit(1)
 		}
-		if blockStore.BlockstoreType() != "s3" {
-			fmt.Printf("Configuration uses unsupported block adapter: %s. Only s3 is supported.\n", blockStore.BlockstoreType())
-			os.Exit(1)
-		}
 		repoName := u.Repository
-		parsedURL, err := url.Parse(manifestURL)
-		if err != nil || parsedURL.Scheme != "s3" || !strings.HasSuffix(parsedURL.Path, "/manifest.json") {
-			fmt.Printf("Invalid manifest url. expected format: %s\n", ManifestURLFormat)
+		var inventoryGenerator block.InventoryGenerator = blockStore
+		inventoryURL := manifestURL
+		switch {
+		case manifestURL != "" && listURL != "":
+			fmt.Printf("Only one of --%s and --%s may be set\n", ManifestURLFlagName, ListURLFlagName)
 			os.Exit(1)
+		case listURL != "":
+			if _, err := block.ResolveNamespace(listURL, ""); err != nil {
+				fmt.Printf("Invalid list url %s: %s\n", listURL, err)
+				os.Exit(1)
+			}
+			inventoryGenerator = block.NewListingInventoryGenerator(blockStore, block.DefaultListingParallelism)
+			inventoryURL = listURL
+		default:
+			if err := validateInventoryURL(blockStore.BlockstoreType(), manifestURL); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
 		}
 
 		repo, err := getRepository(ctx, cataloger, repoName, dryRun)
@@ -125,9 +142,9 @@ var importCmd = &cobra.Command{
 		}
 		importConfig := &onboard.Config{
 			CommitUsername:     CommitterName,
-			InventoryURL:       manifestURL,
+			InventoryURL:       inventoryURL,
 			Repository:         repoName,
-			InventoryGenerator: blockStore,
+			InventoryGenerator: inventoryGenerator,
 			Cataloger:          cataloger,
 			KeyPrefixes:        prefixes,
 		}
@@ -172,7 +189,7 @@ var importCmd = &cobra.Command{
 		if withMerge {
 			fmt.Printf("Merging import changes into lakefs://%s@%s/\n", repoName, repo.DefaultBranch)
 			msg := fmt.Sprintf(onboard.CommitMsgTemplate, stats.CommitRef)
-			commitLog, err := cataloger.Merge(ctx, repoName, catalog.DefaultImportBranchName, repo.DefaultBranch, CommitterName, msg, nil)
+			commitLog, err := cataloger.Merge(ctx, repoName, catalog.DefaultImportBranchName, repo.DefaultBranch, CommitterName, msg, nil, "")
 			if err != nil {
 				fmt.Printf("Merge failed: %s\n", err)
 				os.Exit(1)
@@ -186,6 +203,37 @@ var importCmd = &cobra.Command{
 	},
 }
 
+var (
+	ErrUnsupportedImportBlockstore = errors.New("unsupported block adapter for import, only s3, gs and local are supported")
+	ErrInvalidInventoryURL         = errors.New("invalid manifest url")
+)
+
+// validateInventoryURL checks that inventoryURL is an inventory the block adapter of
+// blockstoreType can generate.
+func validateInventoryURL(blockstoreType, inventoryURL string) error {
+	parsedURL, err := url.Parse(inventoryURL)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidInventoryURL, err)
+	}
+	switch blockstoreType {
+	case "s3":
+		if parsedURL.Scheme != "s3" || !strings.HasSuffix(parsedURL.Path, "/manifest.json") {
+			return fmt.Errorf("%w. expected format: %s", ErrInvalidInventoryURL, ManifestURLFormat)
+		}
+	case "gs":
+		if parsedURL.Scheme != "gs" || parsedURL.Host == "" {
+			return fmt.Errorf("%w. expected format: %s", ErrInvalidInventoryURL, GSInventoryURLFormat)
+		}
+	case "local":
+		if parsedURL.Scheme != "local" || parsedURL.Host == "" {
+			return fmt.Errorf("%w. expected format: %s", ErrInvalidInventoryURL, LocalInventoryURLFormat)
+		}
+	default:
+		return fmt.Errorf("%w: configuration uses %s", ErrUnsupportedImportBlockstore, blockstoreType)
+	}
+	return nil
+}
+
 func getRepository(ctx context.Context, cataloger catalog.Cataloger, repoName string, dryRun bool) (*catalog.Repository, error) {
 	if dryRun {
 		return &catalog.Repository{
@@ -212,8 +260,8 @@ func getRepository(ctx context.Context, cataloger catalog.Cataloger, repoName st
 func init() {
 	rootCmd.AddCommand(importCmd)
 	importCmd.Flags().Bool(DryRunFlagName, false, "Only read inventory and print stats, without making any changes")
-	importCmd.Flags().StringP(ManifestURLFlagName, "m", "", fmt.Sprintf("S3 uri to the manifest.json to use for the import. Format: %s", ManifestURLFormat))
-	_ = importCmd.MarkFlagRequired(ManifestURLFlagName)
+	importCmd.Flags().StringP(ManifestURLFlagName, "m", "", fmt.Sprintf("uri of the inventory to import. S3 manifest.json format: %s. GS formats: %s. Local format: %s", ManifestURLFormat, GSInventoryURLFormat, LocalInventoryURLFormat))
+	importCmd.Flags().String(ListURLFlagName, "", "uri of a storage prefix to list and import instead of an inventory, e.g. s3://example-bucket/path/to/import")
 	importCmd.Flags().Bool(WithMergeFlagName, false, "Merge imported data to the repository's main branch")
 	importCmd.Flags().Bool(HideProgressFlagName, false, "Suppress progress bar")
 	importCmd.Flags().StringP(PrefixesFileFlagName, "p", "", "File with a list of key prefixes. Imported object keys will be filtered according to these prefixes")
@@ -16,7 +16,9 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/actions"
 	"github.com/treeverse/lakefs/api"
+	"github.com/treeverse/lakefs/audit"
 	"github.com/treeverse/lakefs/auth"
 	"github.com/treeverse/lakefs/auth/crypt"
 	"github.com/treeverse/lakefs/block/factory"
@@ -28,6 +30,8 @@ import (
 	"github.com/treeverse/lakefs/gateway"
 	"github.com/treeverse/lakefs/gateway/multiparts"
 	"github.com/treeverse/lakefs/gateway/simulator"
+	"github.com/treeverse/lakefs/graveler/gc"
+	"github.com/treeverse/lakefs/hooks"
 	"github.com/treeverse/lakefs/httputil"
 	"github.com/treeverse/lakefs/logging"
 	"github.com/treeverse/lakefs/parade"
@@ -69,12 +73,21 @@ var runCmd = &cobra.Command{
 
 		registerPrometheusCollector(dbPool)
 		retention := retention.NewService(dbPool)
+		auditService := audit.NewDBService(dbPool)
 		migrator := db.NewDatabaseMigrator(dbParams)
 
 		cataloger, err := catalogfactory.BuildCataloger(dbPool, cfg)
 		if err != nil {
 			logger.WithError(err).Fatal("failed to create cataloger")
 		}
+		// pre-commit and pre-merge webhooks
+		webhooks, err := cfg.GetHooksWebhooks()
+		if err != nil {
+			logger.WithError(err).Fatal("failed to read webhooks configuration")
+		}
+		if err := hooks.RegisterWebhooks(cataloger.Hooks(), webhooks); err != nil {
+			logger.WithError(err).Fatal("failed to register webhooks")
+		}
 		multipartsTracker := multiparts.NewTracker(dbPool)
 
 		// init block store
@@ -82,6 +95,12 @@ var runCmd = &cobra.Command{
 		if err != nil {
 			logger.WithError(err).Fatal("Failed to create block adapter")
 		}
+		// actions stored in repositories run on pre-commit and pre-merge, their webhooks may
+		// only call the allowed hosts
+		actionsService := actions.NewService(dbPool, cataloger, blockStore, hooks.TargetPolicy{
+			AllowedHosts: cfg.GetActionsWebhookAllowedHosts(),
+		})
+		actionsService.Register(cataloger.Hooks())
 
 		// init authentication
 		authService := auth.NewDBAuthService(
@@ -104,11 +123,19 @@ var runCmd = &cobra.Command{
 		// export handler
 		exportHandler := export.NewHandler(blockStore, cataloger, paradeDB)
 		exportActionManager := parade.NewActionManager(exportHandler, paradeDB, nil)
+		// ranges garbage collection handler, only catalogers backed by graveler hold ranges
+		var gcActionManager *parade.ActionManager
+		if collector, ok := cataloger.(gc.Collector); ok {
+			gcActionManager = parade.NewActionManager(gc.NewHandler(collector), paradeDB, nil)
+		}
 		defer func() {
 			// order is important - close cataloger channel before dedup
 			_ = cataloger.Close()
 			_ = dedupCleaner.Close()
 			exportActionManager.Close()
+			if gcActionManager != nil {
+				gcActionManager.Close()
+			}
 		}()
 
 		// start API server
@@ -126,6 +153,12 @@ var runCmd = &cobra.Command{
 			migrator,
 			paradeDB,
 			dedupCleaner,
+			actionsService,
+			auditService,
+			api.S3GatewayConfig{
+				Region:     cfg.GetS3GatewayRegion(),
+				DomainName: cfg.GetS3GatewayDomainName(),
+			},
 			logger.WithField("service", "api_gateway"),
 		)
 
@@ -147,7 +180,12 @@ var runCmd = &cobra.Command{
 			cfg.GetS3GatewayDomainName(),
 			bufferedCollector,
 			dedupCleaner,
+			auditService,
 			s3FallbackURL,
+			gateway.BucketConfig{
+				StorageNamespaceTemplate: cfg.GetS3GatewayStorageNamespaceTemplate(),
+				DefaultBranch:            cfg.GetS3GatewayDefaultBranch(),
+			},
 		)
 		ctx, cancelFn := context.WithCancel(context.Background())
 		go bufferedCollector.Run(ctx)
@@ -20,6 +20,7 @@ import (
 	blockparams "github.com/treeverse/lakefs/block/params"
 	catalogparams "github.com/treeverse/lakefs/catalog/mvcc/params"
 	dbparams "github.com/treeverse/lakefs/db/params"
+	hooksparams "github.com/treeverse/lakefs/hooks/params"
 	"github.com/treeverse/lakefs/logging"
 	pyramidparams "github.com/treeverse/lakefs/pyramid/params"
 )
@@ -35,17 +36,23 @@ const (
 	DefaultCommittedLocalCacheDir      = "~/lakefs/local_tier"
 	DefaultCommittedBlockStoragePrefix = "_lakefs"
 
+	DefaultCommittedSSTableCacheSize          = 1000
+	DefaultCommittedApproximateRangeSizeBytes = 1 << 20
+
 	DefaultBlockStoreGSS3Endpoint = "https://storage.googleapis.com"
 
+	DefaultBlockStoreAzureTryTimeout = 10 * time.Minute
+
 	DefaultAuthCacheEnabled = true
 	DefaultAuthCacheSize    = 1024
 	DefaultAuthCacheTTL     = 20 * time.Second
 	DefaultAuthCacheJitter  = 3 * time.Second
 
-	DefaultListenAddr          = "0.0.0.0:8000"
-	DefaultS3GatewayDomainName = "s3.local.lakefs.io"
-	DefaultS3GatewayRegion     = "us-east-1"
-	DefaultS3MaxRetries        = 5
+	DefaultListenAddr             = "0.0.0.0:8000"
+	DefaultS3GatewayDomainName    = "s3.local.lakefs.io"
+	DefaultS3GatewayRegion        = "us-east-1"
+	DefaultS3GatewayDefaultBranch = "master"
+	DefaultS3MaxRetries           = 5
 
 	DefaultStatsEnabled       = true
 	DefaultStatsAddr          = "https://stats.treeverse.io"
@@ -98,12 +105,17 @@ func setDefaults() {
 	viper.SetDefault("committed.local_cache.size_bytes", DefaultCommittedLocalCacheBytes)
 	viper.SetDefault("committed.local_cache.dir", DefaultCommittedLocalCacheDir)
 	viper.SetDefault("committed.block_storage_prefix", DefaultCommittedBlockStoragePrefix)
+	viper.SetDefault("committed.sstable.cache_size", DefaultCommittedSSTableCacheSize)
+	viper.SetDefault("committed.approximate_range_size_bytes", DefaultCommittedApproximateRangeSizeBytes)
 
 	viper.SetDefault("gateways.s3.domain_name", DefaultS3GatewayDomainName)
 	viper.SetDefault("gateways.s3.region", DefaultS3GatewayRegion)
+	viper.SetDefault("gateways.s3.default_branch", DefaultS3GatewayDefaultBranch)
 
 	viper.SetDefault("blockstore.gs.s3_endpoint", DefaultBlockStoreGSS3Endpoint)
 
+	viper.SetDefault("blockstore.azure.try_timeout", DefaultBlockStoreAzureTryTimeout)
+
 	viper.SetDefault("stats.enabled", DefaultStatsEnabled)
 	viper.SetDefault("stats.address", DefaultStatsAddr)
 	viper.SetDefault("stats.flush_interval", DefaultStatsFlushInterval)
@@ -268,6 +280,15 @@ func (c *Config) GetBlockAdapterGSParams() (blockparams.GS, error) {
 	}, nil
 }
 
+func (c *Config) GetBlockAdapterAzureParams() (blockparams.Azure, error) {
+	return blockparams.Azure{
+		StorageAccount:   viper.GetString("blockstore.azure.storage_account"),
+		StorageAccessKey: viper.GetString("blockstore.azure.storage_access_key"),
+		Endpoint:         viper.GetString("blockstore.azure.endpoint"),
+		TryTimeout:       viper.GetDuration("blockstore.azure.try_timeout"),
+	}, nil
+}
+
 func (c *Config) GetAuthCacheConfig() authparams.ServiceCache {
 	return authparams.ServiceCache{
 		Enabled:        viper.GetBool("auth.cache.enabled"),
@@ -297,6 +318,14 @@ func (c *Config) GetS3GatewayFallbackURL() string {
 	return viper.GetString("gateways.s3.fallback_url")
 }
 
+func (c *Config) GetS3GatewayStorageNamespaceTemplate() string {
+	return viper.GetString("gateways.s3.storage_namespace_template")
+}
+
+func (c *Config) GetS3GatewayDefaultBranch() string {
+	return viper.GetString("gateways.s3.default_branch")
+}
+
 func (c *Config) GetListenAddress() string {
 	return viper.GetString("listen_address")
 }
@@ -333,6 +362,32 @@ func (c *Config) GetCommittedTierFSParams() (*pyramidparams.Params, error) {
 	}, nil
 }
 
+// GetCommittedSSTableCacheSize returns the number of SSTables to keep open in memory.
+func (c *Config) GetCommittedSSTableCacheSize() int {
+	return viper.GetInt("committed.sstable.cache_size")
+}
+
+// GetCommittedApproximateRangeSizeBytes returns the approximate size of ranges written by
+// committed.
+func (c *Config) GetCommittedApproximateRangeSizeBytes() uint64 {
+	return viper.GetUint64("committed.approximate_range_size_bytes")
+}
+
+// GetHooksWebhooks returns the webhooks called on catalog events.
+func (c *Config) GetHooksWebhooks() ([]hooksparams.Webhook, error) {
+	var webhooks []hooksparams.Webhook
+	if err := viper.UnmarshalKey("hooks.webhooks", &webhooks); err != nil {
+		return nil, fmt.Errorf("hooks.webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// GetActionsWebhookAllowedHosts returns the patterns of the hosts that webhooks of actions
+// stored in repositories may call.  Actions may not call any webhook unless set.
+func (c *Config) GetActionsWebhookAllowedHosts() []string {
+	return viper.GetStringSlice("actions.webhooks.allowed_hosts")
+}
+
 func GetMetastoreAwsConfig() *aws.Config {
 	cfg := &aws.Config{
 		Region: aws.String(viper.GetString("metastore.glue.region")),
@@ -8,6 +8,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"github.com/treeverse/lakefs/block/azure"
 	"github.com/treeverse/lakefs/block/factory"
 	"github.com/treeverse/lakefs/block/gs"
 	"github.com/treeverse/lakefs/block/local"
@@ -106,6 +107,16 @@ func TestConfig_BuildBlockAdapter(t *testing.T) {
 			t.Fatalf("expected an gs block adapter, got something else instead")
 		}
 	})
+
+	t.Run("azure block adapter", func(t *testing.T) {
+		newConfigFromFile("testdata/valid_azure_adapter_config.yaml")
+		c := config.NewConfig()
+		adapter, err := factory.BuildBlockAdapter(c)
+		testutil.Must(t, err)
+		if _, ok := adapter.(*azure.Adapter); !ok {
+			t.Fatalf("expected an azure block adapter, got something else instead")
+		}
+	})
 }
 
 func TestConfig_JSONLogger(t *testing.T) {
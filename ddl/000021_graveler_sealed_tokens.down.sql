@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE graveler_branches
+DROP COLUMN sealed_tokens;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE graveler_branches
+ADD COLUMN sealed_tokens text[] NOT NULL DEFAULT '{}';
+COMMIT;
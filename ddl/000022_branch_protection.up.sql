@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS catalog_branch_protection_rules (
+    repository varchar NOT NULL,
+    pattern varchar NOT NULL,
+    blocked_actions varchar array NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (repository, pattern)
+);
+
+COMMIT;
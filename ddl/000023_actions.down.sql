@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS actions_run_hooks;
+DROP TABLE IF EXISTS actions_runs;
+COMMIT;
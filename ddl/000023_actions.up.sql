@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS actions_runs (
+    repository varchar NOT NULL,
+    run_id varchar NOT NULL,
+    event_type varchar NOT NULL,
+    branch varchar NOT NULL,
+    source_ref varchar NOT NULL DEFAULT '',
+    committer varchar NOT NULL DEFAULT '',
+    commit_message varchar NOT NULL DEFAULT '',
+    start_time timestamptz NOT NULL,
+    end_time timestamptz NOT NULL,
+    passed boolean NOT NULL,
+    PRIMARY KEY (repository, run_id)
+);
+
+CREATE INDEX IF NOT EXISTS actions_runs_branch_idx ON actions_runs (repository, branch, run_id);
+
+CREATE TABLE IF NOT EXISTS actions_run_hooks (
+    repository varchar NOT NULL,
+    run_id varchar NOT NULL,
+    hook_run_id integer NOT NULL,
+    action_name varchar NOT NULL,
+    hook_id varchar NOT NULL,
+    start_time timestamptz NOT NULL,
+    end_time timestamptz NOT NULL,
+    passed boolean NOT NULL,
+    log text NOT NULL DEFAULT '',
+    PRIMARY KEY (repository, run_id, hook_run_id),
+    FOREIGN KEY (repository, run_id) REFERENCES actions_runs (repository, run_id) ON DELETE CASCADE
+);
+
+COMMIT;
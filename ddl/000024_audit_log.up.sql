@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    source varchar NOT NULL,
+    user_id varchar NOT NULL,
+    action varchar NOT NULL,
+    resource varchar NOT NULL DEFAULT '',
+    repository varchar NOT NULL DEFAULT '',
+    ref varchar NOT NULL DEFAULT '',
+    request_id varchar NOT NULL DEFAULT '',
+    status_code integer NOT NULL,
+    result varchar NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX IF NOT EXISTS audit_log_user_id_idx ON audit_log (user_id, id);
+CREATE INDEX IF NOT EXISTS audit_log_repository_idx ON audit_log (repository, id);
+
+-- the audit log is append-only
+CREATE OR REPLACE RULE audit_log_no_update AS ON UPDATE TO audit_log DO INSTEAD NOTHING;
+CREATE OR REPLACE RULE audit_log_no_delete AS ON DELETE TO audit_log DO INSTEAD NOTHING;
+
+COMMIT;
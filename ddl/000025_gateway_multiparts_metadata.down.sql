@@ -0,0 +1 @@
+ALTER TABLE gateway_multiparts DROP COLUMN IF EXISTS metadata;
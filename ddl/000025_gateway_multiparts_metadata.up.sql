@@ -0,0 +1 @@
+ALTER TABLE gateway_multiparts ADD COLUMN IF NOT EXISTS metadata jsonb;
@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP TABLE IF EXISTS gateway_multipart_parts;
+
+DROP INDEX IF EXISTS gateway_multiparts_repository_idx;
+
+ALTER TABLE gateway_multiparts
+    DROP COLUMN IF EXISTS branch,
+    DROP COLUMN IF EXISTS repository;
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+ALTER TABLE gateway_multiparts
+    ADD COLUMN IF NOT EXISTS repository character varying,
+    ADD COLUMN IF NOT EXISTS branch character varying COLLATE "C";
+
+CREATE INDEX IF NOT EXISTS gateway_multiparts_repository_idx
+    ON gateway_multiparts (repository, (branch || '/' || path), upload_id);
+
+CREATE TABLE IF NOT EXISTS gateway_multipart_parts (
+    upload_id character varying NOT NULL,
+    part_number integer NOT NULL,
+    etag character varying NOT NULL,
+    size bigint NOT NULL,
+    last_modified timestamp with time zone DEFAULT now() NOT NULL
+);
+
+ALTER TABLE ONLY gateway_multipart_parts
+    ADD CONSTRAINT gateway_multipart_parts_pk PRIMARY KEY (upload_id, part_number);
+
+ALTER TABLE ONLY gateway_multipart_parts
+    ADD CONSTRAINT gateway_multipart_parts_upload_id_fk FOREIGN KEY (upload_id) REFERENCES gateway_multiparts (upload_id) ON DELETE CASCADE;
+
+COMMIT;
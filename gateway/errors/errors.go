@@ -73,6 +73,7 @@ const (
 	ErrInvalidCopyDest
 	ErrInvalidPolicyDocument
 	ErrInvalidObjectState
+	ErrInvalidTag
 	ErrMalformedXML
 	ErrMissingContentLength
 	ErrMissingContentMD5
@@ -169,6 +170,8 @@ const (
 
 	// Lakefs errors
 	ERRLakeFSNotSupported
+	ERRLakeFSMissingStorageNamespace
+	ERRLakeFSInvalidStorageNamespace
 )
 
 type errorCodeMap map[APIErrorCode]APIError
@@ -296,6 +299,11 @@ var Codes = errorCodeMap{
 		Description:    "The requested range is not satisfiable",
 		HTTPStatusCode: http.StatusRequestedRangeNotSatisfiable,
 	},
+	ErrInvalidTag: {
+		Code:           "InvalidTag",
+		Description:    "The tag provided was not a valid tag.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrMalformedXML: {
 		Code:           "MalformedXML",
 		Description:    "The XML you provided was not well-formed or did not validate against our published schema.",
@@ -745,4 +753,14 @@ var Codes = errorCodeMap{
 		Description:    "This operation is not supported in LakeFS",
 		HTTPStatusCode: http.StatusMethodNotAllowed,
 	},
+	ERRLakeFSMissingStorageNamespace: {
+		Code:           "ERRLakeFSMissingStorageNamespace",
+		Description:    "Creating a repository requires a storage namespace, in the X-Lakefs-Storage-Namespace header or configured for the S3 gateway",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ERRLakeFSInvalidStorageNamespace: {
+		Code:           "ERRLakeFSInvalidStorageNamespace",
+		Description:    "Could not access the storage namespace of the repository",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 }
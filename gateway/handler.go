@@ -14,6 +14,7 @@ import (
 
 	"github.com/treeverse/lakefs/catalog/mvcc"
 
+	"github.com/treeverse/lakefs/audit"
 	"github.com/treeverse/lakefs/auth"
 	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/catalog"
@@ -50,6 +51,16 @@ type ServerContext struct {
 	stats             stats.Collector
 	dedupCleaner      *dedup.Cleaner
 	fallbackProxy     *gohttputil.ReverseProxy
+	bucketConfig      BucketConfig
+}
+
+// BucketConfig configures the repositories that CreateBucket creates.
+type BucketConfig struct {
+	// StorageNamespaceTemplate is the storage namespace of a repository created without
+	// the storage namespace header, with operations.RepositoryPlaceholder replaced by
+	// the name of the repository.
+	StorageNamespaceTemplate string
+	DefaultBranch            string
 }
 
 const operationIDNotFound = "not_found_operation"
@@ -66,6 +77,7 @@ func (c *ServerContext) WithContext(ctx context.Context) *ServerContext {
 		stats:             c.stats,
 		dedupCleaner:      c.dedupCleaner,
 		fallbackProxy:     c.fallbackProxy,
+		bucketConfig:      c.bucketConfig,
 	}
 }
 
@@ -78,7 +90,9 @@ func NewHandler(
 	bareDomain string,
 	stats stats.Collector,
 	dedupCleaner *dedup.Cleaner,
+	auditService audit.Service,
 	fallbackURL *url.URL,
+	bucketConfig BucketConfig,
 ) http.Handler {
 	var fallbackProxy *gohttputil.ReverseProxy
 	if fallbackURL != nil {
@@ -95,6 +109,7 @@ func NewHandler(
 		stats:             stats,
 		dedupCleaner:      dedupCleaner,
 		fallbackProxy:     fallbackProxy,
+		bucketConfig:      bucketConfig,
 	}
 
 	// setup routes
@@ -106,7 +121,8 @@ func NewHandler(
 		ServerErrorHandler: nil,
 	}
 	h = simulator.RegisterRecorder(httputil.LoggingMiddleware(
-		"X-Amz-Request-Id", logging.Fields{"service_name": "s3_gateway"}, h,
+		"X-Amz-Request-Id", logging.Fields{"service_name": "s3_gateway"},
+		audit.Middleware(auditService, audit.SourceS3Gateway, h),
 	), authService, region, bareDomain)
 
 	logging.Default().WithFields(logging.Fields{
@@ -126,7 +142,7 @@ func getAPIErrOrDefault(err error, defaultAPIErr gatewayerrors.APIErrorCode) gat
 	}
 }
 
-func authenticateOperation(s *ServerContext, writer http.ResponseWriter, request *http.Request, perms []permissions.Permission) *operations.AuthenticatedOperation {
+func authenticateOperation(s *ServerContext, writer http.ResponseWriter, request *http.Request, repoID, refID string, perms []permissions.Permission) *operations.AuthenticatedOperation {
 	o := &operations.Operation{
 		Request:           request,
 		ResponseWriter:    writer,
@@ -201,6 +217,7 @@ func authenticateOperation(s *ServerContext, writer http.ResponseWriter, request
 		// no special permissions required, no need to authorize (used for delete-objects, where permissions are checked separately)
 		return op
 	}
+	audit.Track(request.Context(), audit.NewEntry(op.Principal, perms, repoID, refID))
 	// authorize
 	authResp, err := s.authService.Authorize(&auth.AuthorizationRequest{
 		Username:            op.Principal,
@@ -252,7 +269,7 @@ func OperationHandler(sc *ServerContext, handler operations.AuthenticatedOperati
 			o.EncodeError(gatewayerrors.ErrAccessDenied.ToAPIErr())
 			return
 		}
-		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, perms)
+		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, "", "", perms)
 		if authOp == nil {
 			return
 		}
@@ -270,7 +287,7 @@ func RepoOperationHandler(sc *ServerContext, repoID string, handler operations.R
 			o.EncodeError(gatewayerrors.ErrAccessDenied.ToAPIErr())
 			return
 		}
-		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, perms)
+		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, repoID, "", perms)
 		if authOp == nil {
 			return
 		}
@@ -311,7 +328,7 @@ func PathOperationHandler(sc *ServerContext, repoID, refID, path string, handler
 			o.EncodeError(gatewayerrors.ErrAccessDenied.ToAPIErr())
 			return
 		}
-		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, perms)
+		authOp := authenticateOperation(sc.WithContext(request.Context()), writer, request, repoID, refID, perms)
 		if authOp == nil {
 			return
 		}
@@ -447,7 +464,7 @@ func (h *handler) servePathBased(r *http.Request) http.Handler {
 
 		// s3 allows trailing slash for bucket name
 		if ref == "" {
-			return h.repositoryBasedHandlerIfValid(r.Method, repository)
+			return h.repositoryBasedHandlerIfValid(r.Method, r.URL.Query(), repository)
 		}
 		return h.NotFoundHandler
 	}
@@ -455,7 +472,7 @@ func (h *handler) servePathBased(r *http.Request) http.Handler {
 	if parts, ok := SplitFirst(r.URL.Path, 1); ok {
 		// Paths for bare repository
 		repository := parts[0]
-		return h.repositoryBasedHandlerIfValid(r.Method, repository)
+		return h.repositoryBasedHandlerIfValid(r.Method, r.URL.Query(), repository)
 	}
 	// no repository given
 	if r.Method == http.MethodGet {
@@ -499,7 +516,7 @@ func (h *handler) serveVirtualHost(r *http.Request) http.Handler {
 		return h.NotFoundHandler
 	}
 
-	return h.repositoryBasedHandler(r.Method, repository)
+	return h.repositoryBasedHandler(r.Method, r.URL.Query(), repository)
 }
 
 func (h *handler) pathBasedHandler(method, repository, ref, path string) http.Handler {
@@ -523,20 +540,32 @@ func (h *handler) pathBasedHandler(method, repository, ref, path string) http.Ha
 	return PathOperationHandler(h.sc, repository, ref, path, handler)
 }
 
-func (h *handler) repositoryBasedHandlerIfValid(method, repository string) http.Handler {
+func (h *handler) repositoryBasedHandlerIfValid(method string, query url.Values, repository string) http.Handler {
 	if !mvcc.IsValidRepositoryName(repository) {
 		return h.NotFoundHandler
 	}
 
-	return h.repositoryBasedHandler(method, repository)
+	return h.repositoryBasedHandler(method, query, repository)
 }
 
-func (h *handler) repositoryBasedHandler(method, repository string) http.Handler {
-	var handler operations.RepoOperationHandler
-	switch method {
-	case http.MethodDelete, http.MethodPut:
+func (h *handler) repositoryBasedHandler(method string, query url.Values, repository string) http.Handler {
+	if (method == http.MethodPut || method == http.MethodDelete) && hasBucketSubresource(query) {
+		// e.g. PutBucketPolicy or DeleteBucketLifecycle, not CreateBucket or DeleteBucket
 		h.operationID = "unsupported_operation"
 		return unsupportedOperationHandler()
+	}
+	var handler operations.RepoOperationHandler
+	switch method {
+	case http.MethodPut:
+		// the repository does not exist yet, so creating it is not a repository operation
+		h.operationID = "CreateBucket"
+		return OperationHandler(h.sc, &operations.CreateBucket{
+			Repository:               repository,
+			StorageNamespaceTemplate: h.sc.bucketConfig.StorageNamespaceTemplate,
+			DefaultBranch:            h.sc.bucketConfig.DefaultBranch,
+		})
+	case http.MethodDelete:
+		handler = &operations.DeleteBucket{}
 	case http.MethodHead:
 		handler = &operations.HeadBucket{}
 	case http.MethodPost:
@@ -552,6 +581,21 @@ func (h *handler) repositoryBasedHandler(method, repository string) http.Handler
 	return RepoOperationHandler(h.sc, repository, handler)
 }
 
+// hasBucketSubresource returns true if query selects a subresource of a bucket, such as its
+// policy or tagging, rather than the bucket itself.  Presigned requests are authenticated by
+// query parameters, which select nothing.
+func hasBucketSubresource(query url.Values) bool {
+	for key := range query {
+		switch {
+		case strings.HasPrefix(strings.ToLower(key), "x-amz-"):
+		case key == "AWSAccessKeyId", key == "Signature", key == "Expires":
+		default:
+			return true
+		}
+	}
+	return false
+}
+
 func SplitFirst(pth string, parts int) ([]string, bool) {
 	pth = strings.TrimPrefix(pth, path.Separator)
 	pathParts := strings.SplitN(pth, path.Separator, parts)
@@ -6,22 +6,61 @@ import (
 	"fmt"
 	"time"
 
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/db"
 )
 
 type MultipartUpload struct {
 	UploadID        string    `db:"upload_id"`
+	Repository      string    `db:"repository"`
+	Branch          string    `db:"branch"`
 	Path            string    `db:"path"`
 	CreationDate    time.Time `db:"creation_date"`
 	PhysicalAddress string    `db:"physical_address"`
+	// Metadata of the object created when the upload completes
+	Metadata catalog.Metadata `db:"metadata"`
+}
+
+// MultipartPart is a part uploaded to a multipart upload.
+type MultipartPart struct {
+	PartNumber   int       `db:"part_number"`
+	ETag         string    `db:"etag"`
+	Size         int64     `db:"size"`
+	LastModified time.Time `db:"last_modified"`
+}
+
+// ListParams configures the uploads returned by List.  Uploads are sorted by key, their
+// branch and path joined by "/", and then by upload ID.
+type ListParams struct {
+	Repository string
+	// Prefix lists only uploads whose key starts with it.
+	Prefix string
+	// KeyMarker lists only uploads after this key, or with this key and an upload ID after
+	// UploadIDMarker.
+	KeyMarker      string
+	UploadIDMarker string
+	Limit          int
 }
 
 type Tracker interface {
-	Create(ctx context.Context, uploadID, path, physicalAddress string, creationTime time.Time) error
+	Create(ctx context.Context, multipart MultipartUpload) error
 	Get(ctx context.Context, uploadID string) (*MultipartUpload, error)
 	Delete(ctx context.Context, uploadID string) error
+	// List returns up to params.Limit uploads, and whether there are more.
+	List(ctx context.Context, params ListParams) ([]*MultipartUpload, bool, error)
+	// PutPart records part as uploaded to uploadID, replacing any part with the same number.
+	PutPart(ctx context.Context, uploadID string, part MultipartPart) error
+	// ListParts returns up to limit parts of uploadID numbered after afterPartNumber, and
+	// whether there are more.
+	ListParts(ctx context.Context, uploadID string, afterPartNumber int, limit int) ([]*MultipartPart, bool, error)
 }
 
+// uploadKeyExpr is the key of an upload, by which List sorts and filters uploads
+const uploadKeyExpr = "(branch || '/' || path)"
+
+var psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
 type tracker struct {
 	db db.Database
 }
@@ -37,14 +76,14 @@ func NewTracker(adb db.Database) Tracker {
 	}
 }
 
-func (m *tracker) Create(ctx context.Context, uploadID, path, physicalAddress string, creationTime time.Time) error {
-	if uploadID == "" {
+func (m *tracker) Create(ctx context.Context, multipart MultipartUpload) error {
+	if multipart.UploadID == "" {
 		return ErrInvalidUploadID
 	}
 	_, err := m.db.Transact(func(tx db.Tx) (interface{}, error) {
-		_, err := tx.Exec(`INSERT INTO gateway_multiparts (upload_id,path,creation_date,physical_address)
-			VALUES ($1, $2, $3, $4)`,
-			uploadID, path, creationTime, physicalAddress)
+		_, err := tx.Exec(`INSERT INTO gateway_multiparts (upload_id,repository,branch,path,creation_date,physical_address,metadata)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			multipart.UploadID, multipart.Repository, multipart.Branch, multipart.Path, multipart.CreationDate, multipart.PhysicalAddress, multipart.Metadata)
 		return nil, err
 	}, db.WithContext(ctx))
 	return err
@@ -57,7 +96,8 @@ func (m *tracker) Get(ctx context.Context, uploadID string) (*MultipartUpload, e
 	res, err := m.db.Transact(func(tx db.Tx) (interface{}, error) {
 		var m MultipartUpload
 		if err := tx.Get(&m, `
-			SELECT upload_id, path, creation_date, physical_address 
+			SELECT upload_id, COALESCE(repository, '') AS repository, COALESCE(branch, '') AS branch,
+				path, creation_date, physical_address, metadata
 			FROM gateway_multiparts
 			WHERE upload_id = $1`,
 			uploadID); err != nil {
@@ -88,3 +128,86 @@ func (m *tracker) Delete(ctx context.Context, uploadID string) error {
 	})
 	return err
 }
+
+func (m *tracker) List(ctx context.Context, params ListParams) ([]*MultipartUpload, bool, error) {
+	res, err := m.db.Transact(func(tx db.Tx) (interface{}, error) {
+		q := psql.
+			Select("upload_id", "repository", "branch", "path", "creation_date", "physical_address", "metadata").
+			From("gateway_multiparts").
+			Where(sq.Eq{"repository": params.Repository}).
+			OrderBy(uploadKeyExpr, "upload_id").
+			Limit(uint64(params.Limit) + 1)
+		if params.Prefix != "" {
+			q = q.Where(uploadKeyExpr+" LIKE ?", db.Prefix(params.Prefix))
+		}
+		if params.UploadIDMarker != "" {
+			q = q.Where(sq.Or{
+				sq.Expr(uploadKeyExpr+" > ?", params.KeyMarker),
+				sq.And{sq.Expr(uploadKeyExpr+" = ?", params.KeyMarker), sq.Gt{"upload_id": params.UploadIDMarker}},
+			})
+		} else if params.KeyMarker != "" {
+			q = q.Where(uploadKeyExpr+" > ?", params.KeyMarker)
+		}
+		query, args, err := q.ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("build query: %w", err)
+		}
+		var uploads []*MultipartUpload
+		if err := tx.Select(&uploads, query, args...); err != nil {
+			return nil, err
+		}
+		return uploads, nil
+	}, db.WithContext(ctx), db.ReadOnly())
+	if err != nil {
+		return nil, false, err
+	}
+	uploads := res.([]*MultipartUpload)
+	hasMore := len(uploads) > params.Limit
+	if hasMore {
+		uploads = uploads[:params.Limit]
+	}
+	return uploads, hasMore, nil
+}
+
+func (m *tracker) PutPart(ctx context.Context, uploadID string, part MultipartPart) error {
+	if uploadID == "" {
+		return ErrInvalidUploadID
+	}
+	_, err := m.db.Transact(func(tx db.Tx) (interface{}, error) {
+		_, err := tx.Exec(`INSERT INTO gateway_multipart_parts (upload_id,part_number,etag,size,last_modified)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (upload_id, part_number)
+			DO UPDATE SET etag = $3, size = $4, last_modified = $5`,
+			uploadID, part.PartNumber, part.ETag, part.Size, part.LastModified)
+		return nil, err
+	}, db.WithContext(ctx))
+	return err
+}
+
+func (m *tracker) ListParts(ctx context.Context, uploadID string, afterPartNumber int, limit int) ([]*MultipartPart, bool, error) {
+	if uploadID == "" {
+		return nil, false, ErrInvalidUploadID
+	}
+	res, err := m.db.Transact(func(tx db.Tx) (interface{}, error) {
+		var parts []*MultipartPart
+		if err := tx.Select(&parts, `
+			SELECT part_number, etag, size, last_modified
+			FROM gateway_multipart_parts
+			WHERE upload_id = $1 AND part_number > $2
+			ORDER BY part_number
+			LIMIT $3`,
+			uploadID, afterPartNumber, limit+1); err != nil {
+			return nil, err
+		}
+		return parts, nil
+	}, db.WithContext(ctx), db.ReadOnly())
+	if err != nil {
+		return nil, false, err
+	}
+	parts := res.([]*MultipartPart)
+	hasMore := len(parts) > limit
+	if hasMore {
+		parts = parts[:limit]
+	}
+	return parts, hasMore, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/testutil"
 )
 
@@ -21,7 +22,15 @@ func Tracker_Get(t *testing.T) {
 
 	creationTime := time.Now().Round(time.Second) // round in order to remove the monotonic clock
 	// setup test data
-	if err := tracker.Create(ctx, "upload1", "/path1", "/file1", creationTime); err != nil {
+	if err := tracker.Create(ctx, MultipartUpload{
+		UploadID:        "upload1",
+		Repository:      "repo1",
+		Branch:          "master",
+		Path:            "/path1",
+		CreationDate:    creationTime,
+		PhysicalAddress: "/file1",
+		Metadata:        catalog.Metadata{"Content-Type": "text/plain"},
+	}); err != nil {
 		t.Fatal("create multipart upload for testing", err)
 	}
 
@@ -39,9 +48,12 @@ func Tracker_Get(t *testing.T) {
 			args: args{uploadID: "upload1"},
 			want: &MultipartUpload{
 				UploadID:        "upload1",
+				Repository:      "repo1",
+				Branch:          "master",
 				Path:            "/path1",
 				CreationDate:    creationTime,
 				PhysicalAddress: "/file1",
+				Metadata:        catalog.Metadata{"Content-Type": "text/plain"},
 			},
 			wantErr: false,
 		},
@@ -77,7 +89,7 @@ func Tracker_Delete(t *testing.T) {
 	c := testTracker(t)
 
 	// setup test data
-	if err := c.Create(ctx, "uploadX", "/pathX", "/fileX", time.Now()); err != nil {
+	if err := c.Create(ctx, MultipartUpload{UploadID: "uploadX", Path: "/pathX", CreationDate: time.Now(), PhysicalAddress: "/fileX"}); err != nil {
 		t.Fatal("create multipart upload for testing", err)
 	}
 
@@ -107,7 +119,7 @@ func Tracker_Create(t *testing.T) {
 	tracker := testTracker(t)
 
 	// setup test data
-	if err := tracker.Create(ctx, "uploadX", "/pathX", "/fileX", time.Now()); err != nil {
+	if err := tracker.Create(ctx, MultipartUpload{UploadID: "uploadX", Path: "/pathX", CreationDate: time.Now(), PhysicalAddress: "/fileX"}); err != nil {
 		t.Fatal("create multipart upload for testing", err)
 	}
 
@@ -150,7 +162,12 @@ func Tracker_Create(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tracker.Create(ctx, tt.args.uploadID, tt.args.path, tt.args.physicalAddress, tt.args.creationTime)
+			err := tracker.Create(ctx, MultipartUpload{
+				UploadID:        tt.args.uploadID,
+				Path:            tt.args.path,
+				CreationDate:    tt.args.creationTime,
+				PhysicalAddress: tt.args.physicalAddress,
+			})
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -168,3 +185,114 @@ func Tracker_Create(t *testing.T) {
 		})
 	}
 }
+
+func Tracker_List(t *testing.T) {
+	ctx := context.Background()
+	tracker := testTracker(t)
+
+	// setup test data
+	uploads := []MultipartUpload{
+		{UploadID: "upload3", Repository: "repo1", Branch: "master", Path: "a/1"},
+		{UploadID: "upload1", Repository: "repo1", Branch: "master", Path: "a/1"},
+		{UploadID: "upload2", Repository: "repo1", Branch: "master", Path: "b"},
+		{UploadID: "upload4", Repository: "repo1", Branch: "feature", Path: "a/1"},
+		{UploadID: "upload5", Repository: "repo2", Branch: "master", Path: "a/1"},
+	}
+	for _, upload := range uploads {
+		upload.CreationDate = time.Now()
+		upload.PhysicalAddress = "/file-" + upload.UploadID
+		testutil.MustDo(t, "create multipart upload for testing", tracker.Create(ctx, upload))
+	}
+
+	tests := []struct {
+		name            string
+		params          ListParams
+		expectedIDs     []string
+		expectedHasMore bool
+	}{
+		{
+			name:        "all",
+			params:      ListParams{Repository: "repo1", Limit: 10},
+			expectedIDs: []string{"upload4", "upload1", "upload3", "upload2"},
+		},
+		{
+			name:            "limit",
+			params:          ListParams{Repository: "repo1", Limit: 2},
+			expectedIDs:     []string{"upload4", "upload1"},
+			expectedHasMore: true,
+		},
+		{
+			name:        "prefix",
+			params:      ListParams{Repository: "repo1", Prefix: "master/a", Limit: 10},
+			expectedIDs: []string{"upload1", "upload3"},
+		},
+		{
+			name:        "key marker",
+			params:      ListParams{Repository: "repo1", KeyMarker: "master/a/1", Limit: 10},
+			expectedIDs: []string{"upload2"},
+		},
+		{
+			name:        "upload id marker",
+			params:      ListParams{Repository: "repo1", KeyMarker: "master/a/1", UploadIDMarker: "upload1", Limit: 10},
+			expectedIDs: []string{"upload3", "upload2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hasMore, err := tracker.List(ctx, tt.params)
+			testutil.MustDo(t, "List", err)
+			var ids []string
+			for _, upload := range got {
+				ids = append(ids, upload.UploadID)
+			}
+			if !reflect.DeepEqual(ids, tt.expectedIDs) {
+				t.Errorf("List() got = %v, want %v", ids, tt.expectedIDs)
+			}
+			if hasMore != tt.expectedHasMore {
+				t.Errorf("List() hasMore = %t, want %t", hasMore, tt.expectedHasMore)
+			}
+		})
+	}
+}
+
+func Tracker_Parts(t *testing.T) {
+	ctx := context.Background()
+	tracker := testTracker(t)
+
+	// setup test data
+	if err := tracker.Create(ctx, MultipartUpload{UploadID: "uploadP", Path: "/pathP", CreationDate: time.Now(), PhysicalAddress: "/fileP"}); err != nil {
+		t.Fatal("create multipart upload for testing", err)
+	}
+	lastModified := time.Now().Round(time.Second) // round in order to remove the monotonic clock
+	for _, part := range []MultipartPart{
+		{PartNumber: 2, ETag: "etag2", Size: 20, LastModified: lastModified},
+		{PartNumber: 1, ETag: "etag1", Size: 10, LastModified: lastModified},
+		{PartNumber: 2, ETag: "etag2-again", Size: 22, LastModified: lastModified},
+		{PartNumber: 3, ETag: "etag3", Size: 30, LastModified: lastModified},
+	} {
+		testutil.MustDo(t, "PutPart", tracker.PutPart(ctx, "uploadP", part))
+	}
+
+	parts, hasMore, err := tracker.ListParts(ctx, "uploadP", 0, 2)
+	testutil.MustDo(t, "ListParts", err)
+	expected := []*MultipartPart{
+		{PartNumber: 1, ETag: "etag1", Size: 10, LastModified: lastModified},
+		{PartNumber: 2, ETag: "etag2-again", Size: 22, LastModified: lastModified},
+	}
+	if !reflect.DeepEqual(parts, expected) || !hasMore {
+		t.Errorf("ListParts() got = %v (more: %t), want %v with more", parts, hasMore, expected)
+	}
+	parts, hasMore, err = tracker.ListParts(ctx, "uploadP", 2, 2)
+	testutil.MustDo(t, "ListParts after part 2", err)
+	if len(parts) != 1 || parts[0].PartNumber != 3 || hasMore {
+		t.Errorf("ListParts() after part 2 got = %v (more: %t), want part 3", parts, hasMore)
+	}
+
+	// parts are deleted with their upload
+	testutil.MustDo(t, "Delete", tracker.Delete(ctx, "uploadP"))
+	parts, _, err = tracker.ListParts(ctx, "uploadP", 0, 10)
+	testutil.MustDo(t, "ListParts of deleted upload", err)
+	if len(parts) != 0 {
+		t.Errorf("ListParts() of deleted upload got = %v, want none", parts)
+	}
+}
@@ -0,0 +1,88 @@
+package operations
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/db"
+	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/logging"
+	"github.com/treeverse/lakefs/permissions"
+)
+
+const (
+	// StorageNamespaceHeader sets the storage namespace of a repository created by CreateBucket.
+	StorageNamespaceHeader = "X-Lakefs-Storage-Namespace"
+	// RepositoryPlaceholder is replaced by the name of the repository in storage namespace
+	// templates.
+	RepositoryPlaceholder = "{repository}"
+)
+
+// CreateBucket creates a repository.  Its storage namespace is given by StorageNamespaceHeader,
+// or else by StorageNamespaceTemplate.
+type CreateBucket struct {
+	Repository               string
+	StorageNamespaceTemplate string
+	DefaultBranch            string
+}
+
+func (controller *CreateBucket) RequiredPermissions(_ *http.Request) ([]permissions.Permission, error) {
+	return []permissions.Permission{
+		{
+			Action:   permissions.CreateRepositoryAction,
+			Resource: permissions.RepoArn(controller.Repository),
+		},
+	}, nil
+}
+
+func (controller *CreateBucket) storageNamespace(header http.Header) string {
+	if storageNamespace := header.Get(StorageNamespaceHeader); storageNamespace != "" {
+		return storageNamespace
+	}
+	if controller.StorageNamespaceTemplate == "" {
+		return ""
+	}
+	return strings.ReplaceAll(controller.StorageNamespaceTemplate, RepositoryPlaceholder, controller.Repository)
+}
+
+func (controller *CreateBucket) Handle(o *AuthenticatedOperation) {
+	o.Incr("create_repo")
+	storageNamespace := controller.storageNamespace(o.Request.Header)
+	o.AddLogFields(logging.Fields{
+		"repository":        controller.Repository,
+		"storage_namespace": storageNamespace,
+	})
+	if storageNamespace == "" {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ERRLakeFSMissingStorageNamespace))
+		return
+	}
+
+	_, err := o.Cataloger.GetRepository(o.Context(), controller.Repository)
+	switch {
+	case err == nil:
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBucketAlreadyOwnedByYou))
+		return
+	case !errors.Is(err, db.ErrNotFound) && !errors.Is(err, graveler.ErrNotFound):
+		o.Log().WithError(err).Error("could not check whether repository exists")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+
+	err = block.EnsureStorageNamespaceRW(o.BlockStore, storageNamespace)
+	if err != nil {
+		o.Log().WithError(err).Warn("could not access storage namespace")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ERRLakeFSInvalidStorageNamespace))
+		return
+	}
+	_, err = o.Cataloger.CreateRepository(o.Context(), controller.Repository, storageNamespace, controller.DefaultBranch)
+	if err != nil {
+		o.Log().WithError(err).Error("could not create repository")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	o.SetHeader("Location", "/"+controller.Repository)
+	o.ResponseWriter.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,29 @@
+package operations
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCreateBucketStorageNamespace(t *testing.T) {
+	cases := []struct {
+		name     string
+		template string
+		header   http.Header
+		expected string
+	}{
+		{"none", "", http.Header{}, ""},
+		{"template", "s3://bucket/lakefs/{repository}", http.Header{}, "s3://bucket/lakefs/repo1"},
+		{"template without placeholder", "s3://bucket/lakefs", http.Header{}, "s3://bucket/lakefs"},
+		{"header", "", http.Header{StorageNamespaceHeader: []string{"s3://other/repo"}}, "s3://other/repo"},
+		{"header overrides template", "s3://bucket/{repository}", http.Header{StorageNamespaceHeader: []string{"s3://other/repo"}}, "s3://other/repo"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			controller := &CreateBucket{Repository: "repo1", StorageNamespaceTemplate: c.template}
+			if got := controller.storageNamespace(c.header); got != c.expected {
+				t.Errorf("expected storage namespace %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package operations
+
+import (
+	"net/http"
+
+	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/permissions"
+)
+
+type DeleteBucket struct{}
+
+func (controller *DeleteBucket) RequiredPermissions(_ *http.Request, repoID string) ([]permissions.Permission, error) {
+	return []permissions.Permission{
+		{
+			Action:   permissions.DeleteRepositoryAction,
+			Resource: permissions.RepoArn(repoID),
+		},
+	}, nil
+}
+
+// Handle deletes the repository if none of its branches has any objects, committed or not.
+func (controller *DeleteBucket) Handle(o *RepoOperation) {
+	o.Incr("delete_repo")
+	empty, err := repositoryIsEmpty(o)
+	if err != nil {
+		o.Log().WithError(err).Error("could not check whether repository is empty")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	if !empty {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBucketNotEmpty))
+		return
+	}
+	err = o.Cataloger.DeleteRepository(o.Context(), o.Repository.Name)
+	if err != nil {
+		o.Log().WithError(err).Error("could not delete repository")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	o.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func repositoryIsEmpty(o *RepoOperation) (bool, error) {
+	after := ""
+	for {
+		branches, hasMore, err := o.Cataloger.ListBranches(o.Context(), o.Repository.Name, "", -1, after)
+		if err != nil {
+			return false, err
+		}
+		for _, branch := range branches {
+			entries, _, err := o.Cataloger.ListEntries(o.Context(), o.Repository.Name, branch.Name, "", "", "", 1)
+			if err != nil {
+				return false, err
+			}
+			if len(entries) > 0 {
+				return false, nil
+			}
+		}
+		if !hasMore || len(branches) == 0 {
+			return true, nil
+		}
+		after = branches[len(branches)-1].Name
+	}
+}
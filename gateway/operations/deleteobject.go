@@ -14,7 +14,16 @@ import (
 
 type DeleteObject struct{}
 
-func (controller *DeleteObject) RequiredPermissions(_ *http.Request, repoID, _, path string) ([]permissions.Permission, error) {
+func (controller *DeleteObject) RequiredPermissions(request *http.Request, repoID, _, path string) ([]permissions.Permission, error) {
+	if _, exists := request.URL.Query()["tagging"]; exists {
+		// removing the tags of an object changes it rather than deleting it
+		return []permissions.Permission{
+			{
+				Action:   permissions.WriteObjectAction,
+				Resource: permissions.ObjectArn(repoID, path),
+			},
+		}, nil
+	}
 	return []permissions.Permission{
 		{
 			Action:   permissions.DeleteObjectAction,
@@ -28,12 +37,22 @@ func (controller *DeleteObject) HandleAbortMultipartUpload(o *PathOperation) {
 	query := o.Request.URL.Query()
 	uploadID := query.Get(QueryParamUploadID)
 	o.AddLogFields(logging.Fields{"upload_id": uploadID})
-	err := o.BlockStore.AbortMultiPartUpload(block.ObjectPointer{StorageNamespace: o.Repository.StorageNamespace, Identifier: o.Path}, uploadID)
+	multiPart, err := getMultipartUpload(o, uploadID)
+	if err != nil {
+		o.Log().WithError(err).Error("could not read multipart record")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(multipartErrorCode(err)))
+		return
+	}
+	err = o.BlockStore.AbortMultiPartUpload(block.ObjectPointer{StorageNamespace: o.Repository.StorageNamespace, Identifier: multiPart.PhysicalAddress}, uploadID)
 	if err != nil {
 		o.Log().WithError(err).Error("could not abort multipart upload")
 		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
 		return
 	}
+	err = o.MultipartsTracker.Delete(o.Context(), uploadID)
+	if err != nil {
+		o.Log().WithError(err).Warn("could not delete multipart record")
+	}
 	// done.
 	o.ResponseWriter.WriteHeader(http.StatusNoContent)
 }
@@ -46,6 +65,10 @@ func (controller *DeleteObject) Handle(o *PathOperation) {
 		controller.HandleAbortMultipartUpload(o)
 		return
 	}
+	if _, exists := query["tagging"]; exists {
+		controller.HandleDeleteTagging(o)
+		return
+	}
 
 	o.Incr("delete_object")
 	lg := o.Log().WithField("key", o.Path)
@@ -55,7 +78,7 @@ func (controller *DeleteObject) Handle(o *PathOperation) {
 		lg.WithError(err).Debug("could not delete object, it doesn't exist")
 	case err != nil:
 		lg.WithError(err).Error("could not delete object")
-		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(writeErrorCode(err, gatewayerrors.ErrInternalError)))
 		return
 	default:
 		lg.Debug("object set for deletion")
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/treeverse/lakefs/audit"
 	"github.com/treeverse/lakefs/auth"
+	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/db"
 	gerrors "github.com/treeverse/lakefs/gateway/errors"
 	"github.com/treeverse/lakefs/gateway/path"
@@ -40,14 +42,18 @@ func (controller *DeleteObjects) Handle(o *RepoOperation) {
 			continue
 		}
 		// authorize this object deletion
-		authResp, err := o.Auth.Authorize(&auth.AuthorizationRequest{
-			Username: o.Principal,
-			RequiredPermissions: []permissions.Permission{
-				{
-					Action:   permissions.DeleteObjectAction,
-					Resource: permissions.ObjectArn(o.Repository.Name, resolvedPath.Path),
-				},
+		perms := []permissions.Permission{
+			{
+				Action:   permissions.DeleteObjectAction,
+				Resource: permissions.ObjectArn(o.Repository.Name, resolvedPath.Path),
 			},
+		}
+		auditEntry := audit.NewEntry(o.Principal, perms, o.Repository.Name, resolvedPath.Ref)
+		auditEntry.Result = audit.ResultFailure
+		audit.Track(o.Request.Context(), auditEntry)
+		authResp, err := o.Auth.Authorize(&auth.AuthorizationRequest{
+			Username:            o.Principal,
+			RequiredPermissions: perms,
 		})
 		if err != nil || !authResp.Allowed {
 			errs = append(errs, serde.DeleteError{
@@ -55,6 +61,7 @@ func (controller *DeleteObjects) Handle(o *RepoOperation) {
 				Key:     obj.Key,
 				Message: "Access Denied",
 			})
+			continue
 		}
 
 		lg := o.Log().WithField("key", obj.Key)
@@ -62,6 +69,14 @@ func (controller *DeleteObjects) Handle(o *RepoOperation) {
 		switch {
 		case errors.Is(err, db.ErrNotFound):
 			lg.Debug("tried to delete a non-existent object")
+		case errors.Is(err, catalog.ErrBranchProtected):
+			lg.WithError(err).Debug("delete from protected branch denied")
+			errs = append(errs, serde.DeleteError{
+				Code:    "AccessDenied",
+				Key:     obj.Key,
+				Message: "Access Denied",
+			})
+			continue
 		case err != nil:
 			lg.WithError(err).Error("failed deleting object")
 			errs = append(errs, serde.DeleteError{
@@ -73,6 +88,7 @@ func (controller *DeleteObjects) Handle(o *RepoOperation) {
 		default:
 			lg.Debug("object set for deletion")
 		}
+		auditEntry.Result = audit.ResultSuccess
 		if !req.Quiet {
 			responses = append(responses, serde.Deleted{Key: obj.Key})
 		}
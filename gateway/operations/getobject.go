@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/treeverse/lakefs/block"
@@ -12,11 +13,16 @@ import (
 	"github.com/treeverse/lakefs/db"
 	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
 	ghttp "github.com/treeverse/lakefs/gateway/http"
+	"github.com/treeverse/lakefs/gateway/path"
 	"github.com/treeverse/lakefs/gateway/serde"
+	"github.com/treeverse/lakefs/graveler"
 	"github.com/treeverse/lakefs/httputil"
+	"github.com/treeverse/lakefs/logging"
 	"github.com/treeverse/lakefs/permissions"
 )
 
+const ListPartsMaxParts = 1000
+
 type GetObject struct{}
 
 func (controller *GetObject) RequiredPermissions(_ *http.Request, repoID, _, path string) ([]permissions.Permission, error) {
@@ -37,19 +43,31 @@ func (controller *GetObject) Handle(o *PathOperation) {
 	}
 
 	if _, exists := query["tagging"]; exists {
-		o.EncodeResponse(serde.Tagging{}, http.StatusOK)
+		controller.HandleGetTagging(o)
+		return
+	}
+
+	if _, exists := query[QueryParamUploadID]; exists {
+		controller.HandleListParts(o)
+		return
+	}
+
+	reference, versionID, err := objectReference(o)
+	if err != nil {
+		o.Log().WithError(err).WithField("version_id", versionID).Debug("could not find object version")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchVersion))
 		return
 	}
 
 	beforeMeta := time.Now()
-	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{})
+	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, reference, o.Path, catalog.GetEntryParams{})
 	metaTook := time.Since(beforeMeta)
 	o.Log().
 		WithField("took", metaTook).
 		WithError(err).
 		Debug("metadata operation to retrieve object done")
 
-	if errors.Is(err, db.ErrNotFound) {
+	if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
 		// TODO: create distinction between missing repo & missing key
 		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchKey))
 		return
@@ -65,6 +83,12 @@ func (controller *GetObject) Handle(o *PathOperation) {
 	o.SetHeader("Last-Modified", httputil.HeaderTimestamp(entry.CreationDate))
 	o.SetHeader("ETag", httputil.ETag(entry.Checksum))
 	o.SetHeader("Accept-Ranges", "bytes")
+	if versionID != "" {
+		o.SetHeader(versionIDHeader, versionID)
+	}
+	if !checkReadPreconditions(o, entry) {
+		return
+	}
 	// TODO: the rest of https://docs.aws.amazon.com/en_pv/AmazonS3/latest/API/API_GetObject.html
 
 	// range query
@@ -99,11 +123,69 @@ func (controller *GetObject) Handle(o *PathOperation) {
 	if rng.StartOffset != -1 {
 		o.SetHeader("Content-Range", fmt.Sprintf("bytes %d-%d/%d", rng.StartOffset, rng.EndOffset, entry.Size))
 	}
-	// Delete the default content-type header so http.Server will detect it from contents
-	// TODO(ariels): After/if we add content-type support to adapter, use *that*.
-	o.DeleteHeader("Content-Type")
+	amzMetaWriteHeaders(o, entry.Metadata)
 	_, err = io.Copy(o.ResponseWriter, data)
 	if err != nil {
 		o.Log().WithError(err).Error("could not write response body for object")
 	}
 }
+
+func (controller *GetObject) HandleListParts(o *PathOperation) {
+	o.Incr("list_mpu_parts")
+	query := o.Request.URL.Query()
+	uploadID := query.Get(QueryParamUploadID)
+	o.AddLogFields(logging.Fields{"upload_id": uploadID})
+
+	partNumberMarker := 0
+	if marker := query.Get("part-number-marker"); marker != "" {
+		var err error
+		partNumberMarker, err = strconv.Atoi(marker)
+		if err != nil {
+			o.Log().WithError(err).Error("invalid part number marker")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidPartNumberMarker))
+			return
+		}
+	}
+	maxParts := ListPartsMaxParts
+	if maxPartsParam := query.Get("max-parts"); maxPartsParam != "" {
+		parsed, err := strconv.Atoi(maxPartsParam)
+		if err == nil && parsed >= 0 && parsed < maxParts {
+			maxParts = parsed
+		}
+	}
+
+	_, err := getMultipartUpload(o, uploadID)
+	if err != nil {
+		o.Log().WithError(err).Error("could not read multipart record")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(multipartErrorCode(err)))
+		return
+	}
+	parts, hasMore, err := o.MultipartsTracker.ListParts(o.Context(), uploadID, partNumberMarker, maxParts)
+	if err != nil {
+		o.Log().WithError(err).Error("could not list multipart upload parts")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	resp := serde.ListPartsResult{
+		Bucket:           o.Repository.Name,
+		Key:              path.WithRef(o.Path, o.Reference),
+		UploadID:         uploadID,
+		PartNumberMarker: partNumberMarker,
+		MaxParts:         maxParts,
+		StorageClass:     "STANDARD",
+		Part:             make([]serde.Part, 0, len(parts)),
+	}
+	for _, part := range parts {
+		resp.Part = append(resp.Part, serde.Part{
+			PartNumber:   part.PartNumber,
+			LastModified: serde.Timestamp(part.LastModified),
+			ETag:         part.ETag,
+			Size:         part.Size,
+		})
+	}
+	if hasMore {
+		resp.IsTruncated = true
+		resp.NextPartNumberMarker = parts[len(parts)-1].PartNumber
+	}
+	o.EncodeResponse(resp, http.StatusOK)
+}
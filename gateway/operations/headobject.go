@@ -8,6 +8,7 @@ import (
 	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/db"
 	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/graveler"
 	"github.com/treeverse/lakefs/httputil"
 	"github.com/treeverse/lakefs/permissions"
 )
@@ -25,8 +26,14 @@ func (controller *HeadObject) RequiredPermissions(request *http.Request, repoID,
 
 func (controller *HeadObject) Handle(o *PathOperation) {
 	o.Incr("stat_object")
-	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{ReturnExpired: true})
-	if errors.Is(err, db.ErrNotFound) {
+	reference, versionID, err := objectReference(o)
+	if err != nil {
+		o.Log().WithError(err).WithField("version_id", versionID).Debug("could not find object version")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchVersion))
+		return
+	}
+	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, reference, o.Path, catalog.GetEntryParams{ReturnExpired: true})
+	if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
 		// TODO: create distinction between missing repo & missing key
 		o.Log().Debug("path not found")
 		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchKey))
@@ -44,11 +51,14 @@ func (controller *HeadObject) Handle(o *PathOperation) {
 	}
 
 	o.SetHeader("Accept-Ranges", "bytes")
+	if versionID != "" {
+		o.SetHeader(versionIDHeader, versionID)
+	}
 	o.SetHeader("Last-Modified", httputil.HeaderTimestamp(entry.CreationDate))
 	o.SetHeader("ETag", httputil.ETag(entry.Checksum))
+	if !checkReadPreconditions(o, entry) {
+		return
+	}
 	o.SetHeader("Content-Length", fmt.Sprintf("%d", entry.Size))
-
-	// Delete the default content-type header so http.Server will detect it from contents
-	// TODO(ariels): After/if we add content-type support to adapter, use *that*.
-	o.DeleteHeader("Content-Type")
+	amzMetaWriteHeaders(o, entry.Metadata)
 }
@@ -0,0 +1,77 @@
+package operations
+
+import (
+	"net/http"
+	"strconv"
+
+	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/gateway/multiparts"
+	"github.com/treeverse/lakefs/gateway/path"
+	"github.com/treeverse/lakefs/gateway/serde"
+	"github.com/treeverse/lakefs/logging"
+)
+
+const ListMultipartUploadsMaxUploads = 1000
+
+// ListMultipartUploads handles ListMultipartUploads.  The key of each upload is its branch and
+// path, as with all object keys.
+func (controller *ListObjects) ListMultipartUploads(o *RepoOperation) {
+	o.Incr("list_mpu")
+	o.AddLogFields(logging.Fields{
+		"list_type": "multipart_uploads",
+	})
+	params := o.Request.URL.Query()
+	if params.Get("delimiter") != "" {
+		// listing uploads by delimiter is not supported
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNotImplemented))
+		return
+	}
+	maxUploads := ListMultipartUploadsMaxUploads
+	if maxUploadsParam := params.Get("max-uploads"); maxUploadsParam != "" {
+		parsed, err := strconv.Atoi(maxUploadsParam)
+		if err == nil && parsed >= 0 && parsed < maxUploads {
+			maxUploads = parsed
+		}
+	}
+	keyMarker := params.Get("key-marker")
+	uploadIDMarker := params.Get("upload-id-marker")
+
+	uploads, hasMore, err := o.MultipartsTracker.List(o.Context(), multiparts.ListParams{
+		Repository:     o.Repository.Name,
+		Prefix:         params.Get("prefix"),
+		KeyMarker:      keyMarker,
+		UploadIDMarker: uploadIDMarker,
+		Limit:          maxUploads,
+	})
+	if err != nil {
+		o.Log().WithError(err).Error("could not list multipart uploads")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+
+	resp := serde.ListMultipartUploadsResult{
+		Bucket:         o.Repository.Name,
+		KeyMarker:      keyMarker,
+		UploadIDMarker: uploadIDMarker,
+		Prefix:         params.Get("prefix"),
+		MaxUploads:     maxUploads,
+		Upload:         make([]serde.Upload, 0, len(uploads)),
+	}
+	for _, upload := range uploads {
+		resp.Upload = append(resp.Upload, serde.Upload{
+			Key:          path.WithRef(upload.Path, upload.Branch),
+			UploadID:     upload.UploadID,
+			Initiated:    serde.Timestamp(upload.CreationDate),
+			StorageClass: "STANDARD",
+		})
+	}
+	if hasMore {
+		resp.IsTruncated = true
+		if len(resp.Upload) > 0 {
+			last := resp.Upload[len(resp.Upload)-1]
+			resp.NextKeyMarker = last.Key
+			resp.NextUploadIDMarker = last.UploadID
+		}
+	}
+	o.EncodeResponse(resp, http.StatusOK)
+}
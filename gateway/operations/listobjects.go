@@ -377,6 +377,18 @@ func (controller *ListObjects) Handle(o *RepoOperation) {
 		return
 	}
 
+	// handle GET /?uploads
+	if _, found := query["uploads"]; found {
+		controller.ListMultipartUploads(o)
+		return
+	}
+
+	// handle GET /?versions
+	if _, found := query["versions"]; found {
+		controller.ListVersions(o)
+		return
+	}
+
 	// handle ListObjects versions
 	listType := query.Get("list-type")
 	switch listType {
@@ -0,0 +1,326 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/db"
+	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/gateway/path"
+	"github.com/treeverse/lakefs/gateway/serde"
+	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/httputil"
+	"github.com/treeverse/lakefs/logging"
+)
+
+const (
+	listVersionsBatchSize = 1000
+
+	// listVersionsMaxCommits is the number of commits that changed the listed prefix scanned by
+	// a single request.  Versions created by older commits are not listed.
+	listVersionsMaxCommits = 1000
+)
+
+// objectVersion is a version of an object: its entry at a commit that changed it, or a delete
+// marker if that commit deleted it.  The version ID of an object version is the commit ID.
+type objectVersion struct {
+	Path         string
+	CommitID     string
+	CreationDate time.Time
+	Entry        *catalog.Entry // nil for delete markers
+}
+
+// ListVersions handles ListObjectVersions.  The versions of each object are the commits
+// reachable from the reference of the listed prefix that changed it, out of the newest
+// listVersionsMaxCommits commits that changed the prefix.
+func (controller *ListObjects) ListVersions(o *RepoOperation) {
+	o.AddLogFields(logging.Fields{
+		"list_type": "versions",
+	})
+	params := o.Request.URL.Query()
+	delimiter := params.Get("delimiter")
+	if len(delimiter) >= 1 && delimiter != path.Separator {
+		// we only support "/" as a delimiter
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+		return
+	}
+	keyMarker := params.Get("key-marker")
+	versionIDMarker := params.Get("version-id-marker")
+	maxKeys := controller.getMaxKeys(o)
+	if maxKeys < 0 || maxKeys > ListObjectMaxKeys {
+		maxKeys = ListObjectMaxKeys
+	}
+
+	prefix, err := path.ResolvePath(params.Get("prefix"))
+	if err != nil || prefix.Ref == "" {
+		// versions are commits of a reference, which must be part of the prefix
+		o.Log().
+			WithError(err).
+			WithField("path", params.Get("prefix")).
+			Error("could not resolve reference for prefix")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+		return
+	}
+
+	// collect one item more than listed to know whether the listing is truncated
+	page := &versionsPage{
+		ref:             prefix.Ref,
+		prefix:          prefix.Path,
+		delimiter:       delimiter,
+		keyMarker:       keyMarker,
+		versionIDMarker: versionIDMarker,
+		limit:           maxKeys + 1,
+	}
+	err = listObjectVersions(o.Context(), o.Cataloger, o.Repository.Name, page)
+	switch {
+	case errors.Is(err, catalog.ErrFeatureNotSupported):
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNotImplemented))
+		return
+	case errors.Is(err, db.ErrNotFound), errors.Is(err, graveler.ErrNotFound):
+		o.Log().WithError(err).WithField("ref", prefix.Ref).Debug("could not list versions of reference")
+		page.items = nil
+	case err != nil:
+		o.Log().WithError(err).WithFields(logging.Fields{
+			"ref":  prefix.Ref,
+			"path": prefix.Path,
+		}).Error("could not list object versions")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+
+	resp := serde.ListVersionsResult{
+		Name:            o.Repository.Name,
+		Prefix:          params.Get("prefix"),
+		KeyMarker:       keyMarker,
+		VersionIDMarker: versionIDMarker,
+		Delimiter:       delimiter,
+		MaxKeys:         maxKeys,
+		Version:         make([]serde.Version, 0),
+		DeleteMarker:    make([]serde.DeleteMarker, 0),
+		CommonPrefixes:  make([]serde.CommonPrefixes, 0),
+	}
+	items := page.items
+	if len(items) > maxKeys {
+		items = items[:maxKeys]
+		resp.IsTruncated = true
+		if len(items) > 0 {
+			last := items[len(items)-1]
+			resp.NextKeyMarker = last.key
+			if !last.commonPrefix {
+				resp.NextVersionIDMarker = last.version.CommitID
+			}
+		}
+	}
+	for _, item := range items {
+		v := item.version
+		switch {
+		case item.commonPrefix:
+			resp.CommonPrefixes = append(resp.CommonPrefixes, serde.CommonPrefixes{Prefix: item.key})
+		case v.Entry == nil:
+			resp.DeleteMarker = append(resp.DeleteMarker, serde.DeleteMarker{
+				Key:          item.key,
+				VersionID:    v.CommitID,
+				IsLatest:     item.isLatest,
+				LastModified: serde.Timestamp(v.CreationDate),
+			})
+		default:
+			resp.Version = append(resp.Version, serde.Version{
+				Key:          item.key,
+				VersionID:    v.CommitID,
+				IsLatest:     item.isLatest,
+				LastModified: serde.Timestamp(v.Entry.CreationDate),
+				ETag:         httputil.ETag(v.Entry.Checksum),
+				Size:         v.Entry.Size,
+				StorageClass: "STANDARD",
+			})
+		}
+	}
+	o.EncodeResponse(resp, http.StatusOK)
+}
+
+// versionItem is a single item of a listing of versions: a version of an object, or a common
+// prefix standing for all versions of objects under it.
+type versionItem struct {
+	key          string
+	commonPrefix bool
+	isLatest     bool
+	// seq orders versions of the same key: versions are added newest first
+	seq     int
+	version objectVersion
+}
+
+func (i versionItem) less(other versionItem) bool {
+	return i.key < other.key || (i.key == other.key && i.seq < other.seq)
+}
+
+// versionsPage collects the first limit items of a listing of versions after its markers, in
+// listing order: by key, and from newest to oldest version of each key.  Versions must be
+// added from the newest commit to the oldest, and by path within each commit.
+type versionsPage struct {
+	ref             string
+	prefix          string
+	delimiter       string
+	keyMarker       string
+	versionIDMarker string
+	limit           int
+
+	items []versionItem
+	seq   int
+	// passedVersionMarker is set once the version of keyMarker at versionIDMarker was added
+	passedVersionMarker bool
+}
+
+// after returns the path after which versions may be on the page
+func (p *versionsPage) after() string {
+	refPrefix := p.ref + path.Separator
+	if p.versionIDMarker != "" || !strings.HasPrefix(p.keyMarker, refPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(p.keyMarker, refPrefix)
+}
+
+// add adds v to the page if it is listed after the markers and within the limit.  Returns
+// false if v, and every version of a later path, is past the end of the page.
+func (p *versionsPage) add(v objectVersion) bool {
+	item := versionItem{key: path.WithRef(v.Path, p.ref), seq: p.seq, version: v, isLatest: true}
+	p.seq++
+	if p.delimiter != "" {
+		if idx := strings.Index(strings.TrimPrefix(v.Path, p.prefix), p.delimiter); idx >= 0 {
+			item.key = path.WithRef(v.Path[:len(p.prefix)+idx+len(p.delimiter)], p.ref)
+			item.commonPrefix = true
+		}
+	}
+	if len(p.items) >= p.limit && !item.less(p.items[len(p.items)-1]) {
+		return false
+	}
+
+	// skip items listed on previous pages.  A keyMarker ending with the delimiter is a common
+	// prefix listed on a previous page, so all versions under it are skipped.
+	if p.keyMarker != "" {
+		switch {
+		case item.key < p.keyMarker:
+			return true
+		case item.key == p.keyMarker && (p.versionIDMarker == "" || item.commonPrefix):
+			return true
+		case item.key == p.keyMarker:
+			if !p.passedVersionMarker {
+				p.passedVersionMarker = v.CommitID == p.versionIDMarker
+				return true
+			}
+			item.isLatest = false
+		case strings.HasSuffix(p.keyMarker, path.Separator) && strings.HasPrefix(item.key, p.keyMarker):
+			return true
+		}
+	}
+
+	idx := sort.Search(len(p.items), func(i int) bool { return !p.items[i].less(item) })
+	if idx > 0 && p.items[idx-1].key == item.key {
+		if item.commonPrefix {
+			return true
+		}
+		item.isLatest = false
+	}
+	p.items = append(p.items, versionItem{})
+	copy(p.items[idx+1:], p.items[idx:])
+	p.items[idx] = item
+	if len(p.items) > p.limit {
+		p.items = p.items[:p.limit]
+	}
+	return true
+}
+
+// listObjectVersions adds to page the versions of objects under the prefix of page created by
+// the newest listVersionsMaxCommits commits of its reference that changed the prefix.
+func listObjectVersions(ctx context.Context, cataloger catalog.Cataloger, repository string, page *versionsPage) error {
+	after := ""
+	for scanned := 0; scanned < listVersionsMaxCommits; {
+		limit := listVersionsBatchSize
+		if left := listVersionsMaxCommits - scanned; left < limit {
+			limit = left
+		}
+		commits, hasMore, err := cataloger.ListCommitsByPath(ctx, repository, page.ref, page.prefix, after, limit)
+		if err != nil {
+			return err
+		}
+		for _, commit := range commits {
+			err := listCommitVersions(ctx, cataloger, repository, commit, page)
+			if err != nil {
+				return err
+			}
+		}
+		scanned += len(commits)
+		if !hasMore || len(commits) == 0 {
+			break
+		}
+		after = commits[len(commits)-1].Reference
+	}
+	return nil
+}
+
+// listCommitVersions adds to page the versions of objects under the prefix of page that commit
+// created: the changes from its first parent, or all of its objects if it has no parents.
+func listCommitVersions(ctx context.Context, cataloger catalog.Cataloger, repository string, commit *catalog.CommitLog, page *versionsPage) error {
+	prefix := page.prefix
+	after := page.after()
+	if len(commit.Parents) == 0 {
+		for {
+			entries, hasMore, err := cataloger.ListEntries(ctx, repository, commit.Reference, prefix, after, "", listVersionsBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				if !page.add(objectVersion{
+					Path:         entry.Path,
+					CommitID:     commit.Reference,
+					CreationDate: commit.CreationDate,
+					Entry:        entry,
+				}) {
+					return nil
+				}
+			}
+			if !hasMore || len(entries) == 0 {
+				return nil
+			}
+			after = entries[len(entries)-1].Path
+		}
+	}
+	for {
+		diffs, hasMore, err := cataloger.Diff(ctx, repository, commit.Parents[0], commit.Reference, catalog.DiffParams{
+			Limit: listVersionsBatchSize,
+			After: after,
+		})
+		if err != nil {
+			return err
+		}
+		for i := range diffs {
+			diff := diffs[i]
+			if !strings.HasPrefix(diff.Path, prefix) {
+				if diff.Path > prefix {
+					// differences are sorted, none of the rest are under prefix
+					return nil
+				}
+				continue
+			}
+			version := objectVersion{
+				Path:         diff.Path,
+				CommitID:     commit.Reference,
+				CreationDate: commit.CreationDate,
+			}
+			if diff.Type != catalog.DifferenceTypeRemoved {
+				version.Entry = &diff.Entry
+			}
+			if !page.add(version) {
+				return nil
+			}
+		}
+		if !hasMore || len(diffs) == 0 {
+			return nil
+		}
+		after = diffs[len(diffs)-1].Path
+	}
+}
@@ -0,0 +1,146 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+// addVersions adds to page the versions created by commits c3, c2 and c1, newest first
+func addVersions(page *versionsPage) {
+	commits := []struct {
+		id    string
+		paths []string
+	}{
+		{"c3", []string{"a/1", "b"}},
+		{"c2", []string{"a/2", "b"}},
+		{"c1", []string{"a/1", "b", "c"}},
+	}
+	for _, commit := range commits {
+		for _, p := range commit.paths {
+			if !page.add(objectVersion{Path: p, CommitID: commit.id}) {
+				break
+			}
+		}
+	}
+}
+
+type listedVersion struct {
+	Key      string
+	CommitID string
+	IsLatest bool
+}
+
+func pageVersions(page *versionsPage) []listedVersion {
+	listed := make([]listedVersion, 0, len(page.items))
+	for _, item := range page.items {
+		v := listedVersion{Key: item.key, IsLatest: item.isLatest}
+		if !item.commonPrefix {
+			v.CommitID = item.version.CommitID
+		}
+		listed = append(listed, v)
+	}
+	return listed
+}
+
+func TestVersionsPage(t *testing.T) {
+	cases := []struct {
+		name     string
+		limit    int
+		expected []listedVersion
+	}{
+		{"all", 10, []listedVersion{
+			{"master/a/1", "c3", true},
+			{"master/a/1", "c1", false},
+			{"master/a/2", "c2", true},
+			{"master/b", "c3", true},
+			{"master/b", "c2", false},
+			{"master/b", "c1", false},
+			{"master/c", "c1", true},
+		}},
+		{"limit", 4, []listedVersion{
+			{"master/a/1", "c3", true},
+			{"master/a/1", "c1", false},
+			{"master/a/2", "c2", true},
+			{"master/b", "c3", true},
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			page := &versionsPage{ref: "master", limit: c.limit}
+			addVersions(page)
+			if diff := deep.Equal(pageVersions(page), c.expected); diff != nil {
+				t.Errorf("unexpected versions: %s", diff)
+			}
+		})
+	}
+}
+
+func TestVersionsPage_Delimiter(t *testing.T) {
+	page := &versionsPage{ref: "master", delimiter: "/", limit: 10}
+	addVersions(page)
+	expected := []listedVersion{
+		{"master/a/", "", true},
+		{"master/b", "c3", true},
+		{"master/b", "c2", false},
+		{"master/b", "c1", false},
+		{"master/c", "c1", true},
+	}
+	if diff := deep.Equal(pageVersions(page), expected); diff != nil {
+		t.Errorf("unexpected versions: %s", diff)
+	}
+}
+
+func TestVersionsPage_Markers(t *testing.T) {
+	cases := []struct {
+		name            string
+		keyMarker       string
+		versionIDMarker string
+		expected        *listedVersion
+	}{
+		{"no marker", "", "", &listedVersion{"master/a/1", "c3", true}},
+		{"key marker", "master/a/1", "", &listedVersion{"master/a/2", "c2", true}},
+		{"key marker between keys", "master/a/10", "", &listedVersion{"master/a/2", "c2", true}},
+		{"version marker", "master/b", "c3", &listedVersion{"master/b", "c2", false}},
+		{"last version marker", "master/b", "c1", &listedVersion{"master/c", "c1", true}},
+		{"unknown version marker", "master/b", "c4", &listedVersion{"master/c", "c1", true}},
+		{"common prefix marker", "master/a/", "", &listedVersion{"master/b", "c3", true}},
+		{"marker after all keys", "master/d", "", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			page := &versionsPage{ref: "master", keyMarker: c.keyMarker, versionIDMarker: c.versionIDMarker, limit: 1}
+			addVersions(page)
+			listed := pageVersions(page)
+			var first *listedVersion
+			if len(listed) > 0 {
+				first = &listed[0]
+			}
+			if diff := deep.Equal(first, c.expected); diff != nil {
+				t.Errorf("unexpected first version: %s", diff)
+			}
+		})
+	}
+}
+
+func TestVersionsPage_After(t *testing.T) {
+	cases := []struct {
+		name            string
+		keyMarker       string
+		versionIDMarker string
+		expected        string
+	}{
+		{"no marker", "", "", ""},
+		{"key marker", "master/a/1", "", "a/1"},
+		{"version marker", "master/a/1", "c1", ""},
+		{"other ref", "main/a/1", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			page := &versionsPage{ref: "master", keyMarker: c.keyMarker, versionIDMarker: c.versionIDMarker}
+			if got := page.after(); got != c.expected {
+				t.Errorf("expected versions after %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
@@ -55,6 +55,10 @@ func (a *mockAdapter) GetProperties(_ block.ObjectPointer) (block.Properties, er
 	return block.Properties{}, errors.New("getProperties method not implemented in mock adapter")
 }
 
+func (a *mockAdapter) GetPreSignedURL(_ block.ObjectPointer, _ block.PreSignMode) (string, error) {
+	return "", block.ErrOperationNotSupported
+}
+
 func (a *mockAdapter) Remove(_ block.ObjectPointer) error {
 	return errors.New("remove method not implemented in mock adapter")
 }
@@ -69,6 +73,14 @@ func (a *mockAdapter) UploadPart(_ block.ObjectPointer, sizeBytes int64, reader
 	panic("try to upload part in mock adapter")
 }
 
+func (a *mockAdapter) UploadCopyPart(_, _ block.ObjectPointer, _ string, _ int64) (string, error) {
+	panic("try to upload copy part in mock adapter")
+}
+
+func (a *mockAdapter) UploadCopyPartRange(_, _ block.ObjectPointer, _ string, _, _, _ int64) (string, error) {
+	panic("try to upload copy part range in mock adapter")
+}
+
 func (a *mockAdapter) AbortMultiPartUpload(_ block.ObjectPointer, uploadID string) error {
 	panic("try to abort multipart in mock adapter")
 
@@ -78,6 +90,10 @@ func (a *mockAdapter) CompleteMultiPartUpload(_ block.ObjectPointer, uploadID st
 	panic("try to complete multipart in mock adapter")
 }
 
+func (a *mockAdapter) Walk(_ block.ObjectPointer, _ block.WalkFunc) error {
+	return errors.New("walk method not implemented in mock adapter")
+}
+
 func (a *mockAdapter) ValidateConfiguration(_ string) error {
 	return nil
 }
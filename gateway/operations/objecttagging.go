@@ -0,0 +1,186 @@
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/db"
+	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/gateway/serde"
+	"github.com/treeverse/lakefs/graveler"
+)
+
+const (
+	amzTaggingHeader          = "x-amz-tagging"
+	amzTaggingCountHeader     = "x-amz-tagging-count"
+	amzTaggingDirectiveHeader = "x-amz-tagging-directive"
+	taggingDirectiveReplace   = "REPLACE"
+	// amzTaggingMetadataKey is the entry metadata key that holds the tag set of an object,
+	// URL query encoded as in the x-amz-tagging header.  It cannot collide with user
+	// metadata keys, which all start with x-amz-meta-.
+	amzTaggingMetadataKey = "x-amz-tagging"
+
+	// limits on tag sets, as in S3
+	maxObjectTags     = 10
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+// encodeTagging returns tags URL query encoded, for storing in entry metadata.  Keys of tags
+// must be unique, and tags must fit in the S3 limits on tag sets.
+func encodeTagging(tags []serde.Tag) (string, error) {
+	if len(tags) > maxObjectTags {
+		return "", fmt.Errorf("%w: more than %d tags", ErrInvalidTag, maxObjectTags)
+	}
+	values := make(url.Values, len(tags))
+	for _, tag := range tags {
+		if tag.Key == "" || utf8.RuneCountInString(tag.Key) > maxTagKeyLength {
+			return "", fmt.Errorf("%w: key length of %q", ErrInvalidTag, tag.Key)
+		}
+		if utf8.RuneCountInString(tag.Value) > maxTagValueLength {
+			return "", fmt.Errorf("%w: value length of %q", ErrInvalidTag, tag.Key)
+		}
+		if _, ok := values[tag.Key]; ok {
+			return "", fmt.Errorf("%w: duplicate key %q", ErrInvalidTag, tag.Key)
+		}
+		values.Set(tag.Key, tag.Value)
+	}
+	return values.Encode(), nil
+}
+
+// parseTagging returns the tags of encoded, a URL query encoded tag set, sorted by key.
+func parseTagging(encoded string) ([]serde.Tag, error) {
+	values, err := url.ParseQuery(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTag, err)
+	}
+	tags := make([]serde.Tag, 0, len(values))
+	for k, v := range values {
+		if len(v) != 1 {
+			return nil, fmt.Errorf("%w: duplicate key %q", ErrInvalidTag, k)
+		}
+		tags = append(tags, serde.Tag{Key: k, Value: v[0]})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	return tags, nil
+}
+
+// tagsFromMetadata returns the tags stored in the metadata of an entry.
+func tagsFromMetadata(metadata catalog.Metadata) []serde.Tag {
+	encoded := metadata[amzTaggingMetadataKey]
+	if encoded == "" {
+		return nil
+	}
+	// stored tag sets were validated when written
+	tags, _ := parseTagging(encoded)
+	return tags
+}
+
+// taggingErrorCode returns the API error code to report for err, a failed update of the tags
+// of an object.
+func taggingErrorCode(err error) gatewayerrors.APIErrorCode {
+	if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
+		return gatewayerrors.ErrNoSuchKey
+	}
+	return writeErrorCode(err, gatewayerrors.ErrInternalError)
+}
+
+// setObjectTagging replaces the tag set of the object of o with encoded, or removes it if
+// encoded is empty.  The entry is rewritten only if no other write replaced it meanwhile.
+func setObjectTagging(o *PathOperation, encoded string) error {
+	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{})
+	if err != nil {
+		return err
+	}
+	metadata := make(catalog.Metadata, len(entry.Metadata)+1)
+	for k, v := range entry.Metadata {
+		metadata[k] = v
+	}
+	if encoded == "" {
+		delete(metadata, amzTaggingMetadataKey)
+	} else {
+		metadata[amzTaggingMetadataKey] = encoded
+	}
+	physicalAddress, checksum := entry.PhysicalAddress, entry.Checksum
+	entry.Metadata = metadata
+	return o.Cataloger.CreateEntry(o.Context(), o.Repository.Name, o.Reference, *entry, catalog.CreateEntryParams{
+		Condition: func(current *catalog.Entry) error {
+			if current == nil {
+				return db.ErrNotFound
+			}
+			if current.PhysicalAddress != physicalAddress || current.Checksum != checksum {
+				return ErrPreconditionFailed
+			}
+			return nil
+		},
+	})
+}
+
+// HandleGetTagging handles GetObjectTagging, returning the tags of the requested version of
+// the object.
+func (controller *GetObject) HandleGetTagging(o *PathOperation) {
+	o.Incr("get_object_tagging")
+	reference, versionID, err := objectReference(o)
+	if err != nil {
+		o.Log().WithError(err).WithField("version_id", versionID).Debug("could not find object version")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchVersion))
+		return
+	}
+	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, reference, o.Path, catalog.GetEntryParams{})
+	if errors.Is(err, db.ErrNotFound) || errors.Is(err, graveler.ErrNotFound) {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchKey))
+		return
+	}
+	if err != nil {
+		o.Log().WithError(err).Error("could not get object")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	if versionID != "" {
+		o.SetHeader(versionIDHeader, versionID)
+	}
+	o.EncodeResponse(serde.Tagging{TagSet: serde.TagSet{Tag: tagsFromMetadata(entry.Metadata)}}, http.StatusOK)
+}
+
+// HandlePutTagging handles PutObjectTagging, replacing the tags of the object on the branch.
+func (controller *PutObject) HandlePutTagging(o *PathOperation) {
+	o.Incr("put_object_tagging")
+	var tagging serde.Tagging
+	err := DecodeXMLBody(o.Request.Body, &tagging)
+	if err != nil {
+		o.Log().WithError(err).Debug("could not decode tagging")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+		return
+	}
+	encoded, err := encodeTagging(tagging.TagSet.Tag)
+	if err != nil {
+		o.Log().WithError(err).Debug("invalid tagging")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidTag))
+		return
+	}
+	err = setObjectTagging(o, encoded)
+	if err != nil {
+		o.Log().WithError(err).Debug("could not set object tagging")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(taggingErrorCode(err)))
+		return
+	}
+	o.ResponseWriter.WriteHeader(http.StatusOK)
+}
+
+// HandleDeleteTagging handles DeleteObjectTagging, removing all tags of the object on the
+// branch.
+func (controller *DeleteObject) HandleDeleteTagging(o *PathOperation) {
+	o.Incr("delete_object_tagging")
+	err := setObjectTagging(o, "")
+	if err != nil {
+		o.Log().WithError(err).Debug("could not delete object tagging")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(taggingErrorCode(err)))
+		return
+	}
+	o.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
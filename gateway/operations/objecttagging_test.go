@@ -0,0 +1,57 @@
+package operations
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/treeverse/lakefs/gateway/serde"
+)
+
+func TestEncodeTagging(t *testing.T) {
+	manyTags := make([]serde.Tag, maxObjectTags+1)
+	for i := range manyTags {
+		manyTags[i] = serde.Tag{Key: strings.Repeat("k", i+1)}
+	}
+	cases := []struct {
+		name        string
+		tags        []serde.Tag
+		expected    string
+		expectedErr error
+	}{
+		{name: "none", expected: ""},
+		{name: "tags", tags: []serde.Tag{{Key: "project", Value: "lake"}, {Key: "class", Value: "raw data"}}, expected: "class=raw+data&project=lake"},
+		{name: "empty value", tags: []serde.Tag{{Key: "flag"}}, expected: "flag="},
+		{name: "empty key", tags: []serde.Tag{{Value: "lake"}}, expectedErr: ErrInvalidTag},
+		{name: "long key", tags: []serde.Tag{{Key: strings.Repeat("k", maxTagKeyLength+1)}}, expectedErr: ErrInvalidTag},
+		{name: "long value", tags: []serde.Tag{{Key: "k", Value: strings.Repeat("v", maxTagValueLength+1)}}, expectedErr: ErrInvalidTag},
+		{name: "duplicate key", tags: []serde.Tag{{Key: "k", Value: "1"}, {Key: "k", Value: "2"}}, expectedErr: ErrInvalidTag},
+		{name: "too many tags", tags: manyTags, expectedErr: ErrInvalidTag},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := encodeTagging(c.tags)
+			if !errors.Is(err, c.expectedErr) {
+				t.Fatalf("expected error %v, got %v", c.expectedErr, err)
+			}
+			if got != c.expected {
+				t.Errorf("expected encoded tagging %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseTagging(t *testing.T) {
+	tags, err := parseTagging("project=lake&class=raw%20data")
+	if err != nil {
+		t.Fatalf("parseTagging: %s", err)
+	}
+	expected := []serde.Tag{{Key: "class", Value: "raw data"}, {Key: "project", Value: "lake"}}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("expected tags %v, got %v", expected, tags)
+	}
+	if _, err := parseTagging("a=1&a=2"); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("expected %v for duplicate keys, got %v", ErrInvalidTag, err)
+	}
+}
@@ -1,20 +1,308 @@
 package operations
 
 import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/db"
+	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/gateway/multiparts"
 	"github.com/treeverse/lakefs/logging"
 )
 
-func (o *PathOperation) finishUpload(storageNamespace, checksum, physicalAddress string, size int64) error {
+var (
+	ErrCopySourceAccessDenied = errors.New("copy source access denied")
+	ErrPreconditionFailed     = errors.New("precondition failed")
+	ErrUnsupportedCondition   = errors.New("unsupported write condition")
+	ErrInvalidTag             = errors.New("invalid tag")
+)
+
+// writeErrorCode returns the API error code to report for err, a failed write to a branch.
+// Writes blocked by branch protection are denied, writes whose conditions do not hold fail
+// their precondition, all other errors use fallback.
+func writeErrorCode(err error, fallback gatewayerrors.APIErrorCode) gatewayerrors.APIErrorCode {
+	switch {
+	case errors.Is(err, catalog.ErrBranchProtected):
+		return gatewayerrors.ErrAccessDenied
+	case errors.Is(err, ErrPreconditionFailed):
+		return gatewayerrors.ErrPreconditionFailed
+	case errors.Is(err, catalog.ErrFeatureNotSupported):
+		return gatewayerrors.ErrNotImplemented
+	}
+	return fallback
+}
+
+// copySourceErrorCode returns the API error code to report for err, a failure to read the
+// source of a copy.
+func copySourceErrorCode(err error) gatewayerrors.APIErrorCode {
+	if errors.Is(err, ErrCopySourceAccessDenied) {
+		return gatewayerrors.ErrAccessDenied
+	}
+	return gatewayerrors.ErrInvalidCopySource
+}
+
+// multipartErrorCode returns the API error code to report for err, a failure to read a
+// multipart upload from the tracker.
+func multipartErrorCode(err error) gatewayerrors.APIErrorCode {
+	if errors.Is(err, db.ErrNotFound) || errors.Is(err, multiparts.ErrInvalidUploadID) {
+		return gatewayerrors.ErrNoSuchUpload
+	}
+	return gatewayerrors.ErrInternalError
+}
+
+// getMultipartUpload returns the multipart upload uploadID of the object of o.  Uploads of
+// other objects are not found: knowing the ID of an upload grants no access to it.
+func getMultipartUpload(o *PathOperation, uploadID string) (*multiparts.MultipartUpload, error) {
+	multiPart, err := o.MultipartsTracker.Get(o.Context(), uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if multiPart.Repository != o.Repository.Name || multiPart.Branch != o.Reference || multiPart.Path != o.Path {
+		return nil, multiparts.ErrMultipartUploadNotFound
+	}
+	return multiPart, nil
+}
+
+const (
+	amzMetaHeaderPrefix        = "x-amz-meta-"
+	amzMetadataDirectiveHeader = "x-amz-metadata-directive"
+	metadataDirectiveReplace   = "REPLACE"
+	contentTypeHeader          = "Content-Type"
+)
+
+// amzMetaFromHeader returns the metadata to store with an object written by a request with
+// header: its Content-Type, user metadata (x-amz-meta-*) and tagging headers.  User metadata
+// keys are stored in lower case, as S3 returns them.
+func amzMetaFromHeader(header http.Header) (catalog.Metadata, error) {
+	var metadata catalog.Metadata
+	for k, v := range header {
+		key := strings.ToLower(k)
+		if !strings.HasPrefix(key, amzMetaHeaderPrefix) {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(catalog.Metadata)
+		}
+		metadata[key] = strings.Join(v, ",")
+	}
+	if contentType := header.Get(contentTypeHeader); contentType != "" {
+		if metadata == nil {
+			metadata = make(catalog.Metadata)
+		}
+		metadata[contentTypeHeader] = contentType
+	}
+	if tagging := header.Get(amzTaggingHeader); tagging != "" {
+		tags, err := parseTagging(tagging)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := encodeTagging(tags)
+		if err != nil {
+			return nil, err
+		}
+		if metadata == nil {
+			metadata = make(catalog.Metadata)
+		}
+		metadata[amzTaggingMetadataKey] = encoded
+	}
+	return metadata, nil
+}
+
+// copyMetadata returns the metadata to store with a copy of an object with metadata source,
+// written by a request with header.  The copy keeps the Content-Type and user metadata of
+// the source unless x-amz-metadata-directive replaces them, and its tags unless
+// x-amz-tagging-directive replaces them.
+func copyMetadata(header http.Header, source catalog.Metadata) (catalog.Metadata, error) {
+	requested, err := amzMetaFromHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	metadataFrom := source
+	if strings.EqualFold(header.Get(amzMetadataDirectiveHeader), metadataDirectiveReplace) {
+		metadataFrom = requested
+	}
+	taggingFrom := source
+	if strings.EqualFold(header.Get(amzTaggingDirectiveHeader), taggingDirectiveReplace) {
+		taggingFrom = requested
+	}
+	var metadata catalog.Metadata
+	for k, v := range metadataFrom {
+		if k == amzTaggingMetadataKey {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(catalog.Metadata)
+		}
+		metadata[k] = v
+	}
+	if tagging, ok := taggingFrom[amzTaggingMetadataKey]; ok {
+		if metadata == nil {
+			metadata = make(catalog.Metadata)
+		}
+		metadata[amzTaggingMetadataKey] = tagging
+	}
+	return metadata, nil
+}
+
+// amzMetaWriteHeaders sets the Content-Type, user metadata and tag count headers of a
+// response returning an object with metadata.
+func amzMetaWriteHeaders(o *PathOperation, metadata catalog.Metadata) {
+	for k, v := range metadata {
+		if strings.HasPrefix(strings.ToLower(k), amzMetaHeaderPrefix) {
+			o.SetHeader(k, v)
+		}
+	}
+	if contentType := metadata[contentTypeHeader]; contentType != "" {
+		o.SetHeader(contentTypeHeader, contentType)
+	} else {
+		// Delete the default content-type header so http.Server will detect it from contents
+		o.DeleteHeader(contentTypeHeader)
+	}
+	if tags := tagsFromMetadata(metadata); len(tags) > 0 {
+		o.SetHeader(amzTaggingCountHeader, strconv.Itoa(len(tags)))
+	}
+}
+
+const (
+	versionIDQueryParam = "versionId"
+	versionIDHeader     = "x-amz-version-id"
+	// nullVersionID is the version ID S3 uses for the current version of an object
+	nullVersionID = "null"
+)
+
+// objectReference returns the reference from which to read the object of o: the commit of the
+// version requested by the versionId query parameter, or the reference in its path.  versionID
+// is empty when no version was requested.
+func objectReference(o *PathOperation) (reference string, versionID string, err error) {
+	versionID = o.Request.URL.Query().Get(versionIDQueryParam)
+	if versionID == "" || versionID == nullVersionID {
+		return o.Reference, "", nil
+	}
+	if _, err := o.Cataloger.GetCommit(o.Context(), o.Repository.Name, versionID); err != nil {
+		return "", versionID, err
+	}
+	return versionID, versionID, nil
+}
+
+const (
+	ifMatchHeader           = "If-Match"
+	ifNoneMatchHeader       = "If-None-Match"
+	ifModifiedSinceHeader   = "If-Modified-Since"
+	ifUnmodifiedSinceHeader = "If-Unmodified-Since"
+	anyETag                 = "*"
+)
+
+// etagsMatch returns true if the ETag list of a conditional header matches checksum.  Weak
+// ETags match like strong ones, as S3 ETags are never weak.
+func etagsMatch(headerValue, checksum string) bool {
+	for _, etag := range strings.Split(headerValue, ",") {
+		etag = strings.TrimSpace(etag)
+		if etag == anyETag {
+			return true
+		}
+		etag = strings.Trim(strings.TrimPrefix(etag, "W/"), `"`)
+		if etag == checksum {
+			return true
+		}
+	}
+	return false
+}
+
+// modifiedSince returns whether entry was modified after the date of a conditional header.
+// ok is false if the date is invalid, and the header must be ignored.  Header dates have a
+// resolution of seconds.
+func modifiedSince(headerValue string, entry *catalog.Entry) (modified bool, ok bool) {
+	since, err := http.ParseTime(headerValue)
+	if err != nil {
+		return false, false
+	}
+	return entry.CreationDate.Truncate(time.Second).After(since), true
+}
+
+// readPreconditionStatus evaluates the conditional headers of a GET or HEAD request for entry
+// in the order of RFC 7232 section 6.  It returns the status to respond with instead of
+// entry, http.StatusPreconditionFailed or http.StatusNotModified, or http.StatusOK to return
+// entry.
+func readPreconditionStatus(header http.Header, entry *catalog.Entry) int {
+	if ifMatch := header.Get(ifMatchHeader); ifMatch != "" {
+		if !etagsMatch(ifMatch, entry.Checksum) {
+			return http.StatusPreconditionFailed
+		}
+	} else if ifUnmodifiedSince := header.Get(ifUnmodifiedSinceHeader); ifUnmodifiedSince != "" {
+		if modified, ok := modifiedSince(ifUnmodifiedSince, entry); ok && modified {
+			return http.StatusPreconditionFailed
+		}
+	}
+	if ifNoneMatch := header.Get(ifNoneMatchHeader); ifNoneMatch != "" {
+		if etagsMatch(ifNoneMatch, entry.Checksum) {
+			return http.StatusNotModified
+		}
+	} else if ifModifiedSince := header.Get(ifModifiedSinceHeader); ifModifiedSince != "" {
+		if modified, ok := modifiedSince(ifModifiedSince, entry); ok && !modified {
+			return http.StatusNotModified
+		}
+	}
+	return http.StatusOK
+}
+
+// checkReadPreconditions responds to o if its conditional headers do not hold for entry, and
+// returns false if it did.  Call it after setting the ETag and Last-Modified headers, which
+// a Not Modified response also returns.
+func checkReadPreconditions(o *PathOperation, entry *catalog.Entry) bool {
+	switch readPreconditionStatus(o.Request.Header, entry) {
+	case http.StatusNotModified:
+		o.DeleteHeader(contentTypeHeader)
+		o.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return false
+	case http.StatusPreconditionFailed:
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrPreconditionFailed))
+		return false
+	}
+	return true
+}
+
+// writeCondition returns the condition on the current entry of the path that the conditional
+// headers of a write request set, or nil if it has none.  If-None-Match only supports "*",
+// creating the object only if it does not exist.
+func writeCondition(header http.Header) (catalog.EntryCondition, error) {
+	ifMatch := header.Get(ifMatchHeader)
+	ifNoneMatch := header.Get(ifNoneMatchHeader)
+	if ifNoneMatch != "" && strings.TrimSpace(ifNoneMatch) != anyETag {
+		return nil, ErrUnsupportedCondition
+	}
+	if ifMatch == "" && ifNoneMatch == "" {
+		return nil, nil
+	}
+	return func(current *catalog.Entry) error {
+		if ifNoneMatch != "" && current != nil {
+			return ErrPreconditionFailed
+		}
+		if ifMatch != "" && (current == nil || !etagsMatch(ifMatch, current.Checksum)) {
+			return ErrPreconditionFailed
+		}
+		return nil
+	}, nil
+}
+
+// supportsConditionalWrites returns true if cataloger checks the conditions of the entries it
+// creates.
+func supportsConditionalWrites(cataloger catalog.Cataloger) bool {
+	w, ok := cataloger.(catalog.ConditionalWriter)
+	return ok && w.SupportsConditionalWrites()
+}
+
+func (o *PathOperation) finishUpload(storageNamespace, checksum, physicalAddress string, size int64, metadata catalog.Metadata, condition catalog.EntryCondition) error {
 	// write metadata
 	writeTime := time.Now()
 	entry := catalog.Entry{
 		Path:            o.Path,
 		PhysicalAddress: physicalAddress,
 		Checksum:        checksum,
-		Metadata:        nil, // TODO: Read whatever metadata came from the request headers/params and add here
+		Metadata:        metadata,
 		Size:            size,
 		CreationDate:    writeTime,
 	}
@@ -25,7 +313,12 @@ func (o *PathOperation) finishUpload(storageNamespace, checksum, physicalAddress
 				ID:               checksum,
 				StorageNamespace: storageNamespace,
 			},
+			Condition: condition,
 		})
+	if errors.Is(err, ErrPreconditionFailed) {
+		o.Log().WithError(err).Debug("conditional write rejected")
+		return err
+	}
 	if err != nil {
 		o.Log().WithError(err).Error("could not update metadata")
 		return err
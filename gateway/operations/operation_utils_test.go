@@ -0,0 +1,335 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/db"
+	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/gateway/multiparts"
+)
+
+func TestAmzMetaFromHeader(t *testing.T) {
+	cases := []struct {
+		name     string
+		header   http.Header
+		expected catalog.Metadata
+	}{
+		{"none", http.Header{"Authorization": []string{"AWS4-HMAC-SHA256 ..."}}, nil},
+		{"content type", http.Header{"Content-Type": []string{"application/json"}}, catalog.Metadata{"Content-Type": "application/json"}},
+		{
+			name: "user metadata",
+			header: http.Header{
+				"X-Amz-Meta-Owner":    []string{"data-team"},
+				"X-Amz-Meta-Tags":     []string{"a", "b"},
+				"X-Amz-Storage-Class": []string{"STANDARD"},
+				"Content-Type":        []string{"application/x-parquet"},
+			},
+			expected: catalog.Metadata{
+				"x-amz-meta-owner": "data-team",
+				"x-amz-meta-tags":  "a,b",
+				"Content-Type":     "application/x-parquet",
+			},
+		},
+		{
+			name:     "tagging",
+			header:   http.Header{"X-Amz-Tagging": []string{"project=lake&class=raw%20data"}},
+			expected: catalog.Metadata{"x-amz-tagging": "class=raw+data&project=lake"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := amzMetaFromHeader(c.header)
+			if err != nil {
+				t.Fatalf("amzMetaFromHeader: %s", err)
+			}
+			if !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("expected metadata %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestAmzMetaFromHeaderInvalidTagging(t *testing.T) {
+	header := http.Header{"X-Amz-Tagging": []string{"project=lake&project=sea"}}
+	if _, err := amzMetaFromHeader(header); !errors.Is(err, ErrInvalidTag) {
+		t.Fatalf("expected %v for duplicate tag keys, got %v", ErrInvalidTag, err)
+	}
+}
+
+func TestCopyMetadata(t *testing.T) {
+	source := catalog.Metadata{
+		"Content-Type":     "text/csv",
+		"x-amz-meta-owner": "staging",
+		"x-amz-tagging":    "class=curated",
+	}
+	cases := []struct {
+		name     string
+		header   http.Header
+		expected catalog.Metadata
+	}{
+		{"copy", http.Header{"X-Amz-Meta-Owner": []string{"ignored"}}, source},
+		{
+			name: "replace metadata",
+			header: http.Header{
+				"X-Amz-Metadata-Directive": []string{"REPLACE"},
+				"X-Amz-Meta-Owner":         []string{"published"},
+			},
+			expected: catalog.Metadata{"x-amz-meta-owner": "published", "x-amz-tagging": "class=curated"},
+		},
+		{
+			name: "replace tagging",
+			header: http.Header{
+				"X-Amz-Tagging-Directive": []string{"REPLACE"},
+				"X-Amz-Tagging":           []string{"class=published"},
+			},
+			expected: catalog.Metadata{"Content-Type": "text/csv", "x-amz-meta-owner": "staging", "x-amz-tagging": "class=published"},
+		},
+		{
+			name:     "remove tagging",
+			header:   http.Header{"X-Amz-Tagging-Directive": []string{"REPLACE"}},
+			expected: catalog.Metadata{"Content-Type": "text/csv", "x-amz-meta-owner": "staging"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := copyMetadata(c.header, source)
+			if err != nil {
+				t.Fatalf("copyMetadata: %s", err)
+			}
+			if !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("expected metadata %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestAmzMetaWriteHeaders(t *testing.T) {
+	cases := []struct {
+		name                string
+		metadata            catalog.Metadata
+		expectedContentType string
+		expectedOwner       string
+	}{
+		{"no metadata", nil, "", ""},
+		{"content type", catalog.Metadata{"Content-Type": "application/json"}, "application/json", ""},
+		{"user metadata", catalog.Metadata{"x-amz-meta-owner": "data-team", "other": "value"}, "", "data-team"},
+		{"tagging", catalog.Metadata{"x-amz-tagging": "a=1&b=2"}, "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			rec.Header().Set("Content-Type", "text/xml")
+			o := &PathOperation{RefOperation: &RefOperation{RepoOperation: &RepoOperation{
+				AuthenticatedOperation: &AuthenticatedOperation{Operation: &Operation{ResponseWriter: rec}},
+			}}}
+			amzMetaWriteHeaders(o, c.metadata)
+			header := rec.Header()
+			if got := header.Get("Content-Type"); got != c.expectedContentType {
+				t.Errorf("expected Content-Type %q, got %q", c.expectedContentType, got)
+			}
+			if got := header["x-amz-meta-owner"]; c.expectedOwner != "" && (len(got) != 1 || got[0] != c.expectedOwner) {
+				t.Errorf("expected x-amz-meta-owner %q, got %v", c.expectedOwner, got)
+			}
+			if _, ok := header["x-amz-tagging"]; ok {
+				t.Error("expected tags not to be returned as metadata")
+			}
+			if got := header["x-amz-tagging-count"]; c.metadata["x-amz-tagging"] != "" && (len(got) != 1 || got[0] != "2") {
+				t.Errorf("expected x-amz-tagging-count 2, got %v", got)
+			}
+			if _, ok := header["other"]; ok {
+				t.Error("expected metadata other than user metadata not to be returned")
+			}
+		})
+	}
+}
+
+func TestReadPreconditionStatus(t *testing.T) {
+	entry := &catalog.Entry{
+		Checksum:     "abc",
+		CreationDate: time.Date(2020, 12, 1, 10, 0, 0, 500, time.UTC),
+	}
+	const (
+		before = "Tue, 01 Dec 2020 09:00:00 GMT"
+		at     = "Tue, 01 Dec 2020 10:00:00 GMT"
+		after  = "Tue, 01 Dec 2020 11:00:00 GMT"
+	)
+	cases := []struct {
+		name     string
+		header   http.Header
+		expected int
+	}{
+		{"no conditions", http.Header{}, http.StatusOK},
+		{"if-match", http.Header{"If-Match": []string{`"abc"`}}, http.StatusOK},
+		{"if-match list", http.Header{"If-Match": []string{`"xyz", W/"abc"`}}, http.StatusOK},
+		{"if-match any", http.Header{"If-Match": []string{"*"}}, http.StatusOK},
+		{"if-match fails", http.Header{"If-Match": []string{`"xyz"`}}, http.StatusPreconditionFailed},
+		{"if-none-match", http.Header{"If-None-Match": []string{`"xyz"`}}, http.StatusOK},
+		{"if-none-match fails", http.Header{"If-None-Match": []string{`"abc"`}}, http.StatusNotModified},
+		{"if-modified-since", http.Header{"If-Modified-Since": []string{before}}, http.StatusOK},
+		{"if-modified-since fails", http.Header{"If-Modified-Since": []string{at}}, http.StatusNotModified},
+		{"if-modified-since invalid", http.Header{"If-Modified-Since": []string{"yesterday"}}, http.StatusOK},
+		{"if-unmodified-since", http.Header{"If-Unmodified-Since": []string{at}}, http.StatusOK},
+		{"if-unmodified-since fails", http.Header{"If-Unmodified-Since": []string{before}}, http.StatusPreconditionFailed},
+		{
+			name:     "if-match overrides if-unmodified-since",
+			header:   http.Header{"If-Match": []string{`"abc"`}, "If-Unmodified-Since": []string{before}},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "if-none-match overrides if-modified-since",
+			header:   http.Header{"If-None-Match": []string{`"abc"`}, "If-Modified-Since": []string{before}},
+			expected: http.StatusNotModified,
+		},
+		{
+			name:     "precondition failure before not modified",
+			header:   http.Header{"If-Match": []string{`"xyz"`}, "If-Modified-Since": []string{after}},
+			expected: http.StatusPreconditionFailed,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := readPreconditionStatus(c.header, entry)
+			if got != c.expected {
+				t.Errorf("expected status %d, got %d", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestWriteCondition(t *testing.T) {
+	existing := &catalog.Entry{Checksum: "abc"}
+	cases := []struct {
+		name                string
+		header              http.Header
+		expectedErr         error
+		expectedUnset       bool
+		expectedIfExisting  error
+		expectedIfNotExists error
+	}{
+		{name: "unconditional", header: http.Header{}, expectedUnset: true},
+		{name: "if-none-match any", header: http.Header{"If-None-Match": []string{"*"}}, expectedIfExisting: ErrPreconditionFailed},
+		{name: "if-none-match etag", header: http.Header{"If-None-Match": []string{`"abc"`}}, expectedErr: ErrUnsupportedCondition},
+		{name: "if-match", header: http.Header{"If-Match": []string{`"abc"`}}, expectedIfNotExists: ErrPreconditionFailed},
+		{
+			name:                "if-match fails",
+			header:              http.Header{"If-Match": []string{`"xyz"`}},
+			expectedIfExisting:  ErrPreconditionFailed,
+			expectedIfNotExists: ErrPreconditionFailed,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			condition, err := writeCondition(c.header)
+			if !errors.Is(err, c.expectedErr) {
+				t.Fatalf("expected error %v, got %v", c.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if c.expectedUnset {
+				if condition != nil {
+					t.Error("expected no condition")
+				}
+				return
+			}
+			if err := condition(existing); !errors.Is(err, c.expectedIfExisting) {
+				t.Errorf("expected %v for an existing entry, got %v", c.expectedIfExisting, err)
+			}
+			if err := condition(nil); !errors.Is(err, c.expectedIfNotExists) {
+				t.Errorf("expected %v for a missing entry, got %v", c.expectedIfNotExists, err)
+			}
+		})
+	}
+}
+
+func TestCopySourceErrorCode(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected gatewayerrors.APIErrorCode
+	}{
+		{"access denied", fmt.Errorf("copy: %w", ErrCopySourceAccessDenied), gatewayerrors.ErrAccessDenied},
+		{"not found", db.ErrNotFound, gatewayerrors.ErrInvalidCopySource},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := copySourceErrorCode(c.err); got != c.expected {
+				t.Errorf("expected error code %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
+
+type trackerFake struct {
+	multiparts.Tracker
+	upload multiparts.MultipartUpload
+}
+
+func (t *trackerFake) Get(_ context.Context, uploadID string) (*multiparts.MultipartUpload, error) {
+	if uploadID != t.upload.UploadID {
+		return nil, multiparts.ErrMultipartUploadNotFound
+	}
+	upload := t.upload
+	return &upload, nil
+}
+
+func TestGetMultipartUpload(t *testing.T) {
+	tracker := &trackerFake{upload: multiparts.MultipartUpload{
+		UploadID:   "upload",
+		Repository: "repo",
+		Branch:     "master",
+		Path:       "data/file",
+	}}
+	cases := []struct {
+		name       string
+		uploadID   string
+		repository string
+		branch     string
+		path       string
+		expectErr  bool
+	}{
+		{"same object", "upload", "repo", "master", "data/file", false},
+		{"unknown upload", "other", "repo", "master", "data/file", true},
+		{"other repository", "upload", "repo2", "master", "data/file", true},
+		{"other branch", "upload", "repo", "feature", "data/file", true},
+		{"other path", "upload", "repo", "master", "data/other", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			o := &PathOperation{
+				RefOperation: &RefOperation{
+					RepoOperation: &RepoOperation{
+						AuthenticatedOperation: &AuthenticatedOperation{Operation: &Operation{
+							Request:           httptest.NewRequest(http.MethodGet, "/", nil),
+							MultipartsTracker: tracker,
+						}},
+						Repository: &catalog.Repository{Name: c.repository},
+					},
+					Reference: c.branch,
+				},
+				Path: c.path,
+			}
+			upload, err := getMultipartUpload(o, c.uploadID)
+			if c.expectErr {
+				if multipartErrorCode(err) != gatewayerrors.ErrNoSuchUpload {
+					t.Errorf("expected NoSuchUpload, got upload %+v, err %v", upload, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getMultipartUpload: %s", err)
+			}
+			if upload.UploadID != c.uploadID {
+				t.Errorf("expected upload %s, got %s", c.uploadID, upload.UploadID)
+			}
+		})
+	}
+}
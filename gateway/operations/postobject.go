@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/gateway/multiparts"
 	"github.com/treeverse/lakefs/gateway/path"
 	"github.com/treeverse/lakefs/gateway/serde"
 	"github.com/treeverse/lakefs/httputil"
@@ -39,6 +40,12 @@ func (controller *PostObject) HandleCreateMultipartUpload(o *PathOperation) {
 	o.Incr("create_mpu")
 	uuidBytes := [16]byte(uuid.New())
 	objName := hex.EncodeToString(uuidBytes[:])
+	metadata, err := amzMetaFromHeader(o.Request.Header)
+	if err != nil {
+		o.Log().WithError(err).Debug("invalid object metadata")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidTag))
+		return
+	}
 	storageClass := StorageClassFromHeader(o.Request.Header)
 	opts := block.CreateMultiPartUploadOpts{StorageClass: storageClass}
 	uploadID, err := o.BlockStore.CreateMultiPartUpload(block.ObjectPointer{StorageNamespace: o.Repository.StorageNamespace, Identifier: objName}, o.Request, opts)
@@ -47,7 +54,15 @@ func (controller *PostObject) HandleCreateMultipartUpload(o *PathOperation) {
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
 		return
 	}
-	err = o.MultipartsTracker.Create(o.Context(), uploadID, o.Path, objName, time.Now())
+	err = o.MultipartsTracker.Create(o.Context(), multiparts.MultipartUpload{
+		UploadID:        uploadID,
+		Repository:      o.Repository.Name,
+		Branch:          o.Reference,
+		Path:            o.Path,
+		CreationDate:    time.Now(),
+		PhysicalAddress: objName,
+		Metadata:        metadata,
+	})
 	if err != nil {
 		o.Log().WithError(err).Error("could not write multipart upload to DB")
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
@@ -70,10 +85,10 @@ func (controller *PostObject) HandleCompleteMultipartUpload(o *PathOperation) {
 	o.Incr("complete_mpu")
 	uploadID := o.Request.URL.Query().Get(CompleteMultipartUploadQueryParam)
 	o.AddLogFields(logging.Fields{"upload_id": uploadID})
-	multiPart, err := o.MultipartsTracker.Get(o.Context(), uploadID)
+	multiPart, err := getMultipartUpload(o, uploadID)
 	if err != nil {
 		o.Log().WithError(err).Error("could not read multipart record")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		o.EncodeError(errors.Codes.ToAPIErr(multipartErrorCode(err)))
 		return
 	}
 	objName := multiPart.PhysicalAddress
@@ -99,9 +114,9 @@ func (controller *PostObject) HandleCompleteMultipartUpload(o *PathOperation) {
 	}
 	ch := trimQuotes(*etag)
 	checksum := strings.Split(ch, "-")[0]
-	err = o.finishUpload(o.Repository.StorageNamespace, checksum, objName, size)
+	err = o.finishUpload(o.Repository.StorageNamespace, checksum, objName, size, multiPart.Metadata, nil)
 	if err != nil {
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		o.EncodeError(errors.Codes.ToAPIErr(writeErrorCode(err, errors.ErrInternalError)))
 		return
 	}
 	err = o.MultipartsTracker.Delete(o.Context(), uploadID)
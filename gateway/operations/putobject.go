@@ -8,9 +8,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/treeverse/lakefs/auth"
 	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/gateway/errors"
+	ghttp "github.com/treeverse/lakefs/gateway/http"
+	"github.com/treeverse/lakefs/gateway/multiparts"
 	"github.com/treeverse/lakefs/gateway/path"
 	"github.com/treeverse/lakefs/gateway/serde"
 	"github.com/treeverse/lakefs/httputil"
@@ -20,9 +23,10 @@ import (
 )
 
 const (
-	CopySourceHeader     = "x-amz-copy-source"
-	QueryParamUploadID   = "uploadId"
-	QueryParamPartNumber = "partNumber"
+	CopySourceHeader      = "x-amz-copy-source"
+	CopySourceRangeHeader = "x-amz-copy-source-range"
+	QueryParamUploadID    = "uploadId"
+	QueryParamPartNumber  = "partNumber"
 )
 
 type PutObject struct{}
@@ -36,8 +40,10 @@ func (controller *PutObject) RequiredPermissions(_ *http.Request, repoID, _, pat
 	}, nil
 }
 
-func (controller *PutObject) HandleCopy(o *PathOperation, copySource string) {
-	o.Incr("copy_object")
+// getCopySourceEntry returns the repository and entry of the object named by copySource, the
+// value of an x-amz-copy-source header.  The principal of o must be allowed to read the
+// source object, which may be in another repository.
+func getCopySourceEntry(o *PathOperation, copySource string) (*catalog.Repository, *catalog.Entry, error) {
 	// resolve source branch and source path
 	copySourceDecoded, err := url.QueryUnescape(copySource)
 	if err != nil {
@@ -45,33 +51,86 @@ func (controller *PutObject) HandleCopy(o *PathOperation, copySource string) {
 	}
 	p, err := path.ResolveAbsolutePath(copySourceDecoded)
 	if err != nil {
-		o.Log().WithError(err).Error("could not parse copy source path")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidCopySource))
-		return
+		return nil, nil, fmt.Errorf("parse copy source path: %w", err)
 	}
 
-	// validate src and dst are in the same repository
-	if !strings.EqualFold(o.Repository.Name, p.Repo) {
-		o.Log().WithError(err).Error("cannot copy objects across repos")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidCopySource))
-		return
+	sameRepo := strings.EqualFold(o.Repository.Name, p.Repo)
+	repoName := p.Repo
+	if sameRepo {
+		repoName = o.Repository.Name
+	}
+	// the destination write permission does not cover reading the source
+	if err := authorizeCopySource(o, repoName, p.Path); err != nil {
+		return nil, nil, err
+	}
+	repo := o.Repository
+	if !sameRepo {
+		repo, err = o.Cataloger.GetRepository(o.Context(), p.Repo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get copy source repository: %w", err)
+		}
 	}
 
-	// update metadata to refer to the source hash in the destination workspace
-	ent, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, p.Reference, p.Path, catalog.GetEntryParams{})
+	ent, err := o.Cataloger.GetEntry(o.Context(), repo.Name, p.Reference, p.Path, catalog.GetEntryParams{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return repo, ent, nil
+}
+
+// authorizeCopySource returns ErrCopySourceAccessDenied unless the principal of o may read
+// path in repository.
+func authorizeCopySource(o *PathOperation, repository, path string) error {
+	authResp, err := o.Auth.Authorize(&auth.AuthorizationRequest{
+		Username: o.Principal,
+		RequiredPermissions: []permissions.Permission{
+			{
+				Action:   permissions.ReadObjectAction,
+				Resource: permissions.ObjectArn(repository, path),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("authorize copy source: %w", err)
+	}
+	if !authResp.Allowed {
+		return ErrCopySourceAccessDenied
+	}
+	return nil
+}
+
+func (controller *PutObject) HandleCopy(o *PathOperation, copySource string) {
+	o.Incr("copy_object")
+	srcRepo, ent, err := getCopySourceEntry(o, copySource)
 	if err != nil {
 		o.Log().WithError(err).Error("could not read copy source")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidCopySource))
+		o.EncodeError(errors.Codes.ToAPIErr(copySourceErrorCode(err)))
+		return
+	}
+	metadata, err := copyMetadata(o.Request.Header, ent.Metadata)
+	if err != nil {
+		o.Log().WithError(err).Debug("invalid copy metadata")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidTag))
+		return
+	}
+	// refer to the source data from the destination repository, copying it if the two
+	// repositories do not share storage
+	physicalAddress, err := upload.CopyBlob(o.BlockStore, srcRepo.StorageNamespace, ent.PhysicalAddress, o.Repository.StorageNamespace)
+	if err != nil {
+		o.Log().WithError(err).Error("could not copy source data")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
 		return
 	}
 	// write this object to workspace
 	// TODO: move this logic into the Index impl.
+	ent.PhysicalAddress = physicalAddress
 	ent.CreationDate = time.Now()
 	ent.Path = o.Path
+	ent.Metadata = metadata
 	err = o.Cataloger.CreateEntry(o.Context(), o.Repository.Name, o.Reference, *ent, catalog.CreateEntryParams{})
 	if err != nil {
 		o.Log().WithError(err).Error("could not write copy destination")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidCopyDest))
+		o.EncodeError(errors.Codes.ToAPIErr(writeErrorCode(err, errors.ErrInvalidCopyDest)))
 		return
 	}
 
@@ -100,10 +159,10 @@ func (controller *PutObject) HandleUploadPart(o *PathOperation) {
 	})
 
 	// handle the upload itself
-	multiPart, err := o.MultipartsTracker.Get(o.Context(), uploadID)
+	multiPart, err := getMultipartUpload(o, uploadID)
 	if err != nil {
 		o.Log().WithError(err).Error("could not read  multipart record")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		o.EncodeError(errors.Codes.ToAPIErr(multipartErrorCode(err)))
 		return
 	}
 	byteSize := o.Request.ContentLength
@@ -114,10 +173,93 @@ func (controller *PutObject) HandleUploadPart(o *PathOperation) {
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
 		return
 	}
+	err = o.MultipartsTracker.PutPart(o.Context(), uploadID, multiparts.MultipartPart{
+		PartNumber:   int(partNumber),
+		ETag:         etag,
+		Size:         byteSize,
+		LastModified: time.Now(),
+	})
+	if err != nil {
+		o.Log().WithError(err).Error("could not write multipart part to DB")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		return
+	}
 	o.SetHeader("ETag", etag)
 	o.ResponseWriter.WriteHeader(http.StatusOK)
 }
 
+func (controller *PutObject) HandleUploadPartCopy(o *PathOperation, copySource string) {
+	o.Incr("copy_mpu_part")
+	query := o.Request.URL.Query()
+	uploadID := query.Get(QueryParamUploadID)
+	partNumberStr := query.Get(QueryParamPartNumber)
+
+	partNumber, err := strconv.ParseInt(partNumberStr, 10, 64)
+	if err != nil {
+		o.Log().WithError(err).Error("invalid part number")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidPartNumberMarker))
+		return
+	}
+
+	o.AddLogFields(logging.Fields{
+		"part_number": partNumber,
+		"upload_id":   uploadID,
+		"copy_source": copySource,
+	})
+
+	multiPart, err := getMultipartUpload(o, uploadID)
+	if err != nil {
+		o.Log().WithError(err).Error("could not read multipart record")
+		o.EncodeError(errors.Codes.ToAPIErr(multipartErrorCode(err)))
+		return
+	}
+	srcRepo, ent, err := getCopySourceEntry(o, copySource)
+	if err != nil {
+		o.Log().WithError(err).Error("could not read copy source")
+		o.EncodeError(errors.Codes.ToAPIErr(copySourceErrorCode(err)))
+		return
+	}
+
+	sourceObj := block.ObjectPointer{StorageNamespace: srcRepo.StorageNamespace, Identifier: ent.PhysicalAddress}
+	destinationObj := block.ObjectPointer{StorageNamespace: o.Repository.StorageNamespace, Identifier: multiPart.PhysicalAddress}
+	var etag string
+	size := ent.Size
+	rangeSpec := o.Request.Header.Get(CopySourceRangeHeader)
+	if rangeSpec != "" {
+		rng, err := ghttp.ParseRange(rangeSpec, ent.Size)
+		if err != nil {
+			o.Log().WithError(err).WithField("range", rangeSpec).Debug("invalid copy source range")
+			o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidCopyPartRangeSource))
+			return
+		}
+		size = rng.EndOffset - rng.StartOffset + 1
+		etag, err = o.BlockStore.UploadCopyPartRange(sourceObj, destinationObj, uploadID, partNumber, rng.StartOffset, rng.EndOffset)
+	} else {
+		etag, err = o.BlockStore.UploadCopyPart(sourceObj, destinationObj, uploadID, partNumber)
+	}
+	if err != nil {
+		o.Log().WithError(err).Error("part " + partNumberStr + " copy failed")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		return
+	}
+	lastModified := time.Now()
+	err = o.MultipartsTracker.PutPart(o.Context(), uploadID, multiparts.MultipartPart{
+		PartNumber:   int(partNumber),
+		ETag:         etag,
+		Size:         size,
+		LastModified: lastModified,
+	})
+	if err != nil {
+		o.Log().WithError(err).Error("could not write multipart part to DB")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		return
+	}
+	o.EncodeResponse(&serde.CopyPartResult{
+		LastModified: serde.Timestamp(lastModified),
+		ETag:         etag,
+	}, http.StatusOK)
+}
+
 func (controller *PutObject) Handle(o *PathOperation) {
 	// verify branch before we upload data - fail early
 	branchExists, err := o.Cataloger.BranchExists(o.Context(), o.Repository.Name, o.Reference)
@@ -131,13 +273,32 @@ func (controller *PutObject) Handle(o *PathOperation) {
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrNoSuchBucket))
 		return
 	}
+	// verify branch protection allows writes before we upload data
+	err = o.Cataloger.CheckBranchProtection(o.Context(), o.Repository.Name, o.Reference, catalog.BranchProtectionBlockedActionStagingWrite)
+	if err != nil {
+		o.Log().WithError(err).Debug("write to branch denied")
+		o.EncodeError(errors.Codes.ToAPIErr(writeErrorCode(err, errors.ErrInternalError)))
+		return
+	}
 
 	// check if this is a copy operation (i.e. https://docs.aws.amazon.com/AmazonS3/latest/API/API_CopyObject.html)
 	// A copy operation is identified by the existence of an "x-amz-copy-source" header
 	storageClass := StorageClassFromHeader(o.Request.Header)
 	opts := block.PutOpts{StorageClass: storageClass}
 
+	query := o.Request.URL.Query()
+	if _, exists := query["tagging"]; exists {
+		controller.HandlePutTagging(o)
+		return
+	}
+	_, hasUploadID := query[QueryParamUploadID]
+
 	copySource := o.Request.Header.Get(CopySourceHeader)
+	if len(copySource) > 0 && hasUploadID {
+		// a copy into a part of a multipart upload (i.e. https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPartCopy.html)
+		controller.HandleUploadPartCopy(o, copySource)
+		return
+	}
 	if len(copySource) > 0 {
 		// The *first* PUT operation sets PutOpts such as
 		// storage class, subsequent PUT operations of the
@@ -148,16 +309,31 @@ func (controller *PutObject) Handle(o *PathOperation) {
 		return
 	}
 
-	query := o.Request.URL.Query()
-
 	// check if this is a multipart upload creation call
-	_, hasUploadID := query[QueryParamUploadID]
 	if hasUploadID {
 		controller.HandleUploadPart(o)
 		return
 	}
 
 	o.Incr("put_object")
+	condition, err := writeCondition(o.Request.Header)
+	if err != nil {
+		o.Log().WithError(err).Debug("unsupported conditional write")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrNotImplemented))
+		return
+	}
+	// reject before writing data that no entry would refer to
+	if condition != nil && !supportsConditionalWrites(o.Cataloger) {
+		o.Log().Debug("cataloger does not support conditional writes")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrNotImplemented))
+		return
+	}
+	metadata, err := amzMetaFromHeader(o.Request.Header)
+	if err != nil {
+		o.Log().WithError(err).Debug("invalid object metadata")
+		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidTag))
+		return
+	}
 	// handle the upload itself
 	blob, err := upload.WriteBlob(o.BlockStore, o.Repository.StorageNamespace, o.Request.Body, o.Request.ContentLength, opts)
 	if err != nil {
@@ -167,9 +343,9 @@ func (controller *PutObject) Handle(o *PathOperation) {
 	}
 
 	// write metadata
-	err = o.finishUpload(o.Repository.StorageNamespace, blob.Checksum, blob.PhysicalAddress, blob.Size)
+	err = o.finishUpload(o.Repository.StorageNamespace, blob.Checksum, blob.PhysicalAddress, blob.Size, metadata, condition)
 	if err != nil {
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		o.EncodeError(errors.Codes.ToAPIErr(writeErrorCode(err, errors.ErrInternalError)))
 		return
 	}
 	o.SetHeader("ETag", httputil.ETag(blob.Checksum))
@@ -2,13 +2,24 @@ package operations_test
 
 import (
 	"bytes"
+	"context"
 	"crypto/md5" //nolint:gosec
 	"crypto/rand"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/go-openapi/swag"
+	"github.com/treeverse/lakefs/auth"
+	"github.com/treeverse/lakefs/auth/model"
 	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/gateway/multiparts"
+	"github.com/treeverse/lakefs/gateway/operations"
+	"github.com/treeverse/lakefs/permissions"
 	"github.com/treeverse/lakefs/upload"
 )
 
@@ -76,3 +87,178 @@ func TestReadBlob(t *testing.T) {
 		})
 	}
 }
+
+// denyAuth denies every authorization request, recording the permissions it was asked for
+type denyAuth struct {
+	required []permissions.Permission
+}
+
+func (a *denyAuth) GetCredentials(string) (*model.Credential, error) {
+	return nil, db.ErrNotFound
+}
+
+func (a *denyAuth) GetUserByID(int) (*model.User, error) {
+	return nil, db.ErrNotFound
+}
+
+func (a *denyAuth) Authorize(req *auth.AuthorizationRequest) (*auth.AuthorizationResponse, error) {
+	a.required = append(a.required, req.RequiredPermissions...)
+	return &auth.AuthorizationResponse{Allowed: false}, nil
+}
+
+// entryCounter is a cataloger that counts reads of entries, all of which are not found
+type entryCounter struct {
+	catalog.Cataloger
+	reads int
+}
+
+func (c *entryCounter) GetEntry(context.Context, string, string, string, catalog.GetEntryParams) (*catalog.Entry, error) {
+	c.reads++
+	return nil, db.ErrNotFound
+}
+
+// uploadTracker tracks a single multipart upload
+type uploadTracker struct {
+	multiparts.Tracker
+	upload multiparts.MultipartUpload
+}
+
+func (t *uploadTracker) Get(_ context.Context, uploadID string) (*multiparts.MultipartUpload, error) {
+	if uploadID != t.upload.UploadID {
+		return nil, multiparts.ErrMultipartUploadNotFound
+	}
+	upload := t.upload
+	return &upload, nil
+}
+
+func newPathOperation(cataloger catalog.Cataloger, authService *denyAuth, request *http.Request) (*operations.PathOperation, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	return &operations.PathOperation{
+		RefOperation: &operations.RefOperation{
+			RepoOperation: &operations.RepoOperation{
+				AuthenticatedOperation: &operations.AuthenticatedOperation{
+					Operation: &operations.Operation{
+						Request:        request,
+						ResponseWriter: recorder,
+						Cataloger:      cataloger,
+						Auth:           authService,
+						Incr:           func(string) {},
+					},
+					Principal: "writer",
+				},
+				Repository: &catalog.Repository{Name: "repo", StorageNamespace: "mem://repo"},
+			},
+			Reference: "master",
+		},
+		Path: "dest",
+	}, recorder
+}
+
+func TestPutObject_HandleCopySourceDenied(t *testing.T) {
+	cataloger := &entryCounter{}
+	authService := &denyAuth{}
+	o, recorder := newPathOperation(cataloger, authService, httptest.NewRequest(http.MethodPut, "/repo/master/dest", nil))
+
+	// a copy within the repository of the destination still requires reading the source
+	controller := &operations.PutObject{}
+	controller.HandleCopy(o, "repo/master/source")
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected status %d copying a denied source, got %d", http.StatusForbidden, recorder.Code)
+	}
+	if cataloger.reads != 0 {
+		t.Errorf("expected no reads of the denied source, got %d", cataloger.reads)
+	}
+	expected := permissions.Permission{Action: permissions.ReadObjectAction, Resource: permissions.ObjectArn("repo", "source")}
+	if len(authService.required) != 1 || authService.required[0] != expected {
+		t.Errorf("expected authorization of %+v, got %+v", expected, authService.required)
+	}
+}
+
+func TestPutObject_HandleUploadPartCopySourceDenied(t *testing.T) {
+	cataloger := &entryCounter{}
+	authService := &denyAuth{}
+	request := httptest.NewRequest(http.MethodPut, "/repo/master/dest?uploadId=upload&partNumber=1", nil)
+	o, recorder := newPathOperation(cataloger, authService, request)
+	o.MultipartsTracker = &uploadTracker{upload: multiparts.MultipartUpload{
+		UploadID:   "upload",
+		Repository: "repo",
+		Branch:     "master",
+		Path:       "dest",
+	}}
+
+	// a part copied from the repository of the upload still requires reading the source
+	controller := &operations.PutObject{}
+	controller.HandleUploadPartCopy(o, "repo/master/source")
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected status %d copying a part of a denied source, got %d", http.StatusForbidden, recorder.Code)
+	}
+	if cataloger.reads != 0 {
+		t.Errorf("expected no reads of the denied source, got %d", cataloger.reads)
+	}
+	expected := permissions.Permission{Action: permissions.ReadObjectAction, Resource: permissions.ObjectArn("repo", "source")}
+	if len(authService.required) != 1 || authService.required[0] != expected {
+		t.Errorf("expected authorization of %+v, got %+v", expected, authService.required)
+	}
+}
+
+// branchCataloger is a cataloger of an existing branch with no protection rules
+type branchCataloger struct {
+	catalog.Cataloger
+	conditional bool
+	created     int
+}
+
+func (c *branchCataloger) BranchExists(context.Context, string, string) (bool, error) {
+	return true, nil
+}
+
+func (c *branchCataloger) CheckBranchProtection(context.Context, string, string, catalog.BranchProtectionBlockedAction) error {
+	return nil
+}
+
+func (c *branchCataloger) CreateEntry(context.Context, string, string, catalog.Entry, catalog.CreateEntryParams) error {
+	c.created++
+	return nil
+}
+
+func (c *branchCataloger) SupportsConditionalWrites() bool {
+	return c.conditional
+}
+
+func TestPutObject_ConditionalWriteNotSupported(t *testing.T) {
+	cases := []struct {
+		name           string
+		conditional    bool
+		expectedStatus int
+		expectedPuts   int
+	}{
+		{"supported", true, http.StatusOK, 1},
+		{"not supported", false, http.StatusNotImplemented, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cataloger := &branchCataloger{conditional: c.conditional}
+			adapter := newMockAdapter()
+			request := httptest.NewRequest(http.MethodPut, "/repo/master/dest", bytes.NewReader([]byte("data")))
+			request.Header.Set("If-None-Match", "*")
+			o, recorder := newPathOperation(cataloger, &denyAuth{}, request)
+			o.BlockStore = adapter
+
+			controller := &operations.PutObject{}
+			controller.Handle(o)
+
+			if recorder.Code != c.expectedStatus {
+				body, _ := ioutil.ReadAll(recorder.Body)
+				t.Fatalf("expected status %d, got %d: %s", c.expectedStatus, recorder.Code, body)
+			}
+			if adapter.count != c.expectedPuts {
+				t.Errorf("expected %d writes of the object data, got %d", c.expectedPuts, adapter.count)
+			}
+			if cataloger.created != c.expectedPuts {
+				t.Errorf("expected %d entries created, got %d", c.expectedPuts, cataloger.created)
+			}
+		})
+	}
+}
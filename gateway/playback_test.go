@@ -147,6 +147,8 @@ func getBasicHandler(t *testing.T, authService *simulator.PlayBackMockConf) (htt
 		&mockCollector{},
 		dedupCleaner,
 		nil,
+		nil,
+		gateway.BucketConfig{DefaultBranch: "master"},
 	)
 
 	return handler, &dependencies{
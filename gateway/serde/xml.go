@@ -3,7 +3,7 @@ package serde
 import "encoding/xml"
 
 const (
-	VersioningResponse = `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"/>`
+	VersioningResponse = `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`
 )
 
 type Error struct {
@@ -89,6 +89,39 @@ type ListBucketResult struct {
 	Contents       []Contents       `xml:"Contents"`
 }
 
+type Version struct {
+	Key          string `xml:"Key"`
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool   `xml:"IsLatest"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type DeleteMarker struct {
+	Key          string `xml:"Key"`
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool   `xml:"IsLatest"`
+	LastModified string `xml:"LastModified"`
+}
+
+type ListVersionsResult struct {
+	XMLName             xml.Name         `xml:"ListVersionsResult"`
+	Name                string           `xml:"Name"`
+	Prefix              string           `xml:"Prefix"`
+	KeyMarker           string           `xml:"KeyMarker"`
+	VersionIDMarker     string           `xml:"VersionIdMarker"`
+	NextKeyMarker       string           `xml:"NextKeyMarker,omitempty"`
+	NextVersionIDMarker string           `xml:"NextVersionIdMarker,omitempty"`
+	Delimiter           string           `xml:"Delimiter,omitempty"`
+	MaxKeys             int              `xml:"MaxKeys"`
+	IsTruncated         bool             `xml:"IsTruncated"`
+	Version             []Version        `xml:"Version"`
+	DeleteMarker        []DeleteMarker   `xml:"DeleteMarker"`
+	CommonPrefixes      []CommonPrefixes `xml:"CommonPrefixes"`
+}
+
 type Object struct {
 	Key       string `xml:"Key"`
 	VersionID string `xml:"VersionId,omitempty"`
@@ -146,6 +179,51 @@ type CompleteMultipartUploadResult struct {
 	ETag     string `xml:"ETag"`
 }
 
+type CopyPartResult struct {
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+}
+
+type Upload struct {
+	Key          string `xml:"Key"`
+	UploadID     string `xml:"UploadId"`
+	Initiated    string `xml:"Initiated"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type ListMultipartUploadsResult struct {
+	XMLName            xml.Name `xml:"ListMultipartUploadsResult"`
+	Bucket             string   `xml:"Bucket"`
+	KeyMarker          string   `xml:"KeyMarker"`
+	UploadIDMarker     string   `xml:"UploadIdMarker"`
+	NextKeyMarker      string   `xml:"NextKeyMarker,omitempty"`
+	NextUploadIDMarker string   `xml:"NextUploadIdMarker,omitempty"`
+	Prefix             string   `xml:"Prefix"`
+	MaxUploads         int      `xml:"MaxUploads"`
+	IsTruncated        bool     `xml:"IsTruncated"`
+	Upload             []Upload `xml:"Upload"`
+}
+
+type Part struct {
+	PartNumber   int    `xml:"PartNumber"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+}
+
+type ListPartsResult struct {
+	XMLName              xml.Name `xml:"ListPartsResult"`
+	Bucket               string   `xml:"Bucket"`
+	Key                  string   `xml:"Key"`
+	UploadID             string   `xml:"UploadId"`
+	PartNumberMarker     int      `xml:"PartNumberMarker"`
+	NextPartNumberMarker int      `xml:"NextPartNumberMarker,omitempty"`
+	MaxParts             int      `xml:"MaxParts"`
+	IsTruncated          bool     `xml:"IsTruncated"`
+	StorageClass         string   `xml:"StorageClass"`
+	Part                 []Part   `xml:"Part"`
+}
+
 type VersioningConfiguration struct {
 	Enabled bool `xml:"Enabled,omitempty"`
 }
@@ -9,7 +9,9 @@ import (
 	"net/url"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/treeverse/lakefs/auth/model"
 	"github.com/treeverse/lakefs/gateway/errors"
@@ -18,7 +20,11 @@ import (
 )
 
 const (
-	v2authHeaderName = "Authorization"
+	v2authHeaderName   = "Authorization"
+	v2AccessKeyIDParam = "AWSAccessKeyId"
+	v2SignatureParam   = "Signature"
+	v2ExpiresParam     = "Expires"
+	v2DateHeaderName   = "Date"
 )
 
 var (
@@ -68,6 +74,9 @@ func init() {
 type v2Context struct {
 	accessKeyID string
 	signature   []byte
+	// expires is set for requests signed by their query parameters, which are valid until
+	// then
+	expires *time.Time
 }
 
 func (a v2Context) GetAccessKeyID() string {
@@ -108,6 +117,20 @@ func (a *V2SigAuthenticator) Parse() (SigContext, error) {
 			return ctx, ErrHeaderMalformed
 		}
 		ctx.signature = sig
+	} else if query := a.r.URL.Query(); query.Get(v2SignatureParam) != "" {
+		// presigned request
+		ctx.accessKeyID = query.Get(v2AccessKeyIDParam)
+		sig, err := base64.StdEncoding.DecodeString(query.Get(v2SignatureParam))
+		if err != nil {
+			return ctx, errors.ErrInvalidQueryParams
+		}
+		ctx.signature = sig
+		expiresEpoch, err := strconv.ParseInt(query.Get(v2ExpiresParam), 10, 64)
+		if err != nil {
+			return ctx, errors.ErrMalformedExpires
+		}
+		expires := time.Unix(expiresEpoch, 0)
+		ctx.expires = &expires
 	}
 	a.ctx = ctx
 	return ctx, nil
@@ -248,11 +271,25 @@ func (a *V2SigAuthenticator) Verify(creds *model.Credential, bareDomain string)
 	patchedPath = strings.ReplaceAll(patchedPath, "*", "%2A")
 	patchedPath = strings.ReplaceAll(patchedPath, "%7E", "~")
 	path := buildPath(a.r.Host, bareDomain, patchedPath)
-	stringToSigh := canonicalString(a.r.Method, a.r.URL.Query(), path, a.r.Header)
+	headers := a.r.Header
+	if a.ctx.expires != nil {
+		// presigned requests sign their expiry instead of their date
+		headers = headers.Clone()
+		for key := range headers {
+			if strings.EqualFold(key, v2DateHeaderName) {
+				delete(headers, key)
+			}
+		}
+		headers.Set(v2DateHeaderName, a.r.URL.Query().Get(v2ExpiresParam))
+	}
+	stringToSigh := canonicalString(a.r.Method, a.r.URL.Query(), path, headers)
 	digest := signCanonicalString(stringToSigh, []byte(creds.AccessSecretKey))
 	if !Equal(digest, a.ctx.signature) {
 		return errors.ErrSignatureDoesNotMatch
 	}
+	if a.ctx.expires != nil && time.Now().After(*a.ctx.expires) {
+		return errors.ErrExpiredPresignRequest
+	}
 	return nil
 }
 
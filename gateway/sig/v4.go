@@ -31,6 +31,10 @@ const (
 	v4timeFormat            = "20060102T150405Z"
 	v4shortTimeFormat       = "20060102"
 	v4SignatureHeader       = "X-Amz-Signature"
+	v4ExpiresParam          = "X-Amz-Expires"
+	v4service               = "s3"
+	// v4MaxExpires is the longest validity of a presigned URL
+	v4MaxExpires = 7 * 24 * time.Hour
 )
 
 var (
@@ -46,6 +50,10 @@ type V4Auth struct {
 	SignedHeaders       []string
 	SignedHeadersString string
 	Signature           string
+	// Presigned is set for requests signed by their query parameters, which are valid for
+	// Expires from their date
+	Presigned bool
+	Expires   time.Duration
 }
 
 func (a V4Auth) GetAccessKeyID() string {
@@ -116,9 +124,75 @@ func ParseV4AuthContext(r *http.Request) (V4Auth, error) {
 	headers := splitHeaders(ctx.SignedHeadersString)
 	ctx.SignedHeaders = headers
 	ctx.Signature = query.Get(v4SignatureHeader)
+
+	// presigned requests must expire
+	ctx.Presigned = true
+	expires := query.Get(v4ExpiresParam)
+	if len(expires) == 0 {
+		return ctx, errors.ErrInvalidQueryParams
+	}
+	expiresSeconds, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ctx, errors.ErrMalformedExpires
+	}
+	if expiresSeconds < 0 {
+		return ctx, errors.ErrNegativeExpires
+	}
+	ctx.Expires = time.Duration(expiresSeconds) * time.Second
+	if ctx.Expires > v4MaxExpires {
+		return ctx, errors.ErrMaximumExpires
+	}
 	return ctx, nil
 }
 
+// V4Presign returns u signed with creds for requests with method, as a presigned URL valid
+// from signTime for expires.  Only the host header is signed, and the payload is not.
+func V4Presign(u *url.URL, method string, creds *model.Credential, region string, signTime time.Time, expires time.Duration) (*url.URL, error) {
+	if expires <= 0 {
+		return nil, errors.ErrNegativeExpires
+	}
+	if expires > v4MaxExpires {
+		return nil, errors.ErrMaximumExpires
+	}
+	signTime = signTime.UTC()
+	auth := V4Auth{
+		AccessKeyID:         creds.AccessKeyID,
+		Date:                signTime.Format(v4shortTimeFormat),
+		Region:              region,
+		Service:             v4service,
+		SignedHeaders:       []string{"host"},
+		SignedHeadersString: "host",
+		Presigned:           true,
+		Expires:             expires,
+	}
+	presigned := *u
+	query := presigned.Query()
+	query.Set("X-Amz-Algorithm", v4authHeaderPrefix)
+	query.Set("X-Amz-Credential", strings.Join([]string{auth.AccessKeyID, auth.Date, auth.Region, auth.Service, v4scopeTerminator}, "/"))
+	query.Set("X-Amz-Date", signTime.Format(v4timeFormat))
+	query.Set(v4ExpiresParam, strconv.FormatInt(int64(expires/time.Second), 10))
+	query.Set("X-Amz-SignedHeaders", auth.SignedHeadersString)
+	presigned.RawQuery = query.Encode()
+	ctx := &verificationCtx{
+		Request: &http.Request{
+			Method: method,
+			URL:    &presigned,
+			Host:   presigned.Host,
+			Header: make(http.Header),
+		},
+		Query:     query,
+		AuthValue: auth,
+	}
+	stringToSign, err := ctx.buildSignedString(ctx.buildCanonicalRequest())
+	if err != nil {
+		return nil, err
+	}
+	signingKey := createSignature(creds.AccessSecretKey, auth.Date, auth.Region, auth.Service)
+	query.Set(v4SignatureHeader, hex.EncodeToString(sign(signingKey, stringToSign)))
+	presigned.RawQuery = query.Encode()
+	return &presigned, nil
+}
+
 func V4Verify(auth V4Auth, credentials *model.Credential, r *http.Request) error {
 	ctx := &verificationCtx{
 		Request:   r,
@@ -140,6 +214,12 @@ func V4Verify(auth V4Auth, credentials *model.Credential, r *http.Request) error
 		return errors.ErrSignatureDoesNotMatch
 	}
 
+	if auth.Presigned {
+		if err := ctx.verifyExpiration(time.Now()); err != nil {
+			return err
+		}
+	}
+
 	// wrap body with verifier
 	reader, err := ctx.reader(r.Body, credentials)
 	if err != nil {
@@ -288,6 +368,25 @@ func (ctx *verificationCtx) getAmzDate() (string, error) {
 	return amzDate, nil
 }
 
+// verifyExpiration checks that a presigned request is valid at now.
+func (ctx *verificationCtx) verifyExpiration(now time.Time) error {
+	amzDate, err := ctx.getAmzDate()
+	if err != nil {
+		return err
+	}
+	signTime, err := time.Parse(v4timeFormat, amzDate)
+	if err != nil {
+		return errors.ErrMalformedDate
+	}
+	if now.Before(signTime) {
+		return errors.ErrRequestNotReadyYet
+	}
+	if now.After(signTime.Add(ctx.AuthValue.Expires)) {
+		return errors.ErrExpiredPresignRequest
+	}
+	return nil
+}
+
 func sign(key []byte, msg string) []byte {
 	h := hmac.New(sha256.New, key)
 	_, _ = h.Write([]byte(msg))
@@ -5,6 +5,7 @@ go 1.15
 require (
 	cloud.google.com/go v0.74.0
 	cloud.google.com/go/storage v1.12.0
+	github.com/Azure/azure-storage-blob-go v0.13.0
 	github.com/Masterminds/squirrel v1.5.0
 	github.com/Microsoft/go-winio v0.4.16 // indirect
 	github.com/apache/thrift v0.13.0
@@ -83,5 +84,6 @@ require (
 	google.golang.org/api v0.36.0
 	google.golang.org/protobuf v1.25.0
 	gopkg.in/dgrijalva/jwt-go.v3 v3.2.0
+	gopkg.in/yaml.v2 v2.4.0
 	pgregory.net/rapid v0.4.0 // indirect
 )
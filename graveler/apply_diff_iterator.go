@@ -0,0 +1,128 @@
+package graveler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+)
+
+// applyDiffIterator iterates over the changes that bring keys from their values in a left
+// metaRange to their values in a right metaRange, to be applied on top of a branch head.
+// Keys whose value on the branch head is no longer their left value are conflicts: they are
+// skipped and reported by Err once the iteration is done.
+type applyDiffIterator struct {
+	ctx              context.Context
+	committedManager CommittedManager
+	diffs            DiffIterator
+	storageNamespace StorageNamespace
+	leftMetaRangeID  MetaRangeID
+	headMetaRangeID  MetaRangeID
+	value            *ValueRecord
+	conflicts        []Key
+	err              error
+}
+
+// newApplyDiffIterator returns an iterator applying diffs, from leftMetaRangeID to some
+// right metaRange, on top of headMetaRangeID.
+func newApplyDiffIterator(ctx context.Context, manager CommittedManager, diffs DiffIterator, sn StorageNamespace, leftMetaRangeID, headMetaRangeID MetaRangeID) *applyDiffIterator {
+	return &applyDiffIterator{
+		ctx:              ctx,
+		committedManager: manager,
+		diffs:            diffs,
+		storageNamespace: sn,
+		leftMetaRangeID:  leftMetaRangeID,
+		headMetaRangeID:  headMetaRangeID,
+	}
+}
+
+// get returns the value of key in metaRangeID, or nil if it does not exist
+func (a *applyDiffIterator) get(metaRangeID MetaRangeID, key Key) (*Value, error) {
+	value, err := a.committedManager.Get(a.ctx, a.storageNamespace, metaRangeID, key)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return value, err
+}
+
+func sameValue(a, b *Value) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return bytes.Equal(a.Identity, b.Identity)
+}
+
+// leftValue returns the value of the key of diff on the left side, nil if it did not exist
+func (a *applyDiffIterator) leftValue(diff *Diff) (*Value, error) {
+	switch diff.Type {
+	case DiffTypeAdded:
+		return nil, nil
+	case DiffTypeRemoved:
+		return diff.Value, nil
+	default:
+		return a.get(a.leftMetaRangeID, diff.Key)
+	}
+}
+
+// rightValue returns the value of the key of diff on the right side, nil if it does not exist
+func rightValue(diff *Diff) *Value {
+	if diff.Type == DiffTypeRemoved {
+		return nil
+	}
+	return diff.Value
+}
+
+func (a *applyDiffIterator) Next() bool {
+	a.value = nil
+	if a.err != nil {
+		return false
+	}
+	for a.diffs.Next() {
+		diff := a.diffs.Value()
+		current, err := a.get(a.headMetaRangeID, diff.Key)
+		if err != nil {
+			a.err = err
+			return false
+		}
+		left, err := a.leftValue(diff)
+		if err != nil {
+			a.err = err
+			return false
+		}
+		right := rightValue(diff)
+		switch {
+		case sameValue(current, right):
+			// already applied
+			continue
+		case !sameValue(current, left):
+			a.conflicts = append(a.conflicts, diff.Key)
+			continue
+		}
+		a.value = &ValueRecord{Key: diff.Key, Value: right}
+		return true
+	}
+	if err := a.diffs.Err(); err != nil {
+		a.err = err
+	} else if len(a.conflicts) > 0 {
+		a.err = &ConflictError{Keys: a.conflicts}
+	}
+	return false
+}
+
+func (a *applyDiffIterator) SeekGE(id Key) {
+	a.value = nil
+	a.err = nil
+	a.conflicts = nil
+	a.diffs.SeekGE(id)
+}
+
+func (a *applyDiffIterator) Value() *ValueRecord {
+	return a.value
+}
+
+func (a *applyDiffIterator) Err() error {
+	return a.err
+}
+
+func (a *applyDiffIterator) Close() {
+	a.diffs.Close()
+}
@@ -10,6 +10,8 @@ import (
 // allow concurrent writers to branch
 // metadata update commands will wait until all current running writers are done
 // while metadata update is running or waiting to run, writes and metadata update commands will be blocked
+// a commit is a metadata update that only needs to wait for writers until it seals the staging token:
+// writes arriving while it waits will wait for the seal, and writes after it are allowed
 type branchLocker struct {
 	locker   sync.Locker
 	c        *sync.Cond
@@ -19,6 +21,8 @@ type branchLocker struct {
 type branchLockerData struct {
 	writers        int
 	metadataUpdate bool
+	commit         bool
+	sealing        bool
 }
 
 func NewBranchLocker() branchLocker {
@@ -31,16 +35,26 @@ func NewBranchLocker() branchLocker {
 }
 
 // AquireWrite returns a cancel function to release if write is currently available
-// returns ErrBranchUpdateInProgress if metadata update is currently in progress
+// Will wait while a commit is sealing the staging token
+// returns ErrBranchUpdateInProgress if metadata update other than commit is currently in progress
 func (l *branchLocker) AquireWrite(repositoryID RepositoryID, branchID BranchID) (func(), error) {
 	key := formatBranchLockerKey(repositoryID, branchID)
 	l.locker.Lock()
 	defer l.locker.Unlock()
-	data := l.branches[key]
-	if data == nil {
-		data = &branchLockerData{}
-		l.branches[key] = data
-	} else if data.metadataUpdate {
+	var data *branchLockerData
+	for {
+		data = l.branches[key]
+		if data == nil {
+			data = &branchLockerData{}
+			l.branches[key] = data
+			break
+		}
+		if !data.sealing {
+			break
+		}
+		l.c.Wait()
+	}
+	if data.metadataUpdate && !data.commit {
 		return nil, ErrBranchLocked
 	}
 	data.writers++
@@ -91,6 +105,55 @@ func (l *branchLocker) releaseMetadataUpdate(key string) {
 	delete(l.branches, key)
 }
 
+// AquireCommit returns a sealed function to call once the staging token was sealed, and a
+// cancel function to release when the commit is done
+// Will wait until all current writers are done, new writers wait until sealed is called
+// returns ErrBranchUpdateInProgress if metadata update is currently in progress
+func (l *branchLocker) AquireCommit(repositoryID RepositoryID, branchID BranchID) (func(), func(), error) {
+	key := formatBranchLockerKey(repositoryID, branchID)
+	l.locker.Lock()
+	defer l.locker.Unlock()
+	data := l.branches[key]
+	if data == nil {
+		data = &branchLockerData{}
+		l.branches[key] = data
+	} else if data.metadataUpdate {
+		// allow just one metadata update at a time
+		return nil, nil, ErrBranchLocked
+	}
+	// wait until all writers will leave
+	data.metadataUpdate = true
+	data.commit = true
+	data.sealing = true
+	for data.writers > 0 {
+		l.c.Wait()
+	}
+	return func() { l.sealCommit(data) }, func() { l.releaseCommit(key, data) }, nil
+}
+
+func (l *branchLocker) sealCommit(data *branchLockerData) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+	if data.sealing {
+		data.sealing = false
+		l.c.Broadcast()
+	}
+}
+
+func (l *branchLocker) releaseCommit(key string, data *branchLockerData) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+	if data.sealing {
+		data.sealing = false
+		l.c.Broadcast()
+	}
+	data.metadataUpdate = false
+	data.commit = false
+	if data.writers == 0 {
+		delete(l.branches, key)
+	}
+}
+
 func formatBranchLockerKey(repositoryID RepositoryID, branchID BranchID) string {
 	return fmt.Sprintf("%s/%s", repositoryID, branchID)
 }
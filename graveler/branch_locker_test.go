@@ -80,3 +80,59 @@ func TestBranchLock(t *testing.T) {
 	tu.MustDo(t, "acquire write after metadata update", err)
 	closeWrite()
 }
+
+func TestBranchLock_Commit(t *testing.T) {
+	bl := graveler.NewBranchLocker()
+
+	closeWrite, err := bl.AquireWrite("a", testutil.DefaultBranchID)
+	tu.MustDo(t, "acquire write", err)
+
+	type commitLock struct {
+		sealed, cancel func()
+		err            error
+	}
+	commitCh := make(chan commitLock, 1)
+	go func() {
+		sealed, cancel, err := bl.AquireCommit("a", testutil.DefaultBranchID)
+		commitCh <- commitLock{sealed: sealed, cancel: cancel, err: err}
+	}()
+
+	// writers arriving while the commit waits to seal wait instead of failing
+	writeCh := make(chan error, 1)
+	go func() {
+		closeWrite, err := bl.AquireWrite("a", testutil.DefaultBranchID)
+		if err == nil {
+			closeWrite()
+		}
+		writeCh <- err
+	}()
+
+	// release the last writer to let the commit in
+	closeWrite()
+	commit := <-commitCh
+	tu.MustDo(t, "acquire commit", commit.err)
+
+	// metadata updates can't run during a commit
+	_, err = bl.AquireMetadataUpdate("a", testutil.DefaultBranchID)
+	if !errors.Is(err, graveler.ErrBranchLocked) {
+		t.Fatal("metadata update should get branch locked during commit")
+	}
+	_, _, err = bl.AquireCommit("a", testutil.DefaultBranchID)
+	if !errors.Is(err, graveler.ErrBranchLocked) {
+		t.Fatal("commit should get branch locked during commit")
+	}
+
+	// once sealed, the pending writer and new writers are allowed
+	commit.sealed()
+	tu.MustDo(t, "pending write after seal", <-writeCh)
+	closeWrite, err = bl.AquireWrite("a", testutil.DefaultBranchID)
+	tu.MustDo(t, "acquire write during commit", err)
+
+	commit.cancel()
+	closeWrite()
+
+	// after the commit and the writer are done metadata updates are allowed again
+	closeMetadataUpdate, err := bl.AquireMetadataUpdate("a", testutil.DefaultBranchID)
+	tu.MustDo(t, "acquire metadata update after commit", err)
+	closeMetadataUpdate()
+}
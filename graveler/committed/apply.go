@@ -46,7 +46,7 @@ func Apply(ctx context.Context, writer MetaRangeWriter, source Iterator, diffs g
 			} else if c > 0 {
 				// internal error but no data lost: deletion requested of a
 				// file that was not there.
-				logger.WithField("id", string(diffValue.Identity)).Warn("[I] unmatched delete")
+				logger.WithField("key", string(diffValue.Key)).Warn("[I] unmatched delete")
 			}
 		}
 		if c >= 0 {
@@ -78,12 +78,18 @@ func Apply(ctx context.Context, writer MetaRangeWriter, source Iterator, diffs g
 		if diffValue.IsTombstone() {
 			// internal error but no data lost: deletion requested of a
 			// file that was not there.
-			logger.WithField("id", string(diffValue.Identity)).Warn("[I] unmatched delete")
+			logger.WithField("key", string(diffValue.Key)).Warn("[I] unmatched delete")
 			continue
 		}
 		if err := writer.WriteRecord(*diffValue); err != nil {
 			return fmt.Errorf("write added record: %w", err)
 		}
 	}
+	if err := source.Err(); err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	if err := diffs.Err(); err != nil {
+		return fmt.Errorf("diffs: %w", err)
+	}
 	return nil
 }
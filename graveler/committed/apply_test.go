@@ -2,6 +2,7 @@ package committed_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -160,3 +161,23 @@ func TestApplyCopiesLeftoverSources(t *testing.T) {
 
 	assert.NoError(t, committed.Apply(context.Background(), writer, source, diffs))
 }
+
+func TestApplySourceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errSource := errors.New("source failed")
+	source := testutil.NewFakeIterator()
+	source.
+		AddRange(&committed.Range{ID: "one", MaxKey: committed.Key("cz")}).
+		AddValueRecords(makeV("a", "source:a"))
+	source.SetErr(errSource)
+	diffs := testutil.NewValueIteratorFake(nil)
+
+	writer := mock.NewMockMetaRangeWriter(ctrl)
+
+	err := committed.Apply(context.Background(), writer, source, diffs)
+	if !errors.Is(err, errSource) {
+		t.Errorf("Apply err=%v, expected %v", err, errSource)
+	}
+}
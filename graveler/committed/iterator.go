@@ -1,13 +1,17 @@
 package committed
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/treeverse/lakefs/graveler"
 )
 
 type iterator struct {
 	started   bool
 	manager   RangeManager
-	ranges    []Range
+	rangesAll []Range // all ranges, used for seeking
+	ranges    []Range // ranges not yet iterated, starting at the current range
 	it        graveler.ValueIterator
 	err       error
 	namespace Namespace
@@ -17,6 +21,7 @@ func NewIterator(manager RangeManager, namespace Namespace, ranges []Range) Iter
 	return &iterator{
 		manager:   manager,
 		namespace: namespace,
+		rangesAll: ranges,
 		ranges:    ranges,
 	}
 }
@@ -26,8 +31,10 @@ func (rvi *iterator) NextRange() bool {
 		return false
 	}
 	rvi.ranges = rvi.ranges[1:]
-	rvi.it.Close()
-	rvi.it = nil
+	if rvi.it != nil {
+		rvi.it.Close()
+		rvi.it = nil
+	}
 	return true
 }
 
@@ -48,7 +55,7 @@ func (rvi *iterator) Next() bool {
 		return false // Iteration was already finished.
 	}
 	var err error
-	rvi.it, err = rvi.newRangeIterator(rvi.ranges[0].ID)
+	rvi.it, err = rvi.newRangeIterator(rvi.ranges[0].ID, nil)
 	if err != nil {
 		rvi.err = err
 		return false
@@ -88,14 +95,69 @@ func (rvi *iterator) Close() {
 	rvi.it.Close()
 }
 
+// SeekGE skips all ranges that end before id and positions inside the first remaining range,
+// so that the following Next returns the first value >= id.
 func (rvi *iterator) SeekGE(id graveler.Key) {
-	panic("implement me")
+	rvi.Close()
+	rvi.it = nil
+	rvi.err = nil
+	rvi.started = true
+	idx := sort.Search(len(rvi.rangesAll), func(i int) bool {
+		return bytes.Compare(rvi.rangesAll[i].MaxKey, id) >= 0
+	})
+	rvi.ranges = rvi.rangesAll[idx:]
+	if len(rvi.ranges) == 0 {
+		return
+	}
+	it, err := rvi.newRangeIterator(rvi.ranges[0].ID, Key(id))
+	if err != nil {
+		rvi.err = err
+		rvi.ranges = nil
+		return
+	}
+	rvi.it = it
 }
 
-func (rvi *iterator) newRangeIterator(rangeID ID) (graveler.ValueIterator, error) {
-	it, err := rvi.manager.NewRangeIterator(rvi.namespace, rangeID, nil)
+func (rvi *iterator) newRangeIterator(rangeID ID, from Key) (graveler.ValueIterator, error) {
+	it, err := rvi.manager.NewRangeIterator(rvi.namespace, rangeID, from)
 	if err != nil {
 		return nil, err
 	}
 	return NewUnmarshalIterator(it), nil
 }
+
+// valueIterator is a graveler.ValueIterator over all values of an Iterator, skipping Range
+// headers.
+type valueIterator struct {
+	it Iterator
+}
+
+func NewValueIterator(it Iterator) graveler.ValueIterator {
+	return &valueIterator{it: it}
+}
+
+func (v *valueIterator) Next() bool {
+	for v.it.Next() {
+		if value, _ := v.it.Value(); value != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *valueIterator) SeekGE(id graveler.Key) {
+	v.it.SeekGE(id)
+}
+
+func (v *valueIterator) Value() *graveler.ValueRecord {
+	value, _ := v.it.Value()
+	return value
+}
+
+func (v *valueIterator) Err() error {
+	return v.it.Err()
+}
+
+func (v *valueIterator) Close() {
+	v.it.Close()
+}
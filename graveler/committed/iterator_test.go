@@ -126,3 +126,73 @@ func TestIterator(t *testing.T) {
 		})
 	}
 }
+
+func TestIteratorNextRangeAtHeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	manager := mock.NewMockRangeManager(ctrl)
+
+	namespace := committed.Namespace("ns")
+	ranges := []committed.Range{{ID: one}, {ID: two}}
+	manager.EXPECT().NewRangeIterator(gomock.Eq(namespace), two, nil).Return(makeRange(makeKeys("b1")), nil)
+	pvi := committed.NewIterator(manager, namespace, ranges)
+
+	// skip range one without entering it
+	assert.True(t, pvi.Next())
+	assert.True(t, pvi.NextRange())
+	record, rng := pvi.Value()
+	assert.Nil(t, record)
+	assert.Equal(t, two, rng.ID)
+	assert.True(t, pvi.Next())
+	record, _ = pvi.Value()
+	assert.Equal(t, graveler.Key("b1"), record.Key)
+	assert.False(t, pvi.Next())
+	assert.NoError(t, pvi.Err())
+}
+
+func TestIteratorSeekGE(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	manager := mock.NewMockRangeManager(ctrl)
+
+	namespace := committed.Namespace("ns")
+	ranges := []committed.Range{
+		{ID: one, MaxKey: committed.Key("a3")},
+		{ID: two, MaxKey: committed.Key("b3")},
+		{ID: three, MaxKey: committed.Key("c3")},
+	}
+	// range manager seeks inside the range it opens
+	manager.EXPECT().NewRangeIterator(gomock.Eq(namespace), two, committed.Key("b2")).Return(makeRange(makeKeys("b2", "b3")), nil)
+	manager.EXPECT().NewRangeIterator(gomock.Eq(namespace), three, nil).Return(makeRange(makeKeys("c1", "c3")), nil)
+
+	it := committed.NewIterator(manager, namespace, ranges)
+	it.SeekGE(graveler.Key("b2"))
+	if v, _ := it.Value(); v != nil {
+		t.Fatalf("expected no value after SeekGE, got %+v", v)
+	}
+	assert.Equal(t, []rangeKeys{
+		{Name: two, Keys: makeKeys("b2", "b3")},
+		{Name: three, Keys: makeKeys("c1", "c3")},
+	}, keysBySeekedRanges(t, it, two))
+}
+
+// keysBySeekedRanges is like keysByRanges for an iterator that was seeked into the middle of
+// the range first.
+func keysBySeekedRanges(t testing.TB, it committed.Iterator, first committed.ID) []rangeKeys {
+	t.Helper()
+	ret := []rangeKeys{{Name: first}}
+	for it.Next() {
+		v, p := it.Value()
+		require.True(t, p != nil, "iterated past end, it = %+v", it)
+		if v == nil {
+			ret = append(ret, rangeKeys{Name: p.ID})
+		} else {
+			p := &ret[len(ret)-1]
+			p.Keys = append(p.Keys, v.Key)
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Error(err)
+	}
+	return ret
+}
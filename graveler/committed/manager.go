@@ -1,6 +1,7 @@
 package committed
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
@@ -14,20 +15,44 @@ type committedManager struct {
 	logger           logging.Logger
 }
 
-func NewCommittedManager() graveler.CommittedManager {
-	return &committedManager{}
+func NewCommittedManager(m MetaRangeManager) graveler.CommittedManager {
+	return &committedManager{
+		metaRangeManager: m,
+		logger:           logging.Default().WithField("service_name", "committed_manager"),
+	}
+}
+
+// metaRange is the graveler.MetaRange returned by committedManager
+type metaRange struct {
+	id graveler.MetaRangeID
+}
+
+func (m *metaRange) ID() graveler.MetaRangeID {
+	return m.id
 }
 
-func (c *committedManager) GetMetaRange(ns graveler.StorageNamespace, rangeID graveler.MetaRangeID) (graveler.MetaRange, error) {
-	panic("implement me")
+func (c *committedManager) GetMetaRange(ns graveler.StorageNamespace, metaRangeID graveler.MetaRangeID) (graveler.MetaRange, error) {
+	mr, err := c.metaRangeManager.GetMetaRange(ns, metaRangeID)
+	if err != nil {
+		return nil, err
+	}
+	return &metaRange{id: mr.ID}, nil
 }
 
 func (c *committedManager) Get(ctx context.Context, ns graveler.StorageNamespace, rangeID graveler.MetaRangeID, key graveler.Key) (*graveler.Value, error) {
-	panic("implement me")
+	record, err := c.metaRangeManager.GetValue(ns, rangeID, key)
+	if err != nil {
+		return nil, err
+	}
+	return record.Value, nil
 }
 
 func (c *committedManager) List(ctx context.Context, ns graveler.StorageNamespace, rangeID graveler.MetaRangeID) (graveler.ValueIterator, error) {
-	panic("implement me")
+	it, err := c.metaRangeManager.NewMetaRangeIterator(ns, rangeID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewValueIterator(it), nil
 }
 
 func (c *committedManager) WriteMetaRange(ctx context.Context, ns graveler.StorageNamespace, it graveler.ValueIterator) (*graveler.MetaRangeID, error) {
@@ -66,10 +91,147 @@ func (c *committedManager) Diff(ctx context.Context, ns graveler.StorageNamespac
 	return NewDiffIterator(leftIt, rightIt), nil
 }
 
-func (c *committedManager) Merge(ctx context.Context, ns graveler.StorageNamespace, left, right, base graveler.MetaRangeID, committer string, message string, metadata graveler.Metadata) (graveler.MetaRangeID, error) {
-	panic("implement me")
+func (c *committedManager) PrefixChanged(ctx context.Context, ns graveler.StorageNamespace, left, right graveler.MetaRangeID, prefix graveler.Key) (bool, error) {
+	if left == right {
+		return false, nil
+	}
+	if left == "" {
+		return c.hasPrefix(ns, right, prefix)
+	}
+	if right == "" {
+		return c.hasPrefix(ns, left, prefix)
+	}
+	leftRangeIDs, err := c.prefixRangeIDs(ns, left, prefix)
+	if err != nil {
+		return false, fmt.Errorf("left: %w", err)
+	}
+	rightRangeIDs, err := c.prefixRangeIDs(ns, right, prefix)
+	if err != nil {
+		return false, fmt.Errorf("right: %w", err)
+	}
+	if equalRangeIDs(leftRangeIDs, rightRangeIDs) {
+		// the same ranges hold any key with prefix on both sides
+		return false, nil
+	}
+	it, err := c.Diff(ctx, ns, left, right)
+	if err != nil {
+		return false, err
+	}
+	defer it.Close()
+	it.SeekGE(prefix)
+	if !it.Next() {
+		return false, it.Err()
+	}
+	return bytes.HasPrefix(it.Value().Key, prefix), nil
+}
+
+// prefixRangeIDs returns the IDs of the ranges of metaRangeID whose min and max keys allow them
+// to hold a key starting with prefix.
+func (c *committedManager) prefixRangeIDs(ns graveler.StorageNamespace, metaRangeID graveler.MetaRangeID, prefix graveler.Key) ([]ID, error) {
+	mr, err := c.metaRangeManager.GetMetaRange(ns, metaRangeID)
+	if err != nil {
+		return nil, err
+	}
+	upperBound := graveler.UpperBoundForPrefix(prefix)
+	var ids []ID
+	for _, rng := range mr.Ranges {
+		if bytes.Compare(rng.MaxKey, prefix) < 0 {
+			continue
+		}
+		if upperBound != nil && bytes.Compare(rng.MinKey, upperBound) >= 0 {
+			break
+		}
+		ids = append(ids, rng.ID)
+	}
+	return ids, nil
+}
+
+// hasPrefix returns true if metaRangeID holds a key starting with prefix
+func (c *committedManager) hasPrefix(ns graveler.StorageNamespace, metaRangeID graveler.MetaRangeID, prefix graveler.Key) (bool, error) {
+	it, err := c.metaRangeManager.NewMetaRangeIterator(ns, metaRangeID, prefix)
+	if err != nil {
+		return false, err
+	}
+	values := NewValueIterator(it)
+	defer values.Close()
+	if !values.Next() {
+		return false, values.Err()
+	}
+	return bytes.HasPrefix(values.Value().Key, prefix), nil
+}
+
+func equalRangeIDs(a, b []ID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *committedManager) Merge(ctx context.Context, ns graveler.StorageNamespace, left, right, base graveler.MetaRangeID, committer string, message string, metadata graveler.Metadata, strategy graveler.MergeStrategy) (graveler.MetaRangeID, error) {
+	switch {
+	case left == right || left == base:
+		// nothing to merge from left
+		return right, nil
+	case right == base:
+		// fast-forward
+		return left, nil
+	}
+	baseIt, err := c.metaRangeManager.NewMetaRangeIterator(ns, base, nil)
+	if err != nil {
+		return "", fmt.Errorf("base: %w", err)
+	}
+	defer baseIt.Close()
+	leftIt, err := c.metaRangeManager.NewMetaRangeIterator(ns, left, nil)
+	if err != nil {
+		return "", fmt.Errorf("left: %w", err)
+	}
+	defer leftIt.Close()
+	rightIt, err := c.metaRangeManager.NewMetaRangeIterator(ns, right, nil)
+	if err != nil {
+		return "", fmt.Errorf("right: %w", err)
+	}
+	defer rightIt.Close()
+
+	writer := c.metaRangeManager.NewWriter(ns)
+	defer func() {
+		if err := writer.Abort(); err != nil {
+			c.logger.Errorf("Aborting write to meta range: %w", err)
+		}
+	}()
+	if err := Merge(ctx, writer, baseIt, leftIt, rightIt, strategy); err != nil {
+		return "", err
+	}
+	id, err := writer.Close()
+	if err != nil {
+		return "", fmt.Errorf("closing writer: %w", err)
+	}
+	return *id, nil
 }
 
 func (c *committedManager) Apply(ctx context.Context, ns graveler.StorageNamespace, rangeID graveler.MetaRangeID, iterator graveler.ValueIterator) (graveler.MetaRangeID, error) {
-	panic("implement me")
+	source, err := c.metaRangeManager.NewMetaRangeIterator(ns, rangeID, nil)
+	if err != nil {
+		return "", fmt.Errorf("source: %w", err)
+	}
+	defer source.Close()
+
+	writer := c.metaRangeManager.NewWriter(ns)
+	defer func() {
+		if err := writer.Abort(); err != nil {
+			c.logger.Errorf("Aborting write to meta range: %w", err)
+		}
+	}()
+	if err := Apply(ctx, writer, source, iterator); err != nil {
+		return "", err
+	}
+	id, err := writer.Close()
+	if err != nil {
+		return "", fmt.Errorf("closing writer: %w", err)
+	}
+	return *id, nil
 }
@@ -0,0 +1,315 @@
+package committed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/graveler"
+)
+
+// mergeIterator tracks the position of one of the iterators taking part in a merge.
+type mergeIterator struct {
+	it     Iterator
+	ok     bool
+	record *graveler.ValueRecord
+	rng    *Range
+}
+
+func newMergeIterator(it Iterator) *mergeIterator {
+	m := &mergeIterator{it: it}
+	m.next()
+	return m
+}
+
+func (m *mergeIterator) update(ok bool) {
+	m.ok = ok
+	if ok {
+		m.record, m.rng = m.it.Value()
+	} else {
+		m.record, m.rng = nil, nil
+	}
+}
+
+func (m *mergeIterator) next() {
+	m.update(m.it.Next())
+}
+
+func (m *mergeIterator) nextRange() {
+	m.update(m.it.NextRange())
+}
+
+// atRange returns true if the iterator is at the header of a range that it did not enter.
+func (m *mergeIterator) atRange() bool {
+	return m.ok && m.record == nil
+}
+
+// key returns the current key, or the minimal key of the current range when at a header.
+func (m *mergeIterator) key() graveler.Key {
+	if m.record == nil {
+		return graveler.Key(m.rng.MinKey)
+	}
+	return m.record.Key
+}
+
+// compare orders iterators by current key, exhausted iterators last.
+func (m *mergeIterator) compare(o *mergeIterator) int {
+	switch {
+	case !m.ok && !o.ok:
+		return 0
+	case !m.ok:
+		return 1
+	case !o.ok:
+		return -1
+	default:
+		return bytes.Compare(m.key(), o.key())
+	}
+}
+
+// before returns true if the entire current range (or value) of m ends before the current
+// position of every iterator in others.
+func (m *mergeIterator) before(others ...*mergeIterator) bool {
+	end := m.key()
+	if m.atRange() {
+		end = graveler.Key(m.rng.MaxKey)
+	}
+	for _, o := range others {
+		if o.ok && bytes.Compare(end, o.key()) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func sameRange(a, b *mergeIterator) bool {
+	return a.atRange() && b.atRange() && a.rng.ID == b.rng.ID
+}
+
+func sameIdentity(a, b *graveler.ValueRecord) bool {
+	return bytes.Equal(a.Identity, b.Identity)
+}
+
+type merger struct {
+	writer    MetaRangeWriter
+	strategy  graveler.MergeStrategy
+	base      *mergeIterator
+	source    *mergeIterator
+	dest      *mergeIterator
+	conflicts []graveler.Key
+}
+
+// Merge writes to writer the result of a three-way merge of source into dest with the
+// common ancestor base.  Ranges that did not change on one side are handled in one step
+// without reading them, so the cost of a merge is proportional to the size of the changes.
+// Keys that both sides changed differently are resolved according to strategy: with
+// graveler.MergeStrategyFail Merge returns a *graveler.ConflictError holding all conflicting
+// keys.
+func Merge(ctx context.Context, writer MetaRangeWriter, base Iterator, source Iterator, dest Iterator, strategy graveler.MergeStrategy) error {
+	m := &merger{
+		writer:   writer,
+		strategy: strategy,
+		base:     newMergeIterator(base),
+		source:   newMergeIterator(source),
+		dest:     newMergeIterator(dest),
+	}
+	if err := m.merge(); err != nil {
+		return err
+	}
+	for _, it := range []Iterator{base, source, dest} {
+		if err := it.Err(); err != nil {
+			return err
+		}
+	}
+	if len(m.conflicts) > 0 {
+		return &graveler.ConflictError{Keys: m.conflicts}
+	}
+	return nil
+}
+
+func (m *merger) writeRange(rng *Range) error {
+	if len(m.conflicts) > 0 {
+		// merge will fail, just keep collecting conflicts
+		return nil
+	}
+	if err := m.writer.WriteRange(*rng); err != nil {
+		return fmt.Errorf("copy range %s: %w", rng.ID, err)
+	}
+	return nil
+}
+
+func (m *merger) writeRecord(record *graveler.ValueRecord) error {
+	if len(m.conflicts) > 0 {
+		return nil
+	}
+	if err := m.writer.WriteRecord(*record); err != nil {
+		return fmt.Errorf("write record: %w", err)
+	}
+	return nil
+}
+
+func (m *merger) merge() error {
+	for m.source.ok || m.dest.ok {
+		var err error
+		baseFirst := m.base.compare(m.source) < 0 && m.base.compare(m.dest) < 0
+		sourceFirst := m.source.compare(m.base) < 0 && m.source.compare(m.dest) < 0
+		destFirst := m.dest.compare(m.base) < 0 && m.dest.compare(m.source) < 0
+		switch {
+		case baseFirst:
+			// removed on both sides
+			if m.base.atRange() && m.base.before(m.source, m.dest) {
+				m.base.nextRange()
+			} else {
+				m.base.next()
+			}
+		case sourceFirst:
+			err = m.handleOneSide(m.source, m.base, m.dest)
+		case destFirst:
+			err = m.handleOneSide(m.dest, m.base, m.source)
+		case m.rangeAtMinKey():
+			err = m.handleRanges()
+		default:
+			err = m.handleRecords()
+		}
+		if err != nil {
+			return err
+		}
+		if m.source.it.Err() != nil || m.dest.it.Err() != nil || m.base.it.Err() != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+// handleOneSide handles the current position of it, which is before the positions of both
+// other iterators: it was added on that side.
+func (m *merger) handleOneSide(it *mergeIterator, others ...*mergeIterator) error {
+	if !it.atRange() {
+		if err := m.writeRecord(it.record); err != nil {
+			return err
+		}
+		it.next()
+		return nil
+	}
+	if !it.before(others...) {
+		it.next()
+		return nil
+	}
+	if err := m.writeRange(it.rng); err != nil {
+		return err
+	}
+	it.nextRange()
+	return nil
+}
+
+// handleRanges handles the case where at least two iterators are at the same key and at
+// least one of them is at the header of a range.  Identical ranges are skipped or copied
+// without being read, other ranges are entered.
+func (m *merger) handleRanges() error {
+	switch {
+	case sameRange(m.source, m.dest):
+		// same on both sides
+		if err := m.writeRange(m.source.rng); err != nil {
+			return err
+		}
+		m.source.nextRange()
+		m.dest.nextRange()
+	case sameRange(m.source, m.base):
+		// unchanged on source, dest values will be kept as they are
+		m.source.nextRange()
+		m.base.nextRange()
+	case sameRange(m.dest, m.base):
+		// unchanged on dest, source values will be taken as they are
+		m.dest.nextRange()
+		m.base.nextRange()
+	default:
+		key := m.minKey()
+		for _, it := range []*mergeIterator{m.base, m.source, m.dest} {
+			if it.atRange() && bytes.Equal(it.key(), key) {
+				it.next()
+			}
+		}
+	}
+	return nil
+}
+
+// rangeAtMinKey returns true if one of the iterators at the smallest key is at the header of
+// a range.  Ranges that start after the smallest key are not entered until it is handled.
+func (m *merger) rangeAtMinKey() bool {
+	key := m.minKey()
+	for _, it := range []*mergeIterator{m.base, m.source, m.dest} {
+		if it.atRange() && bytes.Equal(it.key(), key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *merger) minKey() graveler.Key {
+	var key graveler.Key
+	for _, it := range []*mergeIterator{m.base, m.source, m.dest} {
+		if it.ok && (key == nil || bytes.Compare(it.key(), key) < 0) {
+			key = it.key()
+		}
+	}
+	return key
+}
+
+// handleRecords resolves the smallest key when it appears in at least two of the iterators
+// and all of them are at values.  Iterators at the headers of later ranges do not take part.
+func (m *merger) handleRecords() error {
+	key := m.minKey()
+	at := func(it *mergeIterator) *graveler.ValueRecord {
+		if it.ok && !it.atRange() && bytes.Equal(it.record.Key, key) {
+			return it.record
+		}
+		return nil
+	}
+	base, source, dest := at(m.base), at(m.source), at(m.dest)
+
+	var err error
+	switch {
+	case source != nil && dest != nil && sameIdentity(source, dest):
+		// same change on both sides, or no change at all
+		err = m.writeRecord(dest)
+	case source != nil && dest != nil && base != nil && sameIdentity(source, base):
+		// changed only on dest
+		err = m.writeRecord(dest)
+	case source != nil && dest != nil && base != nil && sameIdentity(dest, base):
+		// changed only on source
+		err = m.writeRecord(source)
+	case source != nil && dest == nil && base != nil && sameIdentity(source, base):
+		// removed on dest
+	case source == nil && dest != nil && base != nil && sameIdentity(dest, base):
+		// removed on source
+	default:
+		err = m.handleConflict(key, source, dest)
+	}
+	if err != nil {
+		return err
+	}
+	for _, it := range []*mergeIterator{m.base, m.source, m.dest} {
+		if at(it) != nil {
+			it.next()
+		}
+	}
+	return nil
+}
+
+// handleConflict resolves a key changed differently on both sides according to the merge
+// strategy.  A nil source or dest means the key was removed on that side.
+func (m *merger) handleConflict(key graveler.Key, source, dest *graveler.ValueRecord) error {
+	var record *graveler.ValueRecord
+	switch m.strategy {
+	case graveler.MergeStrategySourceWins:
+		record = source
+	case graveler.MergeStrategyDestWins:
+		record = dest
+	default:
+		m.conflicts = append(m.conflicts, key)
+		return nil
+	}
+	if record == nil {
+		return nil
+	}
+	return m.writeRecord(record)
+}
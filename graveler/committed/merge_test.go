@@ -0,0 +1,191 @@
+package committed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/graveler/committed"
+	"github.com/treeverse/lakefs/graveler/committed/mock"
+	"github.com/treeverse/lakefs/graveler/testutil"
+)
+
+func TestMergeUnchangedRanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rangeOne := &committed.Range{ID: "one", MinKey: committed.Key("a"), MaxKey: committed.Key("c")}
+	rangeTwo := &committed.Range{ID: "two", MinKey: committed.Key("d"), MaxKey: committed.Key("f")}
+	rangeOneDest := &committed.Range{ID: "one:dest", MinKey: committed.Key("a"), MaxKey: committed.Key("c")}
+	rangeTwoSource := &committed.Range{ID: "two:source", MinKey: committed.Key("d"), MaxKey: committed.Key("f")}
+
+	base := testutil.NewFakeIterator().
+		AddRange(rangeOne).AddValueRecords(makeV("a", "base:a"), makeV("c", "base:c")).
+		AddRange(rangeTwo).AddValueRecords(makeV("d", "base:d"), makeV("f", "base:f"))
+	source := testutil.NewFakeIterator().
+		AddRange(rangeOne).AddValueRecords(makeV("a", "base:a"), makeV("c", "base:c")).
+		AddRange(rangeTwoSource).AddValueRecords(makeV("d", "source:d"), makeV("f", "base:f"))
+	dest := testutil.NewFakeIterator().
+		AddRange(rangeOneDest).AddValueRecords(makeV("a", "dest:a"), makeV("c", "base:c")).
+		AddRange(rangeTwo).AddValueRecords(makeV("d", "base:d"), makeV("f", "base:f"))
+
+	writer := mock.NewMockMetaRangeWriter(ctrl)
+	gomock.InOrder(
+		writer.EXPECT().WriteRange(gomock.Eq(*rangeOneDest)),
+		writer.EXPECT().WriteRange(gomock.Eq(*rangeTwoSource)),
+	)
+
+	assert.NoError(t, committed.Merge(context.Background(), writer, base, source, dest, graveler.MergeStrategyFail))
+	for name, it := range map[string]*testutil.FakeIterator{"base": base, "source": source, "dest": dest} {
+		assert.Equal(t, []int{0, 0}, it.ReadsByRange(), "%s reads by range", name)
+	}
+}
+
+func TestMergeSameRangeOnBothSides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rangeOne := &committed.Range{ID: "one", MinKey: committed.Key("a"), MaxKey: committed.Key("c")}
+	rangeTwo := &committed.Range{ID: "two", MinKey: committed.Key("d"), MaxKey: committed.Key("f")}
+
+	base := testutil.NewFakeIterator().
+		AddRange(rangeOne).AddValueRecords(makeV("a", "base:a"), makeV("c", "base:c"))
+	source := testutil.NewFakeIterator().
+		AddRange(rangeOne).AddValueRecords(makeV("a", "base:a"), makeV("c", "base:c")).
+		AddRange(rangeTwo).AddValueRecords(makeV("d", "new:d"), makeV("f", "new:f"))
+	dest := testutil.NewFakeIterator().
+		AddRange(rangeTwo).AddValueRecords(makeV("d", "new:d"), makeV("f", "new:f"))
+
+	writer := mock.NewMockMetaRangeWriter(ctrl)
+	writer.EXPECT().WriteRange(gomock.Eq(*rangeTwo))
+
+	assert.NoError(t, committed.Merge(context.Background(), writer, base, source, dest, graveler.MergeStrategyFail))
+	assert.Equal(t, []int{0, 0}, source.ReadsByRange())
+	assert.Equal(t, []int{0}, dest.ReadsByRange())
+}
+
+func TestMergeRecordsBeforeRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	base := testutil.NewFakeIterator().
+		AddRange(&committed.Range{ID: "base:a", MinKey: committed.Key("a"), MaxKey: committed.Key("a")}).
+		AddValueRecords(makeV("a", "base:a")).
+		AddRange(&committed.Range{ID: "base:c", MinKey: committed.Key("c"), MaxKey: committed.Key("c")}).
+		AddValueRecords(makeV("c", "base:c"))
+	source := testutil.NewFakeIterator().
+		AddRange(&committed.Range{ID: "source", MinKey: committed.Key("a"), MaxKey: committed.Key("c")}).
+		AddValueRecords(makeV("a", "base:a"), makeV("b", "new:b"), makeV("c", "base:c"))
+	dest := testutil.NewFakeIterator().
+		AddRange(&committed.Range{ID: "dest", MinKey: committed.Key("a"), MaxKey: committed.Key("c")}).
+		AddValueRecords(makeV("a", "base:a"), makeV("b", "new:b"), makeV("c", "base:c"))
+
+	writer := mock.NewMockMetaRangeWriter(ctrl)
+	gomock.InOrder(
+		writer.EXPECT().WriteRecord(gomock.Eq(*makeV("a", "base:a"))),
+		writer.EXPECT().WriteRecord(gomock.Eq(*makeV("b", "new:b"))),
+		writer.EXPECT().WriteRecord(gomock.Eq(*makeV("c", "base:c"))),
+	)
+
+	assert.NoError(t, committed.Merge(context.Background(), writer, base, source, dest, graveler.MergeStrategyFail))
+}
+
+func TestMergeRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	base := testutil.NewFakeIterator().
+		AddRange(&committed.Range{ID: "base", MinKey: committed.Key("a"), MaxKey: committed.Key("d")}).
+		AddValueRecords(makeV("a", "base:a"), makeV("b", "base:b"), makeV("c", "base:c"), makeV("d", "base:d"))
+	source := testutil.NewFakeIterator().
+		AddRange(&committed.Range{ID: "source", MinKey: committed.Key("a"), MaxKey: committed.Key("e")}).
+		AddValueRecords(makeV("a", "base:a"), makeV("b", "source:b"), makeV("d", "base:d"), makeV("e", "source:e"))
+	dest := testutil.NewFakeIterator().
+		AddRange(&committed.Range{ID: "dest", MinKey: committed.Key("a"), MaxKey: committed.Key("f")}).
+		AddValueRecords(makeV("a", "base:a"), makeV("b", "base:b"), makeV("c", "base:c"), makeV("d", "dest:d"), makeV("f", "dest:f"))
+
+	writer := mock.NewMockMetaRangeWriter(ctrl)
+	gomock.InOrder(
+		writer.EXPECT().WriteRecord(gomock.Eq(*makeV("a", "base:a"))),
+		writer.EXPECT().WriteRecord(gomock.Eq(*makeV("b", "source:b"))),
+		writer.EXPECT().WriteRecord(gomock.Eq(*makeV("d", "dest:d"))),
+		writer.EXPECT().WriteRecord(gomock.Eq(*makeV("e", "source:e"))),
+		writer.EXPECT().WriteRecord(gomock.Eq(*makeV("f", "dest:f"))),
+	)
+
+	assert.NoError(t, committed.Merge(context.Background(), writer, base, source, dest, graveler.MergeStrategyFail))
+}
+
+func TestMergeConflicts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	base := testutil.NewFakeIterator().
+		AddRange(&committed.Range{ID: "base", MinKey: committed.Key("a"), MaxKey: committed.Key("d")}).
+		AddValueRecords(makeV("a", "base:a"), makeV("b", "base:b"), makeV("d", "base:d"))
+	source := testutil.NewFakeIterator().
+		AddRange(&committed.Range{ID: "source", MinKey: committed.Key("a"), MaxKey: committed.Key("d")}).
+		AddValueRecords(makeV("a", "base:a"), makeV("b", "source:b"), makeV("c", "source:c"))
+	dest := testutil.NewFakeIterator().
+		AddRange(&committed.Range{ID: "dest", MinKey: committed.Key("a"), MaxKey: committed.Key("d")}).
+		AddValueRecords(makeV("a", "base:a"), makeV("b", "dest:b"), makeV("c", "dest:c"), makeV("d", "dest:d"))
+
+	writer := mock.NewMockMetaRangeWriter(ctrl)
+	writer.EXPECT().WriteRecord(gomock.Eq(*makeV("a", "base:a")))
+
+	err := committed.Merge(context.Background(), writer, base, source, dest, graveler.MergeStrategyFail)
+	if !errors.Is(err, graveler.ErrConflictFound) {
+		t.Fatalf("Merge err=%v, expected conflict found", err)
+	}
+	var conflictErr *graveler.ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("Merge err=%v, expected a ConflictError", err)
+	}
+	assert.Equal(t, []graveler.Key{graveler.Key("b"), graveler.Key("c"), graveler.Key("d")}, conflictErr.Keys)
+}
+
+func TestMergeConflictStrategies(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Strategy graveler.MergeStrategy
+		Expected []*graveler.ValueRecord
+	}{
+		{
+			Name:     "source wins",
+			Strategy: graveler.MergeStrategySourceWins,
+			Expected: []*graveler.ValueRecord{makeV("a", "base:a"), makeV("b", "source:b"), makeV("c", "source:c")},
+		}, {
+			Name:     "dest wins",
+			Strategy: graveler.MergeStrategyDestWins,
+			Expected: []*graveler.ValueRecord{makeV("a", "base:a"), makeV("b", "dest:b"), makeV("c", "dest:c"), makeV("d", "dest:d")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			base := testutil.NewFakeIterator().
+				AddRange(&committed.Range{ID: "base", MinKey: committed.Key("a"), MaxKey: committed.Key("d")}).
+				AddValueRecords(makeV("a", "base:a"), makeV("b", "base:b"), makeV("d", "base:d"))
+			source := testutil.NewFakeIterator().
+				AddRange(&committed.Range{ID: "source", MinKey: committed.Key("a"), MaxKey: committed.Key("d")}).
+				AddValueRecords(makeV("a", "base:a"), makeV("b", "source:b"), makeV("c", "source:c"))
+			dest := testutil.NewFakeIterator().
+				AddRange(&committed.Range{ID: "dest", MinKey: committed.Key("a"), MaxKey: committed.Key("d")}).
+				AddValueRecords(makeV("a", "base:a"), makeV("b", "dest:b"), makeV("c", "dest:c"), makeV("d", "dest:d"))
+
+			writer := mock.NewMockMetaRangeWriter(ctrl)
+			calls := make([]*gomock.Call, 0, len(tt.Expected))
+			for _, record := range tt.Expected {
+				calls = append(calls, writer.EXPECT().WriteRecord(gomock.Eq(*record)))
+			}
+			gomock.InOrder(calls...)
+
+			assert.NoError(t, committed.Merge(context.Background(), writer, base, source, dest, tt.Strategy))
+		})
+	}
+}
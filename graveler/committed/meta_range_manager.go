@@ -3,6 +3,10 @@ package committed
 //go:generate mockgen -source=meta_range_manager.go -destination=mock/meta_range_manager.go -package=mock
 
 import (
+	"bytes"
+	"fmt"
+	"sort"
+
 	"github.com/treeverse/lakefs/graveler"
 )
 
@@ -66,3 +70,92 @@ type MetaRangeWriter interface {
 
 	Abort() error
 }
+
+type metaRangeManager struct {
+	metaManager               RangeManager // For metaranges
+	rangeManager              RangeManager // For ranges
+	approximateRangeSizeBytes uint64
+}
+
+// NewMetaRangeManager returns a MetaRangeManager that stores MetaRanges using metaManager and
+// the Ranges they reference using rangeManager.
+func NewMetaRangeManager(metaManager, rangeManager RangeManager, approximateRangeSizeBytes uint64) MetaRangeManager {
+	return &metaRangeManager{
+		metaManager:               metaManager,
+		rangeManager:              rangeManager,
+		approximateRangeSizeBytes: approximateRangeSizeBytes,
+	}
+}
+
+func (m *metaRangeManager) GetMetaRange(ns graveler.StorageNamespace, metaRangeID graveler.MetaRangeID) (*MetaRange, error) {
+	it, err := m.metaManager.NewRangeIterator(Namespace(ns), ID(metaRangeID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("open metarange %s: %w", metaRangeID, err)
+	}
+	defer it.Close()
+
+	var ranges []Range
+	for it.Next() {
+		rng, err := valueToRange(it.Value().Value)
+		if err != nil {
+			return nil, fmt.Errorf("read range from metarange %s: %w", metaRangeID, err)
+		}
+		ranges = append(ranges, rng)
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("read metarange %s: %w", metaRangeID, err)
+	}
+	return &MetaRange{ID: metaRangeID, Ranges: ranges}, nil
+}
+
+func (m *metaRangeManager) GetValue(ns graveler.StorageNamespace, metaRangeID graveler.MetaRangeID, key graveler.Key) (*graveler.ValueRecord, error) {
+	mr, err := m.GetMetaRange(ns, metaRangeID)
+	if err != nil {
+		return nil, err
+	}
+	// ranges are sorted and do not overlap, the only range that may hold key is the first
+	// one ending at or after it.
+	ranges := mr.Ranges
+	idx := sort.Search(len(ranges), func(i int) bool {
+		return bytes.Compare(ranges[i].MaxKey, key) >= 0
+	})
+	if idx == len(ranges) || bytes.Compare(key, ranges[idx].MinKey) < 0 {
+		return nil, graveler.ErrNotFound
+	}
+	record, err := m.rangeManager.GetValue(Namespace(ns), Key(key), ranges[idx].ID)
+	if err != nil {
+		return nil, err
+	}
+	value, err := UnmarshalValue(record.Value)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal value for key %s: %w", key, err)
+	}
+	return &graveler.ValueRecord{
+		Key:   graveler.Key(record.Key),
+		Value: value,
+	}, nil
+}
+
+func (m *metaRangeManager) NewWriter(ns graveler.StorageNamespace) MetaRangeWriter {
+	return NewGeneralMetaRangeWriter(m.rangeManager, m.metaManager, m.approximateRangeSizeBytes, Namespace(ns))
+}
+
+func (m *metaRangeManager) NewMetaRangeIterator(ns graveler.StorageNamespace, metaRangeID graveler.MetaRangeID, from graveler.Key) (Iterator, error) {
+	mr, err := m.GetMetaRange(ns, metaRangeID)
+	if err != nil {
+		return nil, err
+	}
+	it := NewIterator(m.rangeManager, Namespace(ns), mr.Ranges)
+	if from != nil {
+		it.SeekGE(from)
+	}
+	return it, nil
+}
+
+func (m *metaRangeManager) NewRangeIterator(ns graveler.StorageNamespace, rangeID ID, from graveler.Key) (graveler.ValueIterator, error) {
+	it, err := m.rangeManager.NewRangeIterator(Namespace(ns), rangeID, Key(from))
+	if err != nil {
+		return nil, err
+	}
+	return NewUnmarshalIterator(it), nil
+}
@@ -0,0 +1,96 @@
+package committed_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/graveler/committed"
+	"github.com/treeverse/lakefs/graveler/committed/mock"
+	"github.com/treeverse/lakefs/graveler/testutil"
+)
+
+// makeMetaRange returns an iterator over the records of a metarange holding ranges
+func makeMetaRange(t testing.TB, ranges ...committed.Range) committed.ValueIterator {
+	t.Helper()
+	records := make([]committed.Record, 0, len(ranges))
+	for _, rng := range ranges {
+		data, err := committed.MarshalRange(rng)
+		require.NoError(t, err)
+		value, err := committed.MarshalValue(&graveler.Value{Identity: []byte(rng.ID), Data: data})
+		require.NoError(t, err)
+		records = append(records, committed.Record{Key: rng.MaxKey, Value: value})
+	}
+	return testutil.NewCommittedValueIteratorFake(records)
+}
+
+func TestMetaRangeManagerGetMetaRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ns := graveler.StorageNamespace("ns")
+	ranges := []committed.Range{
+		{ID: "one", MinKey: committed.Key("a"), MaxKey: committed.Key("c"), EstimatedSize: 10},
+		{ID: "two", MinKey: committed.Key("d"), MaxKey: committed.Key("f"), EstimatedSize: 20},
+	}
+	metaManager := mock.NewMockRangeManager(ctrl)
+	metaManager.EXPECT().NewRangeIterator(committed.Namespace(ns), committed.ID("meta"), nil).Return(makeMetaRange(t, ranges...), nil)
+	rangeManager := mock.NewMockRangeManager(ctrl)
+
+	sut := committed.NewMetaRangeManager(metaManager, rangeManager, 1024)
+	mr, err := sut.GetMetaRange(ns, "meta")
+	require.NoError(t, err)
+	assert.Equal(t, graveler.MetaRangeID("meta"), mr.ID)
+	assert.Equal(t, ranges, mr.Ranges)
+}
+
+func TestMetaRangeManagerGetValue(t *testing.T) {
+	ns := graveler.StorageNamespace("ns")
+	ranges := []committed.Range{
+		{ID: "one", MinKey: committed.Key("b"), MaxKey: committed.Key("d")},
+		{ID: "two", MinKey: committed.Key("h"), MaxKey: committed.Key("k")},
+	}
+	value := &graveler.Value{Identity: []byte("id"), Data: []byte("data")}
+
+	tests := []struct {
+		Name    string
+		Key     string
+		RangeID committed.ID
+	}{
+		{Name: "before first range", Key: "a"},
+		{Name: "first key in range", Key: "b", RangeID: "one"},
+		{Name: "inside range", Key: "c", RangeID: "one"},
+		{Name: "between ranges", Key: "e"},
+		{Name: "last key in range", Key: "k", RangeID: "two"},
+		{Name: "after last range", Key: "z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			metaManager := mock.NewMockRangeManager(ctrl)
+			metaManager.EXPECT().NewRangeIterator(committed.Namespace(ns), committed.ID("meta"), nil).Return(makeMetaRange(t, ranges...), nil)
+			rangeManager := mock.NewMockRangeManager(ctrl)
+			if tt.RangeID != "" {
+				rangeManager.EXPECT().
+					GetValue(committed.Namespace(ns), committed.Key(tt.Key), tt.RangeID).
+					Return(&committed.Record{Key: committed.Key(tt.Key), Value: committed.MustMarshalValue(value)}, nil)
+			}
+
+			sut := committed.NewMetaRangeManager(metaManager, rangeManager, 1024)
+			record, err := sut.GetValue(ns, "meta", graveler.Key(tt.Key))
+			if tt.RangeID == "" {
+				if !errors.Is(err, graveler.ErrNotFound) {
+					t.Fatalf("GetValue(%s) err=%v, expected not found", tt.Key, err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &graveler.ValueRecord{Key: graveler.Key(tt.Key), Value: value}, record)
+		})
+	}
+}
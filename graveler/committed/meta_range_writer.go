@@ -154,6 +154,20 @@ func rangeToValue(rng Range) (Value, error) {
 	return MarshalValue(rangeValue)
 }
 
+// valueToRange returns the Range represented by a Value in MetaRange
+func valueToRange(v Value) (Range, error) {
+	rangeValue, err := UnmarshalValue(v)
+	if err != nil {
+		return Range{}, err
+	}
+	rng, err := UnmarshalRange(rangeValue.Data)
+	if err != nil {
+		return Range{}, err
+	}
+	rng.ID = ID(rangeValue.Identity)
+	return rng, nil
+}
+
 // writeRangesToMetaRange writes all ranges to a MetaRange and returns the MetaRangeID
 func (w *GeneralMetaRangeWriter) writeRangesToMetaRange() (*graveler.MetaRangeID, error) {
 	metaRangeWriter, err := w.metaRangeManager.GetWriter(w.namespace)
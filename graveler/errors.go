@@ -14,6 +14,7 @@ var (
 	ErrInvalidStorageNamespace = fmt.Errorf("storage namespace: %w", ErrInvalidValue)
 	ErrInvalidRepositoryID     = fmt.Errorf("repository id: %w", ErrInvalidValue)
 	ErrInvalidBranchID         = fmt.Errorf("branch id: %w", ErrInvalidValue)
+	ErrInvalidTagID            = fmt.Errorf("tag id: %w", ErrInvalidValue)
 	ErrInvalidRef              = fmt.Errorf("ref: %w", ErrInvalidValue)
 	ErrInvalidCommitID         = fmt.Errorf("commit id: %w", ErrInvalidValue)
 	ErrCommitNotFound          = fmt.Errorf("commit: %w", ErrNotFound)
@@ -21,7 +22,25 @@ var (
 	ErrConflictFound           = errors.New("conflict found")
 	ErrBranchExists            = errors.New("branch already exists")
 	ErrBranchLocked            = errors.New("branch locked for updates")
+	ErrCommitAborted           = errors.New("commit aborted by a reset of the branch")
+	ErrBranchMoved             = errors.New("branch moved during update")
 	ErrTagAlreadyExists        = errors.New("tag already exists")
 	ErrDirtyBranch             = errors.New("can't apply meta-range on dirty branch")
 	ErrMetaRangeNotFound       = errors.New("metarange not found")
+	ErrRevertNotSingleParent   = fmt.Errorf("revert requires a commit with a single parent: %w", ErrInvalidValue)
+	ErrCherryPickNoParent      = fmt.Errorf("cherry-pick requires a commit with a parent: %w", ErrInvalidValue)
 )
+
+// ConflictError is returned when merging fails because of conflicting changes.  It holds the
+// conflicting keys and matches ErrConflictFound.
+type ConflictError struct {
+	Keys []Key
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: %d conflicting keys", ErrConflictFound, len(e.Keys))
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrConflictFound
+}
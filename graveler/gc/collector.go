@@ -0,0 +1,184 @@
+package gc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/graveler/committed"
+	"github.com/treeverse/lakefs/logging"
+	"github.com/treeverse/lakefs/pyramid"
+)
+
+// DefaultGracePeriod is the minimal age of a range or metarange file before it may be
+// collected.  Files are written before the commit referencing them, so newer files may
+// belong to a commit in progress.
+const DefaultGracePeriod = 6 * time.Hour
+
+type Params struct {
+	// DryRun reports the files to remove without removing them
+	DryRun bool
+	// GracePeriod is the minimal age of a file to remove
+	GracePeriod time.Duration
+}
+
+// Report describes a single collection run over a repository
+type Report struct {
+	RepositoryID   graveler.RepositoryID
+	DryRun         bool
+	LiveMetaRanges int
+	LiveRanges     int
+	// Removed holds the files removed, or that would have been removed on dry run
+	Removed []string
+}
+
+// Collector removes ranges and metaranges not referenced by any commit reachable from a
+// branch or a tag
+type Collector interface {
+	CollectRanges(ctx context.Context, repositoryID graveler.RepositoryID, params Params) (*Report, error)
+}
+
+type RangeCollector struct {
+	refManager       graveler.RefManager
+	metaRangeManager committed.MetaRangeManager
+	fs               pyramid.FS
+	log              logging.Logger
+}
+
+func NewRangeCollector(refManager graveler.RefManager, metaRangeManager committed.MetaRangeManager, fs pyramid.FS) *RangeCollector {
+	return &RangeCollector{
+		refManager:       refManager,
+		metaRangeManager: metaRangeManager,
+		fs:               fs,
+		log:              logging.Default().WithField("service_name", "graveler_gc"),
+	}
+}
+
+func (c *RangeCollector) CollectRanges(ctx context.Context, repositoryID graveler.RepositoryID, params Params) (*Report, error) {
+	repo, err := c.refManager.GetRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	// files written after this point may belong to commits we do not see
+	threshold := time.Now().Add(-params.GracePeriod)
+
+	heads, err := c.listHeads(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	metaRangeIDs, err := c.reachableMetaRanges(ctx, repositoryID, heads)
+	if err != nil {
+		return nil, err
+	}
+	live := make(map[string]struct{})
+	liveRanges := 0
+	for _, metaRangeID := range metaRangeIDs {
+		live[string(metaRangeID)] = struct{}{}
+		mr, err := c.metaRangeManager.GetMetaRange(repo.StorageNamespace, metaRangeID)
+		if err != nil {
+			return nil, fmt.Errorf("get metarange %s: %w", metaRangeID, err)
+		}
+		for _, rng := range mr.Ranges {
+			if _, ok := live[string(rng.ID)]; !ok {
+				live[string(rng.ID)] = struct{}{}
+				liveRanges++
+			}
+		}
+	}
+
+	report := &Report{
+		RepositoryID:   repositoryID,
+		DryRun:         params.DryRun,
+		LiveMetaRanges: len(metaRangeIDs),
+		LiveRanges:     liveRanges,
+	}
+	err = c.fs.Walk(string(repo.StorageNamespace), func(entry block.WalkEntry) error {
+		if _, ok := live[entry.Identifier]; ok {
+			return nil
+		}
+		// a file of unknown age may belong to a commit in progress
+		if entry.LastModified.IsZero() || entry.LastModified.After(threshold) {
+			return nil
+		}
+		report.Removed = append(report.Removed, entry.Identifier)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk %s: %w", repo.StorageNamespace, err)
+	}
+	if params.DryRun {
+		return report, nil
+	}
+	for _, filename := range report.Removed {
+		if err := c.fs.Remove(string(repo.StorageNamespace), filename); err != nil {
+			return nil, fmt.Errorf("remove %s: %w", filename, err)
+		}
+	}
+	c.log.WithFields(logging.Fields{
+		"repository":      repositoryID,
+		"live_metaranges": report.LiveMetaRanges,
+		"live_ranges":     report.LiveRanges,
+		"removed_files":   len(report.Removed),
+	}).Info("collected unreferenced ranges")
+	return report, nil
+}
+
+// listHeads returns the commits pointed at by branches and tags
+func (c *RangeCollector) listHeads(ctx context.Context, repositoryID graveler.RepositoryID) ([]graveler.CommitID, error) {
+	var heads []graveler.CommitID
+	branches, err := c.refManager.ListBranches(ctx, repositoryID)
+	if err != nil {
+		return nil, fmt.Errorf("list branches: %w", err)
+	}
+	defer branches.Close()
+	for branches.Next() {
+		heads = append(heads, branches.Value().CommitID)
+	}
+	if err := branches.Err(); err != nil {
+		return nil, fmt.Errorf("list branches: %w", err)
+	}
+
+	tags, err := c.refManager.ListTags(ctx, repositoryID)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	defer tags.Close()
+	for tags.Next() {
+		heads = append(heads, tags.Value().CommitID)
+	}
+	if err := tags.Err(); err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	return heads, nil
+}
+
+// reachableMetaRanges returns the metaranges of all commits reachable from heads
+func (c *RangeCollector) reachableMetaRanges(ctx context.Context, repositoryID graveler.RepositoryID, heads []graveler.CommitID) ([]graveler.MetaRangeID, error) {
+	var metaRangeIDs []graveler.MetaRangeID
+	seenMetaRanges := make(map[graveler.MetaRangeID]struct{})
+	visited := make(map[graveler.CommitID]struct{})
+	queue := heads
+	for len(queue) > 0 {
+		commitID := queue[0]
+		queue = queue[1:]
+		if commitID == "" {
+			continue
+		}
+		if _, ok := visited[commitID]; ok {
+			continue
+		}
+		visited[commitID] = struct{}{}
+		commit, err := c.refManager.GetCommit(ctx, repositoryID, commitID)
+		if err != nil {
+			return nil, fmt.Errorf("get commit %s: %w", commitID, err)
+		}
+		if _, ok := seenMetaRanges[commit.MetaRangeID]; !ok && commit.MetaRangeID != "" {
+			seenMetaRanges[commit.MetaRangeID] = struct{}{}
+			metaRangeIDs = append(metaRangeIDs, commit.MetaRangeID)
+		}
+		queue = append(queue, commit.Parents...)
+	}
+	return metaRangeIDs, nil
+}
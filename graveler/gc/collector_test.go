@@ -0,0 +1,146 @@
+package gc_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/golang/mock/gomock"
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/graveler/committed"
+	"github.com/treeverse/lakefs/graveler/committed/mock"
+	"github.com/treeverse/lakefs/graveler/gc"
+	"github.com/treeverse/lakefs/graveler/testutil"
+	"github.com/treeverse/lakefs/pyramid"
+)
+
+type fsFake struct {
+	pyramid.FS
+	files   map[string]time.Time
+	removed []string
+}
+
+func (f *fsFake) Walk(_ string, walkFn block.WalkFunc) error {
+	names := make([]string, 0, len(f.files))
+	for name := range f.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := walkFn(block.WalkEntry{Identifier: name, LastModified: f.files[name]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fsFake) Remove(_, filename string) error {
+	delete(f.files, filename)
+	f.removed = append(f.removed, filename)
+	return nil
+}
+
+func TestRangeCollector_CollectRanges(t *testing.T) {
+	old := time.Now().Add(-24 * time.Hour)
+	metaRanges := map[graveler.MetaRangeID][]committed.ID{
+		"mr1": {"r1", "r2"},
+		"mr2": {"r2", "r3"},
+		"mr3": {"r4"},
+	}
+	newFiles := func() map[string]time.Time {
+		return map[string]time.Time{
+			"mr1":   old,
+			"mr2":   old,
+			"mr3":   old,
+			"mr4":   old,
+			"r1":    old,
+			"r2":    old,
+			"r3":    old,
+			"r4":    old,
+			"r5":    old,
+			"r6":    old,
+			"fresh": time.Now(),
+			// the storage did not report when it was modified
+			"unknown_age": {},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		dryRun        bool
+		expectedFiles []string
+	}{
+		{
+			name:          "collect",
+			dryRun:        false,
+			expectedFiles: []string{"fresh", "mr1", "mr2", "mr3", "r1", "r2", "r3", "r4", "unknown_age"},
+		},
+		{
+			name:          "dry_run",
+			dryRun:        true,
+			expectedFiles: []string{"fresh", "mr1", "mr2", "mr3", "mr4", "r1", "r2", "r3", "r4", "r5", "r6", "unknown_age"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			refs := &testutil.RefsFake{
+				ListBranchesRes: testutil.NewBranchIteratorFake([]graveler.BranchRecord{
+					{BranchID: "main", Branch: &graveler.Branch{CommitID: "c2"}},
+					{BranchID: "empty", Branch: &graveler.Branch{}},
+				}),
+				ListTagsRes: testutil.NewTagIteratorFake([]graveler.TagRecord{
+					{TagID: "v1", CommitID: "c3"},
+				}),
+				Commits: map[graveler.CommitID]*graveler.Commit{
+					"c1": {MetaRangeID: "mr1"},
+					"c2": {MetaRangeID: "mr2", Parents: graveler.CommitParents{"c1"}},
+					"c3": {MetaRangeID: "mr3", Parents: graveler.CommitParents{"c1"}},
+					"c4": {MetaRangeID: "mr4", Parents: graveler.CommitParents{"c1"}},
+				},
+			}
+			metaRangeManager := mock.NewMockMetaRangeManager(ctrl)
+			for metaRangeID, rangeIDs := range metaRanges {
+				mr := &committed.MetaRange{ID: metaRangeID}
+				for _, rangeID := range rangeIDs {
+					mr.Ranges = append(mr.Ranges, committed.Range{ID: rangeID})
+				}
+				metaRangeManager.EXPECT().GetMetaRange(gomock.Any(), metaRangeID).Return(mr, nil)
+			}
+			fs := &fsFake{files: newFiles()}
+
+			collector := gc.NewRangeCollector(refs, metaRangeManager, fs)
+			report, err := collector.CollectRanges(context.Background(), "repo", gc.Params{
+				DryRun:      tt.dryRun,
+				GracePeriod: time.Hour,
+			})
+			if err != nil {
+				t.Fatalf("CollectRanges: %s", err)
+			}
+
+			expectedReport := &gc.Report{
+				RepositoryID:   "repo",
+				DryRun:         tt.dryRun,
+				LiveMetaRanges: 3,
+				LiveRanges:     4,
+				Removed:        []string{"mr4", "r5", "r6"},
+			}
+			if diff := deep.Equal(report, expectedReport); diff != nil {
+				t.Errorf("unexpected report: %s", diff)
+			}
+			files := make([]string, 0, len(fs.files))
+			for name := range fs.files {
+				files = append(files, name)
+			}
+			sort.Strings(files)
+			if diff := deep.Equal(files, tt.expectedFiles); diff != nil {
+				t.Errorf("unexpected files left: %s", diff)
+			}
+		})
+	}
+}
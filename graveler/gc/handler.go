@@ -0,0 +1,124 @@
+package gc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/treeverse/lakefs/graveler"
+	"github.com/treeverse/lakefs/logging"
+	"github.com/treeverse/lakefs/parade"
+)
+
+const (
+	actorName parade.ActorID = "GC_RANGES"
+
+	CollectRangesAction = "gc:collect-ranges"
+)
+
+var (
+	errUnknownAction = errors.New("unknown action")
+	errMissingBody   = errors.New("missing task body")
+)
+
+// TaskBody is the body of a CollectRangesAction task
+type TaskBody struct {
+	RepositoryID graveler.RepositoryID
+	DryRun       bool
+	GracePeriod  time.Duration
+}
+
+// Handler is a parade actor that collects unreferenced ranges in the background
+type Handler struct {
+	collector Collector
+}
+
+func NewHandler(collector Collector) *Handler {
+	return &Handler{
+		collector: collector,
+	}
+}
+
+// NewCollectRangesTask returns a task that collects unreferenced ranges of repositoryID
+func NewCollectRangesTask(repositoryID graveler.RepositoryID, params Params) (parade.TaskData, error) {
+	body, err := json.Marshal(TaskBody{
+		RepositoryID: repositoryID,
+		DryRun:       params.DryRun,
+		GracePeriod:  params.GracePeriod,
+	})
+	if err != nil {
+		return parade.TaskData{}, err
+	}
+	bodyStr := string(body)
+	one := 1
+	zero := 0
+	return parade.TaskData{
+		ID:                parade.TaskID(fmt.Sprintf("%s:%s:%s", CollectRangesAction, repositoryID, uuid.New().String())),
+		Action:            CollectRangesAction,
+		Body:              &bodyStr,
+		StatusCode:        parade.TaskPending,
+		MaxTries:          &one,
+		TotalDependencies: &zero,
+	}, nil
+}
+
+func (h *Handler) collectRanges(body *string) error {
+	var taskBody TaskBody
+	if body == nil {
+		return errMissingBody
+	}
+	if err := json.Unmarshal([]byte(*body), &taskBody); err != nil {
+		return err
+	}
+	report, err := h.collector.CollectRanges(context.Background(), taskBody.RepositoryID, Params{
+		DryRun:      taskBody.DryRun,
+		GracePeriod: taskBody.GracePeriod,
+	})
+	if err != nil {
+		return err
+	}
+	logging.Default().WithFields(logging.Fields{
+		"actor":         actorName,
+		"repository":    report.RepositoryID,
+		"dry_run":       report.DryRun,
+		"removed_files": len(report.Removed),
+	}).Info("gc ranges done")
+	return nil
+}
+
+func (h *Handler) Handle(action string, body *string, _ int) parade.ActorResult {
+	var err error
+	switch action {
+	case CollectRangesAction:
+		err = h.collectRanges(body)
+	default:
+		err = errUnknownAction
+	}
+
+	if err != nil {
+		logging.Default().WithFields(logging.Fields{
+			"actor":  actorName,
+			"action": action,
+		}).WithError(err).Errorf("%s failed", action)
+
+		return parade.ActorResult{
+			Status:     err.Error(),
+			StatusCode: parade.TaskAborted,
+		}
+	}
+	return parade.ActorResult{
+		Status:     "Completed",
+		StatusCode: parade.TaskCompleted,
+	}
+}
+
+func (h *Handler) Actions() []string {
+	return []string{CollectRangesAction}
+}
+
+func (h *Handler) ActorID() parade.ActorID {
+	return actorName
+}
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -36,6 +37,27 @@ const (
 	ReferenceTypeBranch
 )
 
+// MergeStrategy represents the way conflicts are resolved during merge
+type MergeStrategy uint8
+
+const (
+	// MergeStrategyFail fails the merge when a conflict is found
+	MergeStrategyFail MergeStrategy = iota
+	// MergeStrategySourceWins resolves conflicts by taking the source value
+	MergeStrategySourceWins
+	// MergeStrategyDestWins resolves conflicts by keeping the destination value
+	MergeStrategyDestWins
+)
+
+const (
+	// MergeStrategyMetadataKey is the commit metadata key recording the strategy used by a merge
+	MergeStrategyMetadataKey = ".lakefs.merge.strategy"
+	// RevertCommitMetadataKey is the commit metadata key recording the commit reverted by a revert
+	RevertCommitMetadataKey = ".lakefs.revert.commit"
+	// CherryPickCommitMetadataKey is the commit metadata key recording the commit picked by a cherry-pick
+	CherryPickCommitMetadataKey = ".lakefs.cherry-pick.commit"
+)
+
 type Reference interface {
 	Type() ReferenceType
 	Branch() Branch
@@ -147,6 +169,9 @@ type CommitRecord struct {
 type Branch struct {
 	CommitID     CommitID
 	StagingToken StagingToken
+	// SealedTokens are staging tokens of commits in progress, newest first.  Their changes are
+	// visible on the branch, with StagingToken taking precedence, until the commit lands.
+	SealedTokens []StagingToken
 }
 
 // BranchRecord holds BranchID with the associated Branch data
@@ -168,6 +193,15 @@ type Diff struct {
 	Value *Value
 }
 
+// ValueCondition checks the current value of a key before it is set.  current is nil if the
+// key has no value.  A non-nil error rejects the set and is returned to its caller.
+type ValueCondition func(current *Value) error
+
+// ValueUpdateFunc returns the value to write under a staging token and key, given their
+// current value: nil for a tombstone, or err ErrNotFound if nothing is staged there.  A nil
+// returned value is a tombstone.
+type ValueUpdateFunc func(value *Value, err error) (*Value, error)
+
 // Interfaces
 
 type KeyValueStore interface {
@@ -178,6 +212,11 @@ type KeyValueStore interface {
 	// Set stores value on repository / branch by key. nil value is a valid value for tombstone
 	Set(ctx context.Context, repositoryID RepositoryID, branchID BranchID, key Key, value Value) error
 
+	// SetIf stores value on repository / branch by key if condition accepts the current value
+	// of key.  Checking the condition and storing the value are atomic with respect to other
+	// writes of key on the branch.
+	SetIf(ctx context.Context, repositoryID RepositoryID, branchID BranchID, key Key, value Value, condition ValueCondition) error
+
 	// Delete value from repository / branch branch by key
 	Delete(ctx context.Context, repositoryID RepositoryID, branchID BranchID, key Key) error
 
@@ -222,6 +261,11 @@ type VersionController interface {
 	// Log returns an iterator starting at commit ID up to repository root
 	Log(ctx context.Context, repositoryID RepositoryID, commitID CommitID) (CommitIterator, error)
 
+	// LogByPrefix returns an iterator over the commits reachable from commit ID that changed
+	// the value of a key starting with prefix.  Merge commits are returned only if they differ
+	// from all their parents under prefix.
+	LogByPrefix(ctx context.Context, repositoryID RepositoryID, commitID CommitID, prefix Key) (CommitIterator, error)
+
 	// ListBranches lists branches on repositories
 	ListBranches(ctx context.Context, repositoryID RepositoryID) (BranchIterator, error)
 
@@ -247,26 +291,41 @@ type VersionController interface {
 	// Dereference returns the commit ID based on 'ref' reference
 	Dereference(ctx context.Context, repositoryID RepositoryID, ref Ref) (CommitID, error)
 
-	// Reset throws all staged data on the repository / branch
+	// Reset throws all staged data on the repository / branch, including the data of commits in
+	// progress, which fail with ErrCommitAborted
 	Reset(ctx context.Context, repositoryID RepositoryID, branchID BranchID) error
 
-	// Reset throws all staged data under the specified key on the repository / branch
+	// ResetKey throws all staged data under the specified key on the repository / branch.
+	// Commits in progress fail with ErrCommitAborted and leave their other data staged.
 	ResetKey(ctx context.Context, repositoryID RepositoryID, branchID BranchID, key Key) error
 
-	// Reset throws all staged data starting with the given prefix on the repository / branch
+	// ResetPrefix throws all staged data starting with the given prefix on the repository /
+	// branch.  Commits in progress fail with ErrCommitAborted and leave their other data staged.
 	ResetPrefix(ctx context.Context, repositoryID RepositoryID, branchID BranchID, key Key) error
 
-	// Revert commits a change that will revert all the changes make from 'ref' specified
-	Revert(ctx context.Context, repositoryID RepositoryID, branchID BranchID, ref Ref) (CommitID, error)
+	// Revert commits a change that will revert all the changes make from 'ref' specified.
+	// Returns ErrDirtyBranch if the branch has uncommitted changes, and a ConflictError if
+	// the reverted keys changed on the branch since.
+	Revert(ctx context.Context, repositoryID RepositoryID, branchID BranchID, ref Ref, committer string) (CommitID, error)
 
-	// Merge merge 'from' with 'to' branches under repository returns the new commit id on 'to' branch
-	Merge(ctx context.Context, repositoryID RepositoryID, from Ref, to BranchID, committer string, message string, metadata Metadata) (CommitID, error)
+	// CherryPick commits on branchID the changes introduced by the commit 'ref' relative to its
+	// first parent.  Returns ErrDirtyBranch if the branch has uncommitted changes, and a
+	// ConflictError if the changed keys were also changed on the branch.
+	CherryPick(ctx context.Context, repositoryID RepositoryID, ref Ref, branchID BranchID, committer string) (CommitID, error)
+
+	// Merge merge 'from' with 'to' branches under repository returns the new commit id on 'to' branch.
+	// Conflicts are resolved according to strategy, which is recorded in the commit metadata.
+	Merge(ctx context.Context, repositoryID RepositoryID, from Ref, to BranchID, committer string, message string, metadata Metadata, strategy MergeStrategy) (CommitID, error)
 
 	// DiffUncommitted returns iterator to scan the changes made on the branch
 	DiffUncommitted(ctx context.Context, repositoryID RepositoryID, branchID BranchID) (DiffIterator, error)
 
 	// Diff returns the changes between 'left' and 'right' ref, starting from the 'from' key
 	Diff(ctx context.Context, repositoryID RepositoryID, left, right Ref) (DiffIterator, error)
+
+	// Compare returns the changes merging 'from' into 'to' brings: the changes between their
+	// merge-base and 'from', as in a three-dot diff (to...from)
+	Compare(ctx context.Context, repositoryID RepositoryID, from, to Ref) (DiffIterator, error)
 }
 
 type Graveler interface {
@@ -396,6 +455,10 @@ type RefManager interface {
 
 	// Log returns an iterator starting at commit ID up to repository root
 	Log(ctx context.Context, repositoryID RepositoryID, commitID CommitID) (CommitIterator, error)
+
+	// WalkCommits returns an iterator over all commits reachable from commit ID, following all
+	// parents of each commit
+	WalkCommits(ctx context.Context, repositoryID RepositoryID, commitID CommitID) (CommitIterator, error)
 }
 
 // MetaRange abstracts the data structure of the committed data.
@@ -423,10 +486,14 @@ type CommittedManager interface {
 	// Diff receives two metaRanges and returns a DiffIterator describing all differences between them.
 	Diff(ctx context.Context, ns StorageNamespace, left, right MetaRangeID) (DiffIterator, error)
 
+	// PrefixChanged returns true if the value of any key starting with prefix differs between
+	// the left and right metaRanges.  An empty MetaRangeID stands for an empty metaRange.
+	PrefixChanged(ctx context.Context, ns StorageNamespace, left, right MetaRangeID, prefix Key) (bool, error)
+
 	// Merge receives two metaRanges and a 3rd merge base metaRange used to resolve the change type
 	// it applies that changes from left to right, resulting in a new metaRange that
-	// is expected to be immediately addressable
-	Merge(ctx context.Context, ns StorageNamespace, left, right, base MetaRangeID, committer string, message string, metadata Metadata) (MetaRangeID, error)
+	// is expected to be immediately addressable.  Conflicts are resolved according to strategy.
+	Merge(ctx context.Context, ns StorageNamespace, left, right, base MetaRangeID, committer string, message string, metadata Metadata, strategy MergeStrategy) (MetaRangeID, error)
 
 	// Apply is the act of taking an existing metaRange (snapshot) and applying a set of changes to it.
 	// A change is either an entity to write/overwrite, or a tombstone to mark a deletion
@@ -443,6 +510,10 @@ type StagingManager interface {
 	// Set writes a (possibly nil) value under the given staging token and key.
 	Set(ctx context.Context, st StagingToken, key Key, value *Value) error
 
+	// Update atomically writes the value returned by updateFunc under the given staging token
+	// and key.  Nothing is written if updateFunc fails.
+	Update(ctx context.Context, st StagingToken, key Key, updateFunc ValueUpdateFunc) error
+
 	// List returns a ValueIterator for the given staging token
 	List(ctx context.Context, st StagingToken) (ValueIterator, error)
 
@@ -459,6 +530,8 @@ type StagingManager interface {
 var (
 	reValidBranchID     = regexp.MustCompile(`^\w[-\w]*$`)
 	reValidRepositoryID = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{2,62}$`)
+	// tags are valid wherever a branch name is accepted as a reference, so they share its format
+	reValidTagID = reValidBranchID
 )
 
 func NewRepositoryID(id string) (RepositoryID, error) {
@@ -495,6 +568,17 @@ func (id BranchID) String() string {
 	return string(id)
 }
 
+func NewTagID(id string) (TagID, error) {
+	if !reValidTagID.MatchString(id) {
+		return "", ErrInvalidTagID
+	}
+	return TagID(id), nil
+}
+
+func (id TagID) String() string {
+	return string(id)
+}
+
 func NewRef(id string) (Ref, error) {
 	if id == "" || strings.ContainsAny(id, " \t\r\n") {
 		return "", ErrInvalidRef
@@ -514,6 +598,19 @@ func (id Key) String() string {
 	return string(id)
 }
 
+func (s MergeStrategy) String() string {
+	switch s {
+	case MergeStrategyFail:
+		return "fail"
+	case MergeStrategySourceWins:
+		return "source-wins"
+	case MergeStrategyDestWins:
+		return "dest-wins"
+	default:
+		return fmt.Sprintf("MergeStrategy(%d)", s)
+	}
+}
+
 func NewCommitID(id string) (CommitID, error) {
 	_, err := hex.DecodeString(id)
 	if err != nil {
@@ -531,6 +628,8 @@ type graveler struct {
 	StagingManager   StagingManager
 	RefManager       RefManager
 	branchLocker     branchLocker
+	// branchUpdateLock serializes the landing of commits with the resets that abort them
+	branchUpdateLock sync.Mutex
 	log              logging.Logger
 }
 
@@ -633,12 +732,9 @@ func (g *graveler) UpdateBranch(ctx context.Context, repositoryID RepositoryID,
 	}
 	// validate no conflict
 	// TODO(Guys) return error only on conflicts, currently returns error for any changes on staging
-	iter, err := g.StagingManager.List(ctx, curBranch.StagingToken)
-	if err != nil {
+	if empty, err := g.stagingEmpty(ctx, curBranch); err != nil {
 		return nil, err
-	}
-	defer iter.Close()
-	if iter.Next() {
+	} else if !empty {
 		return nil, ErrConflictFound
 	}
 
@@ -685,6 +781,18 @@ func (g *graveler) Log(ctx context.Context, repositoryID RepositoryID, commitID
 	return g.RefManager.Log(ctx, repositoryID, commitID)
 }
 
+func (g *graveler) LogByPrefix(ctx context.Context, repositoryID RepositoryID, commitID CommitID, prefix Key) (CommitIterator, error) {
+	repo, err := g.RefManager.GetRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	it, err := g.RefManager.WalkCommits(ctx, repositoryID, commitID)
+	if err != nil {
+		return nil, err
+	}
+	return newPrefixLogIterator(ctx, g.RefManager, g.CommittedManager, repositoryID, repo.StorageNamespace, it, prefix), nil
+}
+
 func (g *graveler) ListBranches(ctx context.Context, repositoryID RepositoryID) (BranchIterator, error) {
 	return g.RefManager.ListBranches(ctx, repositoryID)
 }
@@ -699,9 +807,11 @@ func (g *graveler) DeleteBranch(ctx context.Context, repositoryID RepositoryID,
 	if err != nil {
 		return err
 	}
-	err = g.StagingManager.Drop(ctx, branch.StagingToken)
-	if err != nil && !errors.Is(err, ErrNotFound) {
-		return err
+	for _, token := range stagingTokens(branch) {
+		err = g.StagingManager.Drop(ctx, token)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
 	}
 	return g.RefManager.DeleteBranch(ctx, repositoryID, branchID)
 }
@@ -718,7 +828,7 @@ func (g *graveler) Get(ctx context.Context, repositoryID RepositoryID, ref Ref,
 	if reference.Type() == ReferenceTypeBranch {
 		// try to get from staging, if not found proceed to committed
 		branch := reference.Branch()
-		value, err := g.StagingManager.Get(ctx, branch.StagingToken, key)
+		value, err := g.getFromStagingArea(ctx, stagingTokens(&branch), key)
 		if !errors.Is(err, ErrNotFound) {
 			if err != nil {
 				return nil, err
@@ -751,6 +861,61 @@ func (g *graveler) Set(ctx context.Context, repositoryID RepositoryID, branchID
 	return g.StagingManager.Set(ctx, branch.StagingToken, key, &value)
 }
 
+func (g *graveler) SetIf(ctx context.Context, repositoryID RepositoryID, branchID BranchID, key Key, value Value, condition ValueCondition) error {
+	cancel, err := g.branchLocker.AquireWrite(repositoryID, branchID)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	repo, err := g.RefManager.GetRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	branch, err := g.GetBranch(ctx, repositoryID, branchID)
+	if err != nil {
+		return err
+	}
+	// the write lock keeps the staging token from being sealed, so only the key under it can
+	// change while the condition is checked
+	return g.StagingManager.Update(ctx, branch.StagingToken, key, func(staged *Value, err error) (*Value, error) {
+		current := staged
+		if errors.Is(err, ErrNotFound) {
+			current, err = g.getUnstaged(ctx, repositoryID, repo, branch, key)
+			if errors.Is(err, ErrNotFound) {
+				current, err = nil, nil
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := condition(current); err != nil {
+			return nil, err
+		}
+		return &value, nil
+	})
+}
+
+// getUnstaged returns the value of key on branch ignoring its current staging token: from its
+// sealed tokens, or else from its commit.  Returns ErrNotFound if key has no value there.
+func (g *graveler) getUnstaged(ctx context.Context, repositoryID RepositoryID, repo *Repository, branch *Branch, key Key) (*Value, error) {
+	value, err := g.getFromStagingArea(ctx, branch.SealedTokens, key)
+	if !errors.Is(err, ErrNotFound) {
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			// tombstone
+			return nil, ErrNotFound
+		}
+		return value, nil
+	}
+	commit, err := g.RefManager.GetCommit(ctx, repositoryID, branch.CommitID)
+	if err != nil {
+		return nil, err
+	}
+	return g.CommittedManager.Get(ctx, repo.StorageNamespace, commit.MetaRangeID, key)
+}
+
 func (g *graveler) Delete(ctx context.Context, repositoryID RepositoryID, branchID BranchID, key Key) error {
 	cancel, err := g.branchLocker.AquireWrite(repositoryID, branchID)
 	if err != nil {
@@ -770,18 +935,26 @@ func (g *graveler) Delete(ctx context.Context, repositoryID RepositoryID, branch
 		return err
 	}
 
-	// check key in committed - do we need tombstone?
+	// check key in committed or in a commit in progress - do we need tombstone?
 	_, err = g.CommittedManager.Get(ctx, repo.StorageNamespace, commit.MetaRangeID, key)
-	if errors.Is(err, ErrNotFound) {
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	needTombstone := err == nil
+	if !needTombstone && len(branch.SealedTokens) > 0 {
+		sealedValue, err := g.getFromStagingArea(ctx, branch.SealedTokens, key)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		needTombstone = err == nil && sealedValue != nil
+	}
+	if !needTombstone {
 		// no need for tombstone - drop key from stage
 		return g.StagingManager.DropKey(ctx, branch.StagingToken, key)
 	}
-	if err != nil {
-		return err
-	}
 
 	// make sure we have tombstone in staging
-	entry, err := g.StagingManager.Get(ctx, branch.StagingToken, key)
+	entry, err := g.getFromStagingArea(ctx, stagingTokens(branch), key)
 	if err != nil && !errors.Is(err, ErrNotFound) {
 		return err
 	}
@@ -811,7 +984,8 @@ func (g *graveler) List(ctx context.Context, repositoryID RepositoryID, ref Ref)
 		return nil, err
 	}
 	if reference.Type() == ReferenceTypeBranch {
-		stagingList, err := g.StagingManager.List(ctx, reference.Branch().StagingToken)
+		branch := reference.Branch()
+		stagingList, err := g.listStagingArea(ctx, stagingTokens(&branch))
 		if err != nil {
 			return nil, err
 		}
@@ -820,10 +994,14 @@ func (g *graveler) List(ctx context.Context, repositoryID RepositoryID, ref Ref)
 	return listing, nil
 }
 
+// Commit seals the staging token of the branch by switching the branch to a new staging
+// token, and writes the commit from the sealed tokens.  Writes to the branch continue on the
+// new staging token while the commit is written, and reads consult the sealed tokens until
+// the commit lands.  A reset of the branch meanwhile aborts the commit.
 func (g *graveler) Commit(ctx context.Context, repositoryID RepositoryID, branchID BranchID, committer string, message string, metadata Metadata) (CommitID, error) {
-	cancel, err := g.branchLocker.AquireMetadataUpdate(repositoryID, branchID)
+	sealed, cancel, err := g.branchLocker.AquireCommit(repositoryID, branchID)
 	if err != nil {
-		return "", fmt.Errorf("acquire metadata update: %w", err)
+		return "", fmt.Errorf("acquire commit: %w", err)
 	}
 	defer cancel()
 	repo, err := g.RefManager.GetRepository(ctx, repositoryID)
@@ -838,10 +1016,24 @@ func (g *graveler) Commit(ctx context.Context, repositoryID RepositoryID, branch
 	if err != nil {
 		return "", fmt.Errorf("get commit: %w", err)
 	}
-	changes, err := g.StagingManager.List(ctx, branch.StagingToken)
+	// seal the staging token, writers will continue on a new one
+	sealedTokens := stagingTokens(branch)
+	stagingToken := generateStagingToken(repositoryID, branchID)
+	err = g.RefManager.SetBranch(ctx, repositoryID, branchID, Branch{
+		CommitID:     branch.CommitID,
+		StagingToken: stagingToken,
+		SealedTokens: sealedTokens,
+	})
+	if err != nil {
+		return "", fmt.Errorf("seal staging token: %w", err)
+	}
+	sealed()
+
+	changes, err := g.listStagingArea(ctx, sealedTokens)
 	if err != nil {
 		return "", fmt.Errorf("staging list: %w", err)
 	}
+	defer changes.Close()
 	metaRangeID, err := g.CommittedManager.Apply(ctx, repo.StorageNamespace, commit.MetaRangeID, changes)
 	if err != nil {
 		return "", fmt.Errorf("apply: %w", err)
@@ -857,19 +1049,75 @@ func (g *graveler) Commit(ctx context.Context, repositoryID RepositoryID, branch
 	if err != nil {
 		return "", fmt.Errorf("add commit: %w", err)
 	}
-	err = g.StagingManager.Drop(ctx, branch.StagingToken)
+	err = g.landCommit(ctx, repositoryID, branchID, newCommit, stagingToken)
 	if err != nil {
-		g.log.WithContext(ctx).WithFields(logging.Fields{
-			"repository_id": repositoryID,
-			"branch_id":     branchID,
-			"commit_id":     *commit,
-			"message":       message,
-			"staging_token": branch.StagingToken,
-		}).Error("Failed to drop staging data")
+		return "", err
+	}
+	for _, token := range sealedTokens {
+		err = g.StagingManager.Drop(ctx, token)
+		if err != nil {
+			g.log.WithContext(ctx).WithFields(logging.Fields{
+				"repository_id": repositoryID,
+				"branch_id":     branchID,
+				"commit_id":     newCommit,
+				"message":       message,
+				"staging_token": token,
+			}).Error("Failed to drop staging data")
+		}
 	}
 	return newCommit, nil
 }
 
+// landCommit points the branch at commitID, unless a reset aborted the commit by replacing
+// stagingToken, the staging token the commit switched the branch to.
+func (g *graveler) landCommit(ctx context.Context, repositoryID RepositoryID, branchID BranchID, commitID CommitID, stagingToken StagingToken) error {
+	g.branchUpdateLock.Lock()
+	defer g.branchUpdateLock.Unlock()
+	branch, err := g.RefManager.GetBranch(ctx, repositoryID, branchID)
+	if err != nil {
+		return fmt.Errorf("get branch: %w", err)
+	}
+	if branch.StagingToken != stagingToken {
+		return ErrCommitAborted
+	}
+	err = g.RefManager.SetBranch(ctx, repositoryID, branchID, Branch{
+		CommitID:     commitID,
+		StagingToken: stagingToken,
+	})
+	if err != nil {
+		return fmt.Errorf("set branch commit: %w", err)
+	}
+	return nil
+}
+
+// abortCommits switches the branch to a new staging token if it has sealed tokens, so commits
+// in progress fail to land.  The changes of the sealed tokens are dropped from the branch,
+// unless keepChanges is set and they stay on it as sealed tokens.  Returns the branch as it
+// was before.
+func (g *graveler) abortCommits(ctx context.Context, repositoryID RepositoryID, branchID BranchID, keepChanges bool) (*Branch, error) {
+	g.branchUpdateLock.Lock()
+	defer g.branchUpdateLock.Unlock()
+	branch, err := g.RefManager.GetBranch(ctx, repositoryID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	if len(branch.SealedTokens) == 0 {
+		return branch, nil
+	}
+	aborted := Branch{
+		CommitID:     branch.CommitID,
+		StagingToken: generateStagingToken(repositoryID, branchID),
+	}
+	if keepChanges {
+		aborted.SealedTokens = stagingTokens(branch)
+	}
+	err = g.RefManager.SetBranch(ctx, repositoryID, branchID, aborted)
+	if err != nil {
+		return nil, fmt.Errorf("abort commit: %w", err)
+	}
+	return branch, nil
+}
+
 func (g *graveler) CommitExistingMetaRange(ctx context.Context, repositoryID RepositoryID, branchID BranchID, metaRangeID MetaRangeID, committer string, message string, metadata Metadata) (CommitID, error) {
 	cancel, err := g.branchLocker.AquireMetadataUpdate(repositoryID, branchID)
 	if err != nil {
@@ -913,7 +1161,7 @@ func (g *graveler) CommitExistingMetaRange(ctx context.Context, repositoryID Rep
 }
 
 func (g *graveler) stagingEmpty(ctx context.Context, branch *Branch) (bool, error) {
-	stIt, err := g.StagingManager.List(ctx, branch.StagingToken)
+	stIt, err := g.listStagingArea(ctx, stagingTokens(branch))
 	if err != nil {
 		return false, fmt.Errorf("staging list (token %s): %w", branch.StagingToken, err)
 	}
@@ -925,17 +1173,62 @@ func (g *graveler) stagingEmpty(ctx context.Context, branch *Branch) (bool, erro
 	return true, nil
 }
 
+// stagingTokens returns the staging tokens holding the uncommitted changes of branch, by
+// precedence: the current staging token followed by the tokens sealed by commits in progress.
+func stagingTokens(branch *Branch) []StagingToken {
+	return append([]StagingToken{branch.StagingToken}, branch.SealedTokens...)
+}
+
+// getFromStagingArea returns the value of key from the first staging token holding it.  A
+// nil value is a tombstone.  Returns ErrNotFound if no token holds the key.
+func (g *graveler) getFromStagingArea(ctx context.Context, tokens []StagingToken, key Key) (*Value, error) {
+	for _, token := range tokens {
+		value, err := g.StagingManager.Get(ctx, token, key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		return value, err
+	}
+	return nil, ErrNotFound
+}
+
+// listStagingArea lists the changes held by tokens, including tombstones.  Changes of earlier
+// tokens take precedence.
+func (g *graveler) listStagingArea(ctx context.Context, tokens []StagingToken) (ValueIterator, error) {
+	if len(tokens) == 1 {
+		return g.StagingManager.List(ctx, tokens[0])
+	}
+	iterators := make([]ValueIterator, 0, len(tokens))
+	for _, token := range tokens {
+		it, err := g.StagingManager.List(ctx, token)
+		if err != nil {
+			for _, it := range iterators {
+				it.Close()
+			}
+			return nil, fmt.Errorf("staging list (token %s): %w", token, err)
+		}
+		iterators = append(iterators, it)
+	}
+	return newStagingAreaIterator(iterators...), nil
+}
+
 func (g *graveler) Reset(ctx context.Context, repositoryID RepositoryID, branchID BranchID) error {
 	cancel, err := g.branchLocker.AquireWrite(repositoryID, branchID)
 	if err != nil {
 		return err
 	}
 	defer cancel()
-	branch, err := g.RefManager.GetBranch(ctx, repositoryID, branchID)
+	branch, err := g.abortCommits(ctx, repositoryID, branchID, false)
 	if err != nil {
 		return err
 	}
-	return g.StagingManager.Drop(ctx, branch.StagingToken)
+	for _, token := range stagingTokens(branch) {
+		err = g.StagingManager.Drop(ctx, token)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (g *graveler) ResetKey(ctx context.Context, repositoryID RepositoryID, branchID BranchID, key Key) error {
@@ -944,11 +1237,17 @@ func (g *graveler) ResetKey(ctx context.Context, repositoryID RepositoryID, bran
 		return err
 	}
 	defer cancel()
-	branch, err := g.RefManager.GetBranch(ctx, repositoryID, branchID)
+	branch, err := g.abortCommits(ctx, repositoryID, branchID, true)
 	if err != nil {
 		return err
 	}
-	return g.StagingManager.DropKey(ctx, branch.StagingToken, key)
+	for _, token := range stagingTokens(branch) {
+		err = g.StagingManager.DropKey(ctx, token, key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (g *graveler) ResetPrefix(ctx context.Context, repositoryID RepositoryID, branchID BranchID, key Key) error {
@@ -957,18 +1256,137 @@ func (g *graveler) ResetPrefix(ctx context.Context, repositoryID RepositoryID, b
 		return err
 	}
 	defer cancel()
-	branch, err := g.RefManager.GetBranch(ctx, repositoryID, branchID)
+	branch, err := g.abortCommits(ctx, repositoryID, branchID, true)
 	if err != nil {
 		return err
 	}
-	return g.StagingManager.DropByPrefix(ctx, branch.StagingToken, key)
+	for _, token := range stagingTokens(branch) {
+		err = g.StagingManager.DropByPrefix(ctx, token, key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *graveler) Revert(ctx context.Context, repositoryID RepositoryID, branchID BranchID, ref Ref, committer string) (CommitID, error) {
+	commitRecord, err := g.getCommitRecordFromRef(ctx, repositoryID, ref)
+	if err != nil {
+		return "", fmt.Errorf("get commit from ref %s: %w", ref, err)
+	}
+	if len(commitRecord.Parents) != 1 {
+		return "", ErrRevertNotSingleParent
+	}
+	parentCommit, err := g.RefManager.GetCommit(ctx, repositoryID, commitRecord.Parents[0])
+	if err != nil {
+		return "", fmt.Errorf("get parent commit %s: %w", commitRecord.Parents[0], err)
+	}
+	return g.commitDiff(ctx, repositoryID, branchID, commitRecord.MetaRangeID, parentCommit.MetaRangeID, Commit{
+		Committer: committer,
+		Message:   fmt.Sprintf("Revert %s", commitRecord.CommitID),
+		Metadata:  Metadata{RevertCommitMetadataKey: commitRecord.CommitID.String()},
+	})
+}
+
+func (g *graveler) CherryPick(ctx context.Context, repositoryID RepositoryID, ref Ref, branchID BranchID, committer string) (CommitID, error) {
+	commitRecord, err := g.getCommitRecordFromRef(ctx, repositoryID, ref)
+	if err != nil {
+		return "", fmt.Errorf("get commit from ref %s: %w", ref, err)
+	}
+	if len(commitRecord.Parents) == 0 {
+		return "", ErrCherryPickNoParent
+	}
+	parentCommit, err := g.RefManager.GetCommit(ctx, repositoryID, commitRecord.Parents[0])
+	if err != nil {
+		return "", fmt.Errorf("get parent commit %s: %w", commitRecord.Parents[0], err)
+	}
+	metadata := make(Metadata, len(commitRecord.Metadata)+1)
+	for k, v := range commitRecord.Metadata {
+		metadata[k] = v
+	}
+	metadata[CherryPickCommitMetadataKey] = commitRecord.CommitID.String()
+	return g.commitDiff(ctx, repositoryID, branchID, parentCommit.MetaRangeID, commitRecord.MetaRangeID, Commit{
+		Committer: committer,
+		Message:   commitRecord.Message,
+		Metadata:  metadata,
+	})
+}
+
+// commitDiff applies the changes from left to right metaRanges on top of the branch head, and
+// commits the result on the branch using the committer, message and metadata of commit.
+// Returns ErrDirtyBranch if the branch has uncommitted changes, a ConflictError if keys
+// changed by the diff were also changed on the branch, and ErrBranchMoved if the branch was
+// updated while the commit was being written.
+func (g *graveler) commitDiff(ctx context.Context, repositoryID RepositoryID, branchID BranchID, left, right MetaRangeID, commit Commit) (CommitID, error) {
+	cancel, err := g.branchLocker.AquireMetadataUpdate(repositoryID, branchID)
+	if err != nil {
+		return "", fmt.Errorf("acquire metadata update: %w", err)
+	}
+	defer cancel()
+	repo, err := g.RefManager.GetRepository(ctx, repositoryID)
+	if err != nil {
+		return "", fmt.Errorf("get repository: %w", err)
+	}
+	branch, err := g.RefManager.GetBranch(ctx, repositoryID, branchID)
+	if err != nil {
+		return "", fmt.Errorf("get branch %s: %w", branchID, err)
+	}
+	if empty, err := g.stagingEmpty(ctx, branch); err != nil {
+		return "", err
+	} else if !empty {
+		return "", ErrDirtyBranch
+	}
+	headCommit, err := g.RefManager.GetCommit(ctx, repositoryID, branch.CommitID)
+	if err != nil {
+		return "", fmt.Errorf("get commit %s: %w", branch.CommitID, err)
+	}
+	diffs, err := g.CommittedManager.Diff(ctx, repo.StorageNamespace, left, right)
+	if err != nil {
+		return "", fmt.Errorf("diff: %w", err)
+	}
+	changes := newApplyDiffIterator(ctx, g.CommittedManager, diffs, repo.StorageNamespace, left, headCommit.MetaRangeID)
+	defer changes.Close()
+	commit.MetaRangeID, err = g.CommittedManager.Apply(ctx, repo.StorageNamespace, headCommit.MetaRangeID, changes)
+	if err != nil {
+		return "", fmt.Errorf("apply: %w", err)
+	}
+	commit.CreationDate = time.Now()
+	commit.Parents = CommitParents{branch.CommitID}
+	newCommit, err := g.RefManager.AddCommit(ctx, repositoryID, commit)
+	if err != nil {
+		return "", fmt.Errorf("add commit: %w", err)
+	}
+	err = g.moveBranch(ctx, repositoryID, branchID, branch, newCommit)
+	if err != nil {
+		return "", err
+	}
+	return newCommit, nil
 }
 
-func (g *graveler) Revert(_ context.Context, _ RepositoryID, _ BranchID, _ Ref) (CommitID, error) {
-	panic("implement me")
+// moveBranch points the branch at commitID, unless its head or staging token changed since it
+// was read as branch.  The branch keeps its staging tokens.
+func (g *graveler) moveBranch(ctx context.Context, repositoryID RepositoryID, branchID BranchID, branch *Branch, commitID CommitID) error {
+	g.branchUpdateLock.Lock()
+	defer g.branchUpdateLock.Unlock()
+	current, err := g.RefManager.GetBranch(ctx, repositoryID, branchID)
+	if err != nil {
+		return fmt.Errorf("get branch: %w", err)
+	}
+	if current.CommitID != branch.CommitID || current.StagingToken != branch.StagingToken {
+		return ErrBranchMoved
+	}
+	err = g.RefManager.SetBranch(ctx, repositoryID, branchID, Branch{
+		CommitID:     commitID,
+		StagingToken: current.StagingToken,
+		SealedTokens: current.SealedTokens,
+	})
+	if err != nil {
+		return fmt.Errorf("set branch commit: %w", err)
+	}
+	return nil
 }
 
-func (g *graveler) Merge(ctx context.Context, repositoryID RepositoryID, from Ref, to BranchID, committer string, message string, metadata Metadata) (CommitID, error) {
+func (g *graveler) Merge(ctx context.Context, repositoryID RepositoryID, from Ref, to BranchID, committer string, message string, metadata Metadata, strategy MergeStrategy) (CommitID, error) {
 	cancel, err := g.branchLocker.AquireMetadataUpdate(repositoryID, to)
 	if err != nil {
 		return "", err
@@ -992,17 +1410,22 @@ func (g *graveler) Merge(ctx context.Context, repositoryID RepositoryID, from Re
 		return "", err
 	}
 
-	metaRangeID, err := g.CommittedManager.Merge(ctx, repo.StorageNamespace, fromCommit.MetaRangeID, toCommit.MetaRangeID, baseCommit.MetaRangeID, committer, message, metadata)
+	metaRangeID, err := g.CommittedManager.Merge(ctx, repo.StorageNamespace, fromCommit.MetaRangeID, toCommit.MetaRangeID, baseCommit.MetaRangeID, committer, message, metadata, strategy)
 	if err != nil {
 		return "", err
 	}
+	commitMetadata := make(Metadata, len(metadata)+1)
+	for k, v := range metadata {
+		commitMetadata[k] = v
+	}
+	commitMetadata[MergeStrategyMetadataKey] = strategy.String()
 	commit := Commit{
 		Committer:    committer,
 		Message:      message,
 		MetaRangeID:  metaRangeID,
-		CreationDate: time.Time{},
+		CreationDate: time.Now(),
 		Parents:      []CommitID{fromCommit.CommitID, toCommit.CommitID},
-		Metadata:     metadata,
+		Metadata:     commitMetadata,
 	}
 	return g.RefManager.AddCommit(ctx, repositoryID, commit)
 }
@@ -1020,7 +1443,7 @@ func (g *graveler) DiffUncommitted(ctx context.Context, repositoryID RepositoryI
 	if err != nil {
 		return nil, err
 	}
-	valueIterator, err := g.StagingManager.List(ctx, branch.StagingToken)
+	valueIterator, err := g.listStagingArea(ctx, stagingTokens(branch))
 	if err != nil {
 		return nil, err
 	}
@@ -1058,3 +1481,23 @@ func (g *graveler) Diff(ctx context.Context, repositoryID RepositoryID, left, ri
 
 	return g.CommittedManager.Diff(ctx, repo.StorageNamespace, leftCommit.MetaRangeID, rightCommit.MetaRangeID)
 }
+
+func (g *graveler) Compare(ctx context.Context, repositoryID RepositoryID, from, to Ref) (DiffIterator, error) {
+	repo, err := g.RefManager.GetRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	fromCommit, err := g.getCommitRecordFromRef(ctx, repositoryID, from)
+	if err != nil {
+		return nil, err
+	}
+	toCommit, err := g.getCommitRecordFromRef(ctx, repositoryID, to)
+	if err != nil {
+		return nil, err
+	}
+	baseCommit, err := g.RefManager.FindMergeBase(ctx, repositoryID, fromCommit.CommitID, toCommit.CommitID)
+	if err != nil {
+		return nil, err
+	}
+	return g.CommittedManager.Diff(ctx, repo.StorageNamespace, baseCommit.MetaRangeID, fromCommit.MetaRangeID)
+}
@@ -230,6 +230,25 @@ func TestGraveler_DiffUncommitted(t *testing.T) {
 	}
 }
 
+func TestGraveler_Compare(t *testing.T) {
+	committedManager := &testutil.CommittedFake{DiffIterator: testutil.NewDiffIter(nil)}
+	refManager := &testutil.RefsFake{
+		RefType:   graveler.ReferenceTypeBranch,
+		Commit:    &graveler.Commit{MetaRangeID: "fromRangeID"},
+		MergeBase: &graveler.Commit{MetaRangeID: "baseRangeID"},
+	}
+	g := graveler.NewGraveler(committedManager, nil, refManager)
+
+	it, err := g.Compare(context.Background(), "repo", "from", "to")
+	if err != nil {
+		t.Fatalf("Compare failed: %s", err)
+	}
+	defer it.Close()
+	if committedManager.DiffLeft != "baseRangeID" || committedManager.DiffRight != "fromRangeID" {
+		t.Errorf("expected diff from merge base to source, got diff of %s and %s", committedManager.DiffLeft, committedManager.DiffRight)
+	}
+}
+
 func TestGraveler_CreateBranch(t *testing.T) {
 	gravel := graveler.NewGraveler(nil,
 		nil,
@@ -429,6 +448,134 @@ func TestGraveler_Commit(t *testing.T) {
 	}
 }
 
+func TestGraveler_CommitSealsStagingToken(t *testing.T) {
+	const (
+		stagingToken = graveler.StagingToken("token")
+		sealedToken  = graveler.StagingToken("sealed")
+	)
+	headCommitID := graveler.CommitID("head")
+	expectedCommitID := graveler.CommitID("expectedCommitId")
+	refManager := &testutil.RefsFake{
+		CommitID: expectedCommitID,
+		Branch:   &graveler.Branch{CommitID: headCommitID, StagingToken: stagingToken, SealedTokens: []graveler.StagingToken{sealedToken}},
+		Commit:   &graveler.Commit{MetaRangeID: "rangeID"},
+	}
+	stagingManager := &testutil.StagingFake{ValueIterator: testutil.NewValueIteratorFake(nil)}
+	g := graveler.NewGraveler(&testutil.CommittedFake{MetaRangeID: "newRangeID"}, stagingManager, refManager)
+
+	got, err := g.Commit(context.Background(), "repo", "branch", "committer", "message", nil)
+	if err != nil {
+		t.Fatalf("Commit failed: %s", err)
+	}
+	if got != expectedCommitID {
+		t.Errorf("got wrong commitID, got = %v, want %v", got, expectedCommitID)
+	}
+	if len(refManager.SetBranches) != 2 {
+		t.Fatalf("expected branch to be set twice, got %d updates: %+v", len(refManager.SetBranches), refManager.SetBranches)
+	}
+	seal := refManager.SetBranches[0]
+	if seal.StagingToken == stagingToken || seal.StagingToken == sealedToken || seal.StagingToken == "" {
+		t.Errorf("expected commit to switch to a new staging token, got %s", seal.StagingToken)
+	}
+	if diff := deep.Equal(seal, graveler.Branch{
+		CommitID:     headCommitID,
+		StagingToken: seal.StagingToken,
+		SealedTokens: []graveler.StagingToken{stagingToken, sealedToken},
+	}); diff != nil {
+		t.Errorf("unexpected sealed branch %s", diff)
+	}
+	if diff := deep.Equal(refManager.SetBranches[1], graveler.Branch{
+		CommitID:     expectedCommitID,
+		StagingToken: seal.StagingToken,
+	}); diff != nil {
+		t.Errorf("unexpected committed branch %s", diff)
+	}
+	if !stagingManager.DropCalled {
+		t.Errorf("expected sealed tokens to be dropped")
+	}
+}
+
+func TestGraveler_ResetDuringCommit(t *testing.T) {
+	const stagingToken = graveler.StagingToken("token")
+	headCommitID := graveler.CommitID("head")
+	tests := []struct {
+		name       string
+		reset      func(ctx context.Context, g graveler.Graveler) error
+		keepSealed bool
+	}{
+		{
+			name: "reset",
+			reset: func(ctx context.Context, g graveler.Graveler) error {
+				return g.Reset(ctx, "repo", "branch")
+			},
+		},
+		{
+			name: "reset key",
+			reset: func(ctx context.Context, g graveler.Graveler) error {
+				return g.ResetKey(ctx, "repo", "branch", graveler.Key("key"))
+			},
+			keepSealed: true,
+		},
+		{
+			name: "reset prefix",
+			reset: func(ctx context.Context, g graveler.Graveler) error {
+				return g.ResetPrefix(ctx, "repo", "branch", graveler.Key("prefix/"))
+			},
+			keepSealed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			refManager := &testutil.RefsFake{
+				CommitID: "newCommit",
+				Branch:   &graveler.Branch{CommitID: headCommitID, StagingToken: stagingToken},
+				Commit:   &graveler.Commit{MetaRangeID: "rangeID"},
+			}
+			stagingManager := &testutil.StagingFake{ValueIterator: testutil.NewValueIteratorFake(nil)}
+			committedManager := &testutil.CommittedFake{MetaRangeID: "newRangeID"}
+			g := graveler.NewGraveler(committedManager, stagingManager, refManager)
+			var resetErr error
+			committedManager.ApplyFunc = func() {
+				resetErr = tt.reset(ctx, g)
+			}
+
+			_, err := g.Commit(ctx, "repo", "branch", "committer", "message", nil)
+			if !errors.Is(err, graveler.ErrCommitAborted) {
+				t.Fatalf("Commit err = %v, expected %v", err, graveler.ErrCommitAborted)
+			}
+			if resetErr != nil {
+				t.Fatalf("reset during commit failed: %s", resetErr)
+			}
+			if len(refManager.SetBranches) != 2 {
+				t.Fatalf("expected branch to be set twice, got %d updates: %+v", len(refManager.SetBranches), refManager.SetBranches)
+			}
+			sealedToken := refManager.SetBranches[0].StagingToken
+			branch := refManager.SetBranches[1]
+			if branch.CommitID != headCommitID {
+				t.Errorf("branch commit = %s, expected the commit to not land on %s", branch.CommitID, headCommitID)
+			}
+			if branch.StagingToken == stagingToken || branch.StagingToken == sealedToken {
+				t.Errorf("expected reset to switch to a new staging token, got %s", branch.StagingToken)
+			}
+			var expectedSealed []graveler.StagingToken
+			if tt.keepSealed {
+				expectedSealed = []graveler.StagingToken{sealedToken, stagingToken}
+			}
+			if diff := deep.Equal(branch.SealedTokens, expectedSealed); diff != nil {
+				t.Errorf("unexpected sealed tokens after reset %s", diff)
+			}
+			var expectedDropped []graveler.StagingToken
+			if !tt.keepSealed {
+				expectedDropped = []graveler.StagingToken{sealedToken, stagingToken}
+			}
+			if diff := deep.Equal(stagingManager.DroppedTokens, expectedDropped); diff != nil {
+				t.Errorf("unexpected dropped staging tokens %s", diff)
+			}
+		})
+	}
+}
+
 func TestGraveler_CommitExistingRange(t *testing.T) {
 	const (
 		expectedCommitID     = graveler.CommitID("expectedCommitId")
@@ -701,3 +848,447 @@ func TestGraveler_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestGraveler_SetIf(t *testing.T) {
+	errExists := errors.New("exists")
+	errTest := errors.New("some kind of err")
+	ifAbsent := func(current *graveler.Value) error {
+		if current != nil {
+			return errExists
+		}
+		return nil
+	}
+	value := graveler.Value{Identity: []byte("new")}
+	tests := []struct {
+		name             string
+		committedManager graveler.CommittedManager
+		stagingManager   *testutil.StagingFake
+		branch           *graveler.Branch
+		expectedSetValue *graveler.ValueRecord
+		expectedErr      error
+	}{
+		{
+			name:             "not in committed not in staging",
+			committedManager: &testutil.CommittedFake{Err: graveler.ErrNotFound},
+			stagingManager:   &testutil.StagingFake{Err: graveler.ErrNotFound},
+			branch:           &graveler.Branch{},
+			expectedSetValue: &graveler.ValueRecord{Key: []byte("key"), Value: &value},
+		},
+		{
+			name:             "exists only in committed",
+			committedManager: &testutil.CommittedFake{Value: &graveler.Value{Identity: []byte("committed")}},
+			stagingManager:   &testutil.StagingFake{Err: graveler.ErrNotFound},
+			branch:           &graveler.Branch{},
+			expectedErr:      errExists,
+		},
+		{
+			name:             "exists in staging",
+			committedManager: &testutil.CommittedFake{Err: graveler.ErrNotFound},
+			stagingManager:   &testutil.StagingFake{Value: &graveler.Value{Identity: []byte("staged")}},
+			branch:           &graveler.Branch{},
+			expectedErr:      errExists,
+		},
+		{
+			name:             "exists in committed tombstone in staging",
+			committedManager: &testutil.CommittedFake{Value: &graveler.Value{Identity: []byte("committed")}},
+			stagingManager:   &testutil.StagingFake{Value: nil},
+			branch:           &graveler.Branch{},
+			expectedSetValue: &graveler.ValueRecord{Key: []byte("key"), Value: &value},
+		},
+		{
+			name:             "committed failed",
+			committedManager: &testutil.CommittedFake{Err: errTest},
+			stagingManager:   &testutil.StagingFake{Err: graveler.ErrNotFound},
+			branch:           &graveler.Branch{},
+			expectedErr:      errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refManager := &testutil.RefsFake{Branch: tt.branch, Commit: &graveler.Commit{}}
+			g := graveler.NewGraveler(tt.committedManager, tt.stagingManager, refManager)
+			err := g.SetIf(context.Background(), "repo", "branch", []byte("key"), value, ifAbsent)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("SetIf() returned unexpected error. got = %v, expected %v", err, tt.expectedErr)
+			}
+			if diff := deep.Equal(tt.stagingManager.LastSetValueRecord, tt.expectedSetValue); diff != nil {
+				t.Errorf("unexpected set value %s", diff)
+			}
+		})
+	}
+}
+
+func TestGraveler_Merge(t *testing.T) {
+	const (
+		expectedCommitID     = graveler.CommitID("expectedCommitID")
+		expectedRangeID      = graveler.MetaRangeID("expectedRangeID")
+		expectedRepositoryID = graveler.RepositoryID("expectedRepositoryID")
+	)
+	tests := []struct {
+		name     string
+		strategy graveler.MergeStrategy
+		metadata graveler.Metadata
+		expected graveler.Metadata
+	}{
+		{
+			name:     "fail",
+			strategy: graveler.MergeStrategyFail,
+			expected: graveler.Metadata{graveler.MergeStrategyMetadataKey: "fail"},
+		},
+		{
+			name:     "source wins",
+			strategy: graveler.MergeStrategySourceWins,
+			metadata: graveler.Metadata{"key": "value"},
+			expected: graveler.Metadata{"key": "value", graveler.MergeStrategyMetadataKey: "source-wins"},
+		},
+		{
+			name:     "dest wins",
+			strategy: graveler.MergeStrategyDestWins,
+			expected: graveler.Metadata{graveler.MergeStrategyMetadataKey: "dest-wins"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refManager := &testutil.RefsFake{
+				RefType:  graveler.ReferenceTypeBranch,
+				CommitID: expectedCommitID,
+				Branch:   &graveler.Branch{CommitID: expectedCommitID},
+				Commit:   &graveler.Commit{MetaRangeID: expectedRangeID},
+			}
+			g := graveler.NewGraveler(&testutil.CommittedFake{MetaRangeID: expectedRangeID}, &testutil.StagingFake{}, refManager)
+			got, err := g.Merge(context.Background(), expectedRepositoryID, "source", "dest", "committer", "a message", tt.metadata, tt.strategy)
+			if err != nil {
+				t.Fatalf("unexpected err %v", err)
+			}
+			if got != expectedCommitID {
+				t.Errorf("got wrong commitID, got = %v, want %v", got, expectedCommitID)
+			}
+			if diff := deep.Equal(refManager.AddedCommit.Metadata, tt.expected); diff != nil {
+				t.Errorf("unexpected merge commit metadata %s", diff)
+			}
+		})
+	}
+}
+
+func TestGraveler_Revert(t *testing.T) {
+	const (
+		revertedCommitID = graveler.CommitID("reverted")
+		parentCommitID   = graveler.CommitID("parent")
+		headCommitID     = graveler.CommitID("head")
+		expectedCommitID = graveler.CommitID("expectedCommitID")
+		expectedRangeID  = graveler.MetaRangeID("expectedRangeID")
+	)
+	value := func(id string) *graveler.Value {
+		return &graveler.Value{Identity: []byte(id), Data: []byte(id)}
+	}
+	// diffs from the reverted commit to its parent
+	diffs := []graveler.Diff{
+		{Type: graveler.DiffTypeRemoved, Key: graveler.Key("a"), Value: value("a:reverted")},
+		{Type: graveler.DiffTypeAdded, Key: graveler.Key("b"), Value: value("b:parent")},
+		{Type: graveler.DiffTypeChanged, Key: graveler.Key("c"), Value: value("c:parent")},
+		{Type: graveler.DiffTypeChanged, Key: graveler.Key("d"), Value: value("d:parent")},
+	}
+	revertedValues := map[string]*graveler.Value{"a": value("a:reverted"), "c": value("c:reverted"), "d": value("d:reverted")}
+
+	tests := []struct {
+		name              string
+		headValues        map[string]*graveler.Value
+		staged            []graveler.ValueRecord
+		parents           graveler.CommitParents
+		expectedErr       error
+		expectedValues    []graveler.ValueRecord
+		expectedConflicts []graveler.Key
+	}{
+		{
+			name:       "revert",
+			headValues: map[string]*graveler.Value{"a": value("a:reverted"), "c": value("c:reverted"), "d": value("d:reverted")},
+			parents:    graveler.CommitParents{parentCommitID},
+			expectedValues: []graveler.ValueRecord{
+				{Key: graveler.Key("a")},
+				{Key: graveler.Key("b"), Value: value("b:parent")},
+				{Key: graveler.Key("c"), Value: value("c:parent")},
+				{Key: graveler.Key("d"), Value: value("d:parent")},
+			},
+		},
+		{
+			name:       "already reverted keys",
+			headValues: map[string]*graveler.Value{"b": value("b:parent"), "c": value("c:reverted"), "d": value("d:parent")},
+			parents:    graveler.CommitParents{parentCommitID},
+			expectedValues: []graveler.ValueRecord{
+				{Key: graveler.Key("c"), Value: value("c:parent")},
+			},
+		},
+		{
+			name:              "conflict",
+			headValues:        map[string]*graveler.Value{"a": value("a:later"), "b": value("b:later"), "c": value("c:reverted"), "d": value("d:later")},
+			parents:           graveler.CommitParents{parentCommitID},
+			expectedValues:    []graveler.ValueRecord{{Key: graveler.Key("c"), Value: value("c:parent")}},
+			expectedConflicts: []graveler.Key{graveler.Key("a"), graveler.Key("b"), graveler.Key("d")},
+		},
+		{
+			name:        "dirty branch",
+			staged:      []graveler.ValueRecord{{Key: graveler.Key("staged"), Value: value("staged")}},
+			parents:     graveler.CommitParents{parentCommitID},
+			expectedErr: graveler.ErrDirtyBranch,
+		},
+		{
+			name:        "merge commit",
+			parents:     graveler.CommitParents{parentCommitID, headCommitID},
+			expectedErr: graveler.ErrRevertNotSingleParent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			committedManager := &testutil.CommittedFake{
+				MetaRangeID:  expectedRangeID,
+				DiffIterator: testutil.NewDiffIter(diffs),
+				ValuesByMetaRange: map[graveler.MetaRangeID]map[string]*graveler.Value{
+					"reverted": revertedValues,
+					"head":     tt.headValues,
+				},
+			}
+			refManager := &testutil.RefsFake{
+				RefType:     graveler.ReferenceTypeCommit,
+				RefCommitID: revertedCommitID,
+				CommitID:    expectedCommitID,
+				Branch:      &graveler.Branch{CommitID: headCommitID},
+				Commits: map[graveler.CommitID]*graveler.Commit{
+					revertedCommitID: {MetaRangeID: "reverted", Parents: tt.parents},
+					parentCommitID:   {MetaRangeID: "parent"},
+					headCommitID:     {MetaRangeID: "head"},
+				},
+			}
+			g := graveler.NewGraveler(committedManager, &testutil.StagingFake{ValueIterator: testutil.NewValueIteratorFake(tt.staged)}, refManager)
+			got, err := g.Revert(context.Background(), "repo", "branch", graveler.Ref(revertedCommitID), "committer")
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("unexpected err got = %v, wanted = %v", err, tt.expectedErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != expectedCommitID {
+				t.Errorf("got wrong commitID, got = %v, want %v", got, expectedCommitID)
+			}
+			if committedManager.AppliedData.MetaRangeID != "head" {
+				t.Errorf("applied on metarange %s, expected head", committedManager.AppliedData.MetaRangeID)
+			}
+
+			// the fake does not read the applied changes, read them here
+			var values []graveler.ValueRecord
+			changes := committedManager.AppliedData.Values
+			for changes.Next() {
+				values = append(values, *changes.Value())
+			}
+			if diff := deep.Equal(values, tt.expectedValues); diff != nil {
+				t.Errorf("unexpected changes %s", diff)
+			}
+			var conflicts []graveler.Key
+			var conflictErr *graveler.ConflictError
+			if errors.As(changes.Err(), &conflictErr) {
+				conflicts = conflictErr.Keys
+			} else if err := changes.Err(); err != nil {
+				t.Fatalf("unexpected changes err %v", err)
+			}
+			if diff := deep.Equal(conflicts, tt.expectedConflicts); diff != nil {
+				t.Errorf("unexpected conflicts %s", diff)
+			}
+			if diff := deep.Equal(refManager.AddedCommit, testutil.AddedCommitData{
+				Committer:   "committer",
+				Message:     "Revert reverted",
+				MetaRangeID: expectedRangeID,
+				Parents:     graveler.CommitParents{headCommitID},
+				Metadata:    graveler.Metadata{graveler.RevertCommitMetadataKey: "reverted"},
+			}); diff != nil {
+				t.Errorf("unexpected added commit %s", diff)
+			}
+			if refManager.Branch.CommitID != expectedCommitID {
+				t.Errorf("branch points at commit %s, expected %s", refManager.Branch.CommitID, expectedCommitID)
+			}
+		})
+	}
+}
+
+func TestGraveler_CherryPick(t *testing.T) {
+	const (
+		pickedCommitID   = graveler.CommitID("picked")
+		parentCommitID   = graveler.CommitID("parent")
+		headCommitID     = graveler.CommitID("head")
+		expectedCommitID = graveler.CommitID("expectedCommitID")
+		expectedRangeID  = graveler.MetaRangeID("expectedRangeID")
+	)
+	value := func(id string) *graveler.Value {
+		return &graveler.Value{Identity: []byte(id), Data: []byte(id)}
+	}
+	// diffs from the parent to the picked commit
+	diffs := []graveler.Diff{
+		{Type: graveler.DiffTypeAdded, Key: graveler.Key("a"), Value: value("a:picked")},
+		{Type: graveler.DiffTypeRemoved, Key: graveler.Key("b"), Value: value("b:parent")},
+		{Type: graveler.DiffTypeChanged, Key: graveler.Key("c"), Value: value("c:picked")},
+	}
+	parentValues := map[string]*graveler.Value{"b": value("b:parent"), "c": value("c:parent")}
+
+	tests := []struct {
+		name              string
+		headValues        map[string]*graveler.Value
+		parents           graveler.CommitParents
+		expectedErr       error
+		expectedValues    []graveler.ValueRecord
+		expectedConflicts []graveler.Key
+	}{
+		{
+			name:       "cherry-pick",
+			headValues: map[string]*graveler.Value{"b": value("b:parent"), "c": value("c:parent")},
+			parents:    graveler.CommitParents{parentCommitID},
+			expectedValues: []graveler.ValueRecord{
+				{Key: graveler.Key("a"), Value: value("a:picked")},
+				{Key: graveler.Key("b")},
+				{Key: graveler.Key("c"), Value: value("c:picked")},
+			},
+		},
+		{
+			name:       "merge commit uses first parent",
+			headValues: map[string]*graveler.Value{"a": value("a:picked"), "c": value("c:parent")},
+			parents:    graveler.CommitParents{parentCommitID, headCommitID},
+			expectedValues: []graveler.ValueRecord{
+				{Key: graveler.Key("c"), Value: value("c:picked")},
+			},
+		},
+		{
+			name:              "conflict",
+			headValues:        map[string]*graveler.Value{"a": value("a:other"), "c": value("c:other")},
+			parents:           graveler.CommitParents{parentCommitID},
+			expectedConflicts: []graveler.Key{graveler.Key("a"), graveler.Key("c")},
+		},
+		{
+			name:        "no parent",
+			expectedErr: graveler.ErrCherryPickNoParent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			committedManager := &testutil.CommittedFake{
+				MetaRangeID:  expectedRangeID,
+				DiffIterator: testutil.NewDiffIter(diffs),
+				ValuesByMetaRange: map[graveler.MetaRangeID]map[string]*graveler.Value{
+					"parent": parentValues,
+					"head":   tt.headValues,
+				},
+			}
+			refManager := &testutil.RefsFake{
+				RefType:     graveler.ReferenceTypeCommit,
+				RefCommitID: pickedCommitID,
+				CommitID:    expectedCommitID,
+				Branch:      &graveler.Branch{CommitID: headCommitID},
+				Commits: map[graveler.CommitID]*graveler.Commit{
+					pickedCommitID: {Message: "fix", MetaRangeID: "picked", Parents: tt.parents, Metadata: graveler.Metadata{"key": "value"}},
+					parentCommitID: {MetaRangeID: "parent"},
+					headCommitID:   {MetaRangeID: "head"},
+				},
+			}
+			g := graveler.NewGraveler(committedManager, &testutil.StagingFake{ValueIterator: testutil.NewValueIteratorFake(nil)}, refManager)
+			got, err := g.CherryPick(context.Background(), "repo", graveler.Ref(pickedCommitID), "branch", "committer")
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("unexpected err got = %v, wanted = %v", err, tt.expectedErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != expectedCommitID {
+				t.Errorf("got wrong commitID, got = %v, want %v", got, expectedCommitID)
+			}
+
+			// the fake does not read the applied changes, read them here
+			var values []graveler.ValueRecord
+			changes := committedManager.AppliedData.Values
+			for changes.Next() {
+				values = append(values, *changes.Value())
+			}
+			if diff := deep.Equal(values, tt.expectedValues); diff != nil {
+				t.Errorf("unexpected changes %s", diff)
+			}
+			var conflicts []graveler.Key
+			var conflictErr *graveler.ConflictError
+			if errors.As(changes.Err(), &conflictErr) {
+				conflicts = conflictErr.Keys
+			} else if err := changes.Err(); err != nil {
+				t.Fatalf("unexpected changes err %v", err)
+			}
+			if diff := deep.Equal(conflicts, tt.expectedConflicts); diff != nil {
+				t.Errorf("unexpected conflicts %s", diff)
+			}
+			if diff := deep.Equal(refManager.AddedCommit, testutil.AddedCommitData{
+				Committer:   "committer",
+				Message:     "fix",
+				MetaRangeID: expectedRangeID,
+				Parents:     graveler.CommitParents{headCommitID},
+				Metadata:    graveler.Metadata{"key": "value", graveler.CherryPickCommitMetadataKey: "picked"},
+			}); diff != nil {
+				t.Errorf("unexpected added commit %s", diff)
+			}
+			if refManager.Branch.CommitID != expectedCommitID {
+				t.Errorf("branch points at commit %s, expected %s", refManager.Branch.CommitID, expectedCommitID)
+			}
+		})
+	}
+}
+
+func TestGraveler_LogByPrefix(t *testing.T) {
+	value := func(id string) *graveler.Value {
+		return &graveler.Value{Identity: []byte(id), Data: []byte(id)}
+	}
+	commits := map[graveler.CommitID]*graveler.Commit{
+		"c0":   {MetaRangeID: "mr0"},
+		"c1":   {MetaRangeID: "mr1", Parents: graveler.CommitParents{"c0"}},
+		"c2":   {MetaRangeID: "mr2", Parents: graveler.CommitParents{"c1"}},
+		"side": {MetaRangeID: "mrSide", Parents: graveler.CommitParents{"c0"}},
+		"c3":   {MetaRangeID: "mr3", Parents: graveler.CommitParents{"c2", "side"}},
+	}
+	walk := []graveler.CommitID{"c3", "c2", "side", "c1", "c0"}
+	committedManager := &testutil.CommittedFake{
+		ValuesByMetaRange: map[graveler.MetaRangeID]map[string]*graveler.Value{
+			"mr0":    {"a": value("a0"), "b/x": value("x0")},
+			"mr1":    {"a": value("a1"), "b/x": value("x0")},
+			"mr2":    {"a": value("a1"), "b/x": value("x2")},
+			"mrSide": {"a": value("aSide"), "b/x": value("x0")},
+			"mr3":    {"a": value("a1"), "b/x": value("x2")},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		prefix   graveler.Key
+		expected []graveler.CommitID
+	}{
+		{name: "key", prefix: graveler.Key("a"), expected: []graveler.CommitID{"side", "c1", "c0"}},
+		{name: "prefix", prefix: graveler.Key("b/"), expected: []graveler.CommitID{"c2", "c0"}},
+		{name: "no match", prefix: graveler.Key("c"), expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := make([]graveler.CommitRecord, len(walk))
+			for i, commitID := range walk {
+				records[i] = graveler.CommitRecord{CommitID: commitID, Commit: commits[commitID]}
+			}
+			refManager := &testutil.RefsFake{
+				Commits:    commits,
+				CommitIter: testutil.NewCommitIteratorFake(records),
+			}
+			g := graveler.NewGraveler(committedManager, nil, refManager)
+			it, err := g.LogByPrefix(context.Background(), "repo", "c3", tt.prefix)
+			if err != nil {
+				t.Fatalf("LogByPrefix: %s", err)
+			}
+			defer it.Close()
+			var commitIDs []graveler.CommitID
+			for it.Next() {
+				commitIDs = append(commitIDs, it.Value().CommitID)
+			}
+			if err := it.Err(); err != nil {
+				t.Fatalf("iterate log: %s", err)
+			}
+			if diff := deep.Equal(commitIDs, tt.expected); diff != nil {
+				t.Errorf("unexpected log: %s", diff)
+			}
+		})
+	}
+}
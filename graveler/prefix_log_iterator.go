@@ -0,0 +1,94 @@
+package graveler
+
+import (
+	"context"
+	"fmt"
+)
+
+// prefixLogIterator iterates over the commits of a commit walk that changed the value of a key
+// starting with prefix.  A commit changed the prefix if its metaRange differs under prefix from
+// the metaRanges of all its parents, or if it has no parents and holds a key under prefix.
+type prefixLogIterator struct {
+	ctx              context.Context
+	refManager       RefManager
+	committedManager CommittedManager
+	repositoryID     RepositoryID
+	storageNamespace StorageNamespace
+	commits          CommitIterator
+	prefix           Key
+	value            *CommitRecord
+	err              error
+}
+
+func newPrefixLogIterator(ctx context.Context, refManager RefManager, committedManager CommittedManager, repositoryID RepositoryID, sn StorageNamespace, commits CommitIterator, prefix Key) *prefixLogIterator {
+	return &prefixLogIterator{
+		ctx:              ctx,
+		refManager:       refManager,
+		committedManager: committedManager,
+		repositoryID:     repositoryID,
+		storageNamespace: sn,
+		commits:          commits,
+		prefix:           prefix,
+	}
+}
+
+// changed returns true if commit changed the value of a key under prefix
+func (p *prefixLogIterator) changed(commit *Commit) (bool, error) {
+	if len(commit.Parents) == 0 {
+		return p.committedManager.PrefixChanged(p.ctx, p.storageNamespace, "", commit.MetaRangeID, p.prefix)
+	}
+	for _, parentID := range commit.Parents {
+		parent, err := p.refManager.GetCommit(p.ctx, p.repositoryID, parentID)
+		if err != nil {
+			return false, fmt.Errorf("get parent commit %s: %w", parentID, err)
+		}
+		changed, err := p.committedManager.PrefixChanged(p.ctx, p.storageNamespace, parent.MetaRangeID, commit.MetaRangeID, p.prefix)
+		if err != nil {
+			return false, err
+		}
+		if !changed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (p *prefixLogIterator) Next() bool {
+	if p.err != nil {
+		return false
+	}
+	for p.commits.Next() {
+		record := p.commits.Value()
+		changed, err := p.changed(record.Commit)
+		if err != nil {
+			p.err = err
+			p.value = nil
+			return false
+		}
+		if changed {
+			p.value = record
+			return true
+		}
+	}
+	p.value = nil
+	p.err = p.commits.Err()
+	return false
+}
+
+func (p *prefixLogIterator) SeekGE(id CommitID) {
+	p.commits.SeekGE(id)
+	p.value = nil
+	p.err = nil
+}
+
+func (p *prefixLogIterator) Value() *CommitRecord {
+	return p.value
+}
+
+func (p *prefixLogIterator) Err() error {
+	return p.err
+}
+
+func (p *prefixLogIterator) Close() {
+	p.commits.Close()
+}
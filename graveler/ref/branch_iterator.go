@@ -23,6 +23,28 @@ type branchRecord struct {
 	BranchID     graveler.BranchID     `db:"id"`
 	CommitID     graveler.CommitID     `db:"commit_id"`
 	StagingToken graveler.StagingToken `db:"staging_token"`
+	SealedTokens []string              `db:"sealed_tokens"`
+}
+
+func (b *branchRecord) toGravelerBranch() *graveler.Branch {
+	var sealedTokens []graveler.StagingToken
+	for _, token := range b.SealedTokens {
+		sealedTokens = append(sealedTokens, graveler.StagingToken(token))
+	}
+	return &graveler.Branch{
+		CommitID:     b.CommitID,
+		StagingToken: b.StagingToken,
+		SealedTokens: sealedTokens,
+	}
+}
+
+// tokensToStrings converts staging tokens to a slice of strings for storing as a text array
+func tokensToStrings(tokens []graveler.StagingToken) []string {
+	res := make([]string, len(tokens))
+	for i, token := range tokens {
+		res[i] = string(token)
+	}
+	return res
 }
 
 func NewBranchIterator(ctx context.Context, db db.Database, repositoryID graveler.RepositoryID, prefetchSize int) *BranchIterator {
@@ -76,7 +98,7 @@ func (ri *BranchIterator) fetch() {
 
 	var buf []*branchRecord
 	err := ri.db.WithContext(ri.ctx).Select(&buf, `
-			SELECT id, staging_token, commit_id
+			SELECT id, staging_token, commit_id, sealed_tokens
 			FROM graveler_branches
 			WHERE repository_id = $1
 			AND id `+offsetCondition+` $2
@@ -92,10 +114,7 @@ func (ri *BranchIterator) fetch() {
 	for _, b := range buf {
 		rec := &graveler.BranchRecord{
 			BranchID: b.BranchID,
-			Branch: &graveler.Branch{
-				CommitID:     b.CommitID,
-				StagingToken: b.StagingToken,
-			},
+			Branch:   b.toGravelerBranch(),
 		}
 		ri.buf = append(ri.buf, rec)
 	}
@@ -20,6 +20,7 @@ type CommitWalker struct {
 	queue         []graveler.CommitID
 	discoveredSet map[graveler.CommitID]struct{}
 	value         *graveler.Commit
+	valueID       graveler.CommitID
 	err           error
 }
 
@@ -36,6 +37,7 @@ func NewCommitWalker(ctx context.Context, getter CommitGetter, repositoryID grav
 func (w *CommitWalker) Next() bool {
 	if w.err != nil || len(w.queue) == 0 {
 		w.value = nil
+		w.valueID = ""
 		return false // no more values to walk!
 	}
 
@@ -46,6 +48,7 @@ func (w *CommitWalker) Next() bool {
 	if err != nil {
 		w.err = err
 		w.value = nil
+		w.valueID = ""
 		return false
 	}
 
@@ -57,6 +60,7 @@ func (w *CommitWalker) Next() bool {
 		}
 	}
 	w.value = commit
+	w.valueID = addr
 	return true
 }
 
@@ -68,6 +72,49 @@ func (w *CommitWalker) Err() error {
 	return w.err
 }
 
+// CommitWalkIterator is a graveler.CommitIterator over all commits reachable from a commit,
+// following all parents of each commit.
+type CommitWalkIterator struct {
+	getter       CommitGetter
+	ctx          context.Context
+	repositoryID graveler.RepositoryID
+	walker       *CommitWalker
+}
+
+func NewCommitWalkIterator(ctx context.Context, getter CommitGetter, repositoryID graveler.RepositoryID, startID graveler.CommitID) *CommitWalkIterator {
+	return &CommitWalkIterator{
+		getter:       getter,
+		ctx:          ctx,
+		repositoryID: repositoryID,
+		walker:       NewCommitWalker(ctx, getter, repositoryID, startID),
+	}
+}
+
+func (it *CommitWalkIterator) Next() bool {
+	return it.walker.Next()
+}
+
+// SeekGE restarts the walk at id
+func (it *CommitWalkIterator) SeekGE(id graveler.CommitID) {
+	it.walker = NewCommitWalker(it.ctx, it.getter, it.repositoryID, id)
+}
+
+func (it *CommitWalkIterator) Value() *graveler.CommitRecord {
+	if it.walker.Value() == nil {
+		return nil
+	}
+	return &graveler.CommitRecord{
+		CommitID: it.walker.valueID,
+		Commit:   it.walker.Value(),
+	}
+}
+
+func (it *CommitWalkIterator) Err() error {
+	return it.walker.Err()
+}
+
+func (it *CommitWalkIterator) Close() {}
+
 func FindLowestCommonAncestor(ctx context.Context, getter CommitGetter, repositoryID graveler.RepositoryID, left, right graveler.CommitID) (*graveler.Commit, error) {
 	discoveredSet := make(map[string]struct{})
 	iterLeft := NewCommitWalker(ctx, getter, repositoryID, left)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-test/deep"
 	"github.com/treeverse/lakefs/graveler"
 	"github.com/treeverse/lakefs/graveler/ref"
 	"github.com/treeverse/lakefs/ident"
@@ -168,3 +169,40 @@ func TestFindLowestCommonAncestor(t *testing.T) {
 		})
 	}
 }
+
+func TestCommitWalkIterator(t *testing.T) {
+	c0 := &graveler.Commit{Message: "0", Parents: []graveler.CommitID{}}
+	c1 := &graveler.Commit{Message: "1", Parents: []graveler.CommitID{caddr(c0)}}
+	c2 := &graveler.Commit{Message: "2", Parents: []graveler.CommitID{caddr(c0)}}
+	c3 := &graveler.Commit{Message: "3", Parents: []graveler.CommitID{caddr(c1), caddr(c2)}}
+	getter := newReader(map[graveler.CommitID]*graveler.Commit{
+		"c0": c0, "c1": c1, "c2": c2, "c3": c3,
+	})
+
+	it := ref.NewCommitWalkIterator(context.Background(), getter, "", caddr(c3))
+	defer it.Close()
+	collect := func() []graveler.CommitID {
+		var ids []graveler.CommitID
+		for it.Next() {
+			v := it.Value()
+			if v.CommitID != caddr(v.Commit) {
+				t.Errorf("commit record ID %s does not match commit %s", v.CommitID, caddr(v.Commit))
+			}
+			ids = append(ids, v.CommitID)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("walk failed: %s", err)
+		}
+		return ids
+	}
+
+	expected := []graveler.CommitID{caddr(c3), caddr(c1), caddr(c2), caddr(c0)}
+	if diff := deep.Equal(collect(), expected); diff != nil {
+		t.Errorf("unexpected walk: %s", diff)
+	}
+	it.SeekGE(caddr(c1))
+	expected = []graveler.CommitID{caddr(c1), caddr(c0)}
+	if diff := deep.Equal(collect(), expected); diff != nil {
+		t.Errorf("unexpected walk after seek: %s", diff)
+	}
+}
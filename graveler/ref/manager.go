@@ -53,9 +53,9 @@ func (m *Manager) CreateRepository(ctx context.Context, repositoryID graveler.Re
 			return nil, err
 		}
 		_, err = tx.Exec(`
-				INSERT INTO graveler_branches (repository_id, id, staging_token, commit_id)
-				VALUES ($1, $2, $3, $4)`,
-			repositoryID, repository.DefaultBranchID, branch.StagingToken, branch.CommitID)
+				INSERT INTO graveler_branches (repository_id, id, staging_token, commit_id, sealed_tokens)
+				VALUES ($1, $2, $3, $4, $5)`,
+			repositoryID, repository.DefaultBranchID, branch.StagingToken, branch.CommitID, tokensToStrings(branch.SealedTokens))
 		return nil, err
 	}, db.WithContext(ctx))
 	return err
@@ -89,15 +89,12 @@ func (m *Manager) RevParse(ctx context.Context, repositoryID graveler.Repository
 func (m *Manager) GetBranch(ctx context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID) (*graveler.Branch, error) {
 	branch, err := m.db.Transact(func(tx db.Tx) (interface{}, error) {
 		var rec branchRecord
-		err := tx.Get(&rec, `SELECT commit_id, staging_token FROM graveler_branches WHERE repository_id = $1 AND id = $2`,
+		err := tx.Get(&rec, `SELECT commit_id, staging_token, sealed_tokens FROM graveler_branches WHERE repository_id = $1 AND id = $2`,
 			repositoryID, branchID)
 		if err != nil {
 			return nil, err
 		}
-		return &graveler.Branch{
-			CommitID:     rec.CommitID,
-			StagingToken: rec.StagingToken,
-		}, nil
+		return rec.toGravelerBranch(), nil
 	}, db.ReadOnly(), db.WithContext(ctx))
 	if errors.Is(err, db.ErrNotFound) {
 		return nil, graveler.ErrNotFound
@@ -111,11 +108,11 @@ func (m *Manager) GetBranch(ctx context.Context, repositoryID graveler.Repositor
 func (m *Manager) SetBranch(ctx context.Context, repositoryID graveler.RepositoryID, branchID graveler.BranchID, branch graveler.Branch) error {
 	_, err := m.db.Transact(func(tx db.Tx) (interface{}, error) {
 		_, err := tx.Exec(`
-			INSERT INTO graveler_branches (repository_id, id, staging_token, commit_id)
-			VALUES ($1, $2, $3, $4)
+			INSERT INTO graveler_branches (repository_id, id, staging_token, commit_id, sealed_tokens)
+			VALUES ($1, $2, $3, $4, $5)
 				ON CONFLICT (repository_id, id)
-				DO UPDATE SET staging_token = $3, commit_id = $4`,
-			repositoryID, branchID, branch.StagingToken, branch.CommitID)
+				DO UPDATE SET staging_token = $3, commit_id = $4, sealed_tokens = $5`,
+			repositoryID, branchID, branch.StagingToken, branch.CommitID, tokensToStrings(branch.SealedTokens))
 		return nil, err
 	}, db.WithContext(ctx))
 	return err
@@ -295,3 +292,7 @@ func (m *Manager) FindMergeBase(ctx context.Context, repositoryID graveler.Repos
 func (m *Manager) Log(ctx context.Context, repositoryID graveler.RepositoryID, from graveler.CommitID) (graveler.CommitIterator, error) {
 	return NewCommitIterator(ctx, m.db, repositoryID, from), nil
 }
+
+func (m *Manager) WalkCommits(ctx context.Context, repositoryID graveler.RepositoryID, from graveler.CommitID) (graveler.CommitIterator, error) {
+	return NewCommitWalkIterator(ctx, m, repositoryID, from), nil
+}
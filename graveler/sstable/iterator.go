@@ -53,9 +53,11 @@ func (iter *Iterator) Value() *committed.Record {
 		return nil
 	}
 
+	// key and value are only valid until the next call to the underlying iterator, copy them
+	// so callers may keep records while advancing.
 	return &committed.Record{
-		Key:   iter.currKey.UserKey,
-		Value: iter.currValue,
+		Key:   append(committed.Key(nil), iter.currKey.UserKey...),
+		Value: append(committed.Value(nil), iter.currValue...),
 	}
 }
 
@@ -2,10 +2,10 @@ package sstable
 
 import (
 	"bytes"
-	"errors"
+	"crypto"
 	"fmt"
-	"hash"
 
+	"github.com/treeverse/lakefs/graveler"
 	"github.com/treeverse/lakefs/graveler/committed"
 	"github.com/treeverse/lakefs/logging"
 	"github.com/treeverse/lakefs/pyramid"
@@ -15,16 +15,21 @@ type Manager struct {
 	cache  cache
 	fs     pyramid.FS
 	logger logging.Logger
-	hash   hash.Hash
+	hash   crypto.Hash
 }
 
-func NewPebbleSSTableManager(cache cache, fs pyramid.FS, hash hash.Hash) committed.RangeManager {
-	return &Manager{cache: cache, fs: fs, hash: hash}
+func NewPebbleSSTableManager(cache cache, fs pyramid.FS, hash crypto.Hash) committed.RangeManager {
+	return &Manager{
+		cache:  cache,
+		fs:     fs,
+		logger: logging.Default().WithField("service_name", "sstable_manager"),
+		hash:   hash,
+	}
 }
 
 var (
 	// ErrPathNotFound is the error returned when the path is not found
-	ErrPathNotFound = errors.New("path not found")
+	ErrPathNotFound = fmt.Errorf("path %w", graveler.ErrNotFound)
 )
 
 // GetEntry returns the entry matching the path in the SSTable referenced by the id.
@@ -85,7 +90,12 @@ func (m *Manager) NewRangeIterator(ns committed.Namespace, tid committed.ID, fro
 
 // GetWriter returns a new SSTable writer instance
 func (m *Manager) GetWriter(ns committed.Namespace) (committed.RangeWriter, error) {
-	return NewDiskWriter(m.fs, ns, m.hash)
+	// each writer computes its own ID, so it needs its own hash
+	writer, err := NewDiskWriter(m.fs, ns, m.hash.New())
+	if err != nil {
+		return nil, err
+	}
+	return writer, nil
 }
 
 // GetBatchManager returns a new BatchCloser
@@ -1,7 +1,8 @@
 package sstable_test
 
 import (
-	"crypto/sha256"
+	"crypto"
+	_ "crypto/sha256"
 	"errors"
 	"testing"
 
@@ -19,7 +20,7 @@ func TestGetEntrySuccess(t *testing.T) {
 	mockCache := ssMock.NewMockcache(ctrl)
 	mockFS := fsMock.NewMockFS(ctrl)
 
-	sut := sstable.NewPebbleSSTableManager(mockCache, mockFS, sha256.New())
+	sut := sstable.NewPebbleSSTableManager(mockCache, mockFS, crypto.SHA256)
 
 	ns := "some-ns"
 	keys := randomStrings(10)
@@ -49,7 +50,7 @@ func TestGetEntryCacheFailure(t *testing.T) {
 	mockCache := ssMock.NewMockcache(ctrl)
 	mockFS := fsMock.NewMockFS(ctrl)
 
-	sut := sstable.NewPebbleSSTableManager(mockCache, mockFS, sha256.New())
+	sut := sstable.NewPebbleSSTableManager(mockCache, mockFS, crypto.SHA256)
 
 	ns := "some-ns"
 	sstableID := "some-id"
@@ -69,7 +70,7 @@ func TestGetEntryNotFound(t *testing.T) {
 	mockCache := ssMock.NewMockcache(ctrl)
 	mockFS := fsMock.NewMockFS(ctrl)
 
-	sut := sstable.NewPebbleSSTableManager(mockCache, mockFS, sha256.New())
+	sut := sstable.NewPebbleSSTableManager(mockCache, mockFS, crypto.SHA256)
 
 	ns := "some-ns"
 	keys := randomStrings(10)
@@ -99,7 +100,7 @@ func TestGetWriterSuccess(t *testing.T) {
 	mockCache := ssMock.NewMockcache(ctrl)
 	mockFS := fsMock.NewMockFS(ctrl)
 
-	sut := sstable.NewPebbleSSTableManager(mockCache, mockFS, sha256.New())
+	sut := sstable.NewPebbleSSTableManager(mockCache, mockFS, crypto.SHA256)
 
 	ns := "some-ns"
 	mockFile := fsMock.NewMockStoredFile(ctrl)
@@ -122,7 +123,7 @@ func TestNewPartIteratorSuccess(t *testing.T) {
 	mockCache := ssMock.NewMockcache(ctrl)
 	mockFS := fsMock.NewMockFS(ctrl)
 
-	sut := sstable.NewPebbleSSTableManager(mockCache, mockFS, sha256.New())
+	sut := sstable.NewPebbleSSTableManager(mockCache, mockFS, crypto.SHA256)
 
 	ns := "some-ns"
 	keys := randomStrings(10)
@@ -2,6 +2,8 @@ package staging
 
 import (
 	"context"
+	"errors"
+	"hash/fnv"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/treeverse/lakefs/db"
@@ -44,16 +46,64 @@ func (p *Manager) Set(ctx context.Context, st graveler.StagingToken, key gravele
 		return graveler.ErrInvalidValue
 	}
 	_, err := p.db.Transact(func(tx db.Tx) (interface{}, error) {
-		return tx.Exec(`INSERT INTO graveler_staging_kv (staging_token, key, identity, data)
-								VALUES ($1, $2, $3, $4)
-								ON CONFLICT (staging_token, key) DO UPDATE
-									SET (staging_token, key, identity, data) =
-											(excluded.staging_token, excluded.key, excluded.identity, excluded.data)`,
-			st, key, value.Identity, value.Data)
+		if err := lockKey(tx, st, key); err != nil {
+			return nil, err
+		}
+		return setValue(tx, st, key, value)
+	}, p.txOpts(ctx)...)
+	return err
+}
+
+func (p *Manager) Update(ctx context.Context, st graveler.StagingToken, key graveler.Key, updateFunc graveler.ValueUpdateFunc) error {
+	_, err := p.db.Transact(func(tx db.Tx) (interface{}, error) {
+		if err := lockKey(tx, st, key); err != nil {
+			return nil, err
+		}
+		var current *graveler.Value
+		value := &graveler.Value{}
+		err := tx.Get(value, "SELECT identity, data FROM graveler_staging_kv WHERE staging_token=$1 AND key=$2", st, key)
+		switch {
+		case errors.Is(err, db.ErrNotFound):
+			err = graveler.ErrNotFound
+		case err != nil:
+			return nil, err
+		case value.Identity != nil:
+			current = value
+		}
+		updated, err := updateFunc(current, err)
+		if err != nil {
+			return nil, err
+		}
+		if updated == nil {
+			updated = new(graveler.Value)
+		} else if updated.Identity == nil {
+			return nil, graveler.ErrInvalidValue
+		}
+		return setValue(tx, st, key, updated)
 	}, p.txOpts(ctx)...)
 	return err
 }
 
+// lockKey serializes writes of key under st until tx ends.  Keys are locked even when they
+// have no value yet, which a row lock cannot do.
+func lockKey(tx db.Tx, st graveler.StagingToken, key graveler.Key) error {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(st))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write(key)
+	_, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", int64(h.Sum64()))
+	return err
+}
+
+func setValue(tx db.Tx, st graveler.StagingToken, key graveler.Key, value *graveler.Value) (interface{}, error) {
+	return tx.Exec(`INSERT INTO graveler_staging_kv (staging_token, key, identity, data)
+							VALUES ($1, $2, $3, $4)
+							ON CONFLICT (staging_token, key) DO UPDATE
+								SET (staging_token, key, identity, data) =
+										(excluded.staging_token, excluded.key, excluded.identity, excluded.data)`,
+		st, key, value.Identity, value.Data)
+}
+
 func (p *Manager) DropKey(ctx context.Context, st graveler.StagingToken, key graveler.Key) error {
 	_, err := p.db.Transact(func(tx db.Tx) (interface{}, error) {
 		return tx.Exec("DELETE FROM graveler_staging_kv WHERE staging_token=$1 AND key=$2", st, key)
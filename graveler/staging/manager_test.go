@@ -409,6 +409,54 @@ func TestDeleteAndTombstone(t *testing.T) {
 	}
 }
 
+func TestUpdate(t *testing.T) {
+	ctx, s := newTestStagingManager(t)
+	errExists := errors.New("exists")
+	key := []byte("a/b/c/")
+	createIfAbsent := func(identity string) graveler.ValueUpdateFunc {
+		return func(value *graveler.Value, err error) (*graveler.Value, error) {
+			if !errors.Is(err, graveler.ErrNotFound) {
+				return nil, errExists
+			}
+			return newTestValue(identity, "value"), nil
+		}
+	}
+	const writers = 10
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		go func(i int) {
+			errs <- s.Update(ctx, "t1", key, createIfAbsent(fmt.Sprintf("identity%d", i)))
+		}(i)
+	}
+	created := 0
+	for i := 0; i < writers; i++ {
+		err := <-errs
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, errExists):
+			t.Fatalf("unexpected error from update: %v", err)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("expected exactly one update to create the key, got %d", created)
+	}
+
+	// update replaces the current value
+	err := s.Update(ctx, "t1", key, func(value *graveler.Value, err error) (*graveler.Value, error) {
+		if err != nil || value == nil {
+			t.Fatalf("expected current value, got value=%v err=%v", value, err)
+		}
+		return newTestValue("updated", "value"), nil
+	})
+	testutil.Must(t, err)
+	e, err := s.Get(ctx, "t1", key)
+	testutil.Must(t, err)
+	if string(e.Identity) != "updated" {
+		t.Errorf("got wrong identity. expected=%s, got=%s", "updated", string(e.Identity))
+	}
+}
+
 func newTestValue(identity, data string) *graveler.Value {
 	return &graveler.Value{
 		Identity: []byte(identity),
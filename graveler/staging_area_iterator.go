@@ -0,0 +1,82 @@
+package graveler
+
+import (
+	"bytes"
+)
+
+// stagingAreaIterator iterates over the listings of several staging tokens of the same branch.
+// In case of duplication returns the value of the first iterator holding the key.  Unlike
+// CombinedIterator, tombstones are returned as they mark deletions of committed values.
+type stagingAreaIterator struct {
+	iterators []ValueIterator
+	ok        []bool
+	value     *ValueRecord
+	err       error
+	started   bool
+}
+
+func newStagingAreaIterator(iterators ...ValueIterator) *stagingAreaIterator {
+	return &stagingAreaIterator{
+		iterators: iterators,
+		ok:        make([]bool, len(iterators)),
+	}
+}
+
+func (s *stagingAreaIterator) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	// advance iterators that are at the current key, or all of them on the first call.  keep a
+	// copy of the key as advancing an iterator may reuse its value.
+	var key Key
+	if s.value != nil {
+		key = append(Key(nil), s.value.Key...)
+	}
+	for i, it := range s.iterators {
+		if !s.started || (s.ok[i] && bytes.Equal(it.Value().Key, key)) {
+			s.ok[i] = it.Next()
+			if err := it.Err(); err != nil {
+				s.err = err
+				s.value = nil
+				return false
+			}
+		}
+	}
+	s.started = true
+	s.value = nil
+	for i, it := range s.iterators {
+		if !s.ok[i] {
+			continue
+		}
+		if v := it.Value(); s.value == nil || bytes.Compare(v.Key, s.value.Key) < 0 {
+			s.value = v
+		}
+	}
+	return s.value != nil
+}
+
+func (s *stagingAreaIterator) SeekGE(id Key) {
+	for _, it := range s.iterators {
+		it.SeekGE(id)
+	}
+	for i := range s.ok {
+		s.ok[i] = false
+	}
+	s.value = nil
+	s.err = nil
+	s.started = false
+}
+
+func (s *stagingAreaIterator) Value() *ValueRecord {
+	return s.value
+}
+
+func (s *stagingAreaIterator) Err() error {
+	return s.err
+}
+
+func (s *stagingAreaIterator) Close() {
+	for _, it := range s.iterators {
+		it.Close()
+	}
+}
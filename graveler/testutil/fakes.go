@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/treeverse/lakefs/graveler"
 	"github.com/treeverse/lakefs/graveler/committed"
@@ -20,10 +21,16 @@ type AppliedData struct {
 type CommittedFake struct {
 	Value         *graveler.Value
 	ValueIterator graveler.ValueIterator
-	diffIterator  graveler.DiffIterator
+	DiffIterator  graveler.DiffIterator
 	Err           error
 	MetaRangeID   graveler.MetaRangeID
 	AppliedData   AppliedData
+	// ValuesByMetaRange, when set, holds the values returned by Get for each metaRange
+	ValuesByMetaRange map[graveler.MetaRangeID]map[string]*graveler.Value
+	// ApplyFunc, when set, is called by Apply, as if it ran while the commit was written
+	ApplyFunc func()
+	// DiffLeft and DiffRight are the metaRanges of the last call to Diff
+	DiffLeft, DiffRight graveler.MetaRangeID
 }
 
 type MetaRangeFake struct {
@@ -38,10 +45,17 @@ func NewCommittedFake() graveler.CommittedManager {
 	return &CommittedFake{}
 }
 
-func (c *CommittedFake) Get(_ context.Context, _ graveler.StorageNamespace, _ graveler.MetaRangeID, _ graveler.Key) (*graveler.Value, error) {
+func (c *CommittedFake) Get(_ context.Context, _ graveler.StorageNamespace, metaRangeID graveler.MetaRangeID, key graveler.Key) (*graveler.Value, error) {
 	if c.Err != nil {
 		return nil, c.Err
 	}
+	if c.ValuesByMetaRange != nil {
+		value, ok := c.ValuesByMetaRange[metaRangeID][string(key)]
+		if !ok {
+			return nil, graveler.ErrNotFound
+		}
+		return value, nil
+	}
 	return c.Value, nil
 }
 
@@ -52,6 +66,32 @@ func (c *CommittedFake) GetMetaRange(_ graveler.StorageNamespace, metaRangeID gr
 	return &MetaRangeFake{id: metaRangeID}, nil
 }
 
+func (c *CommittedFake) PrefixChanged(_ context.Context, _ graveler.StorageNamespace, left, right graveler.MetaRangeID, prefix graveler.Key) (bool, error) {
+	if c.Err != nil {
+		return false, c.Err
+	}
+	leftValues := c.ValuesByMetaRange[left]
+	rightValues := c.ValuesByMetaRange[right]
+	for key, value := range leftValues {
+		if strings.HasPrefix(key, string(prefix)) && !sameValue(value, rightValues[key]) {
+			return true, nil
+		}
+	}
+	for key, value := range rightValues {
+		if _, ok := leftValues[key]; !ok && strings.HasPrefix(key, string(prefix)) && value != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func sameValue(a, b *graveler.Value) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return bytes.Equal(a.Identity, b.Identity)
+}
+
 func (c *CommittedFake) List(_ context.Context, _ graveler.StorageNamespace, _ graveler.MetaRangeID) (graveler.ValueIterator, error) {
 	if c.Err != nil {
 		return nil, c.Err
@@ -59,14 +99,15 @@ func (c *CommittedFake) List(_ context.Context, _ graveler.StorageNamespace, _ g
 	return c.ValueIterator, nil
 }
 
-func (c *CommittedFake) Diff(_ context.Context, _ graveler.StorageNamespace, _, _ graveler.MetaRangeID) (graveler.DiffIterator, error) {
+func (c *CommittedFake) Diff(_ context.Context, _ graveler.StorageNamespace, left, right graveler.MetaRangeID) (graveler.DiffIterator, error) {
 	if c.Err != nil {
 		return nil, c.Err
 	}
-	return c.diffIterator, nil
+	c.DiffLeft, c.DiffRight = left, right
+	return c.DiffIterator, nil
 }
 
-func (c *CommittedFake) Merge(_ context.Context, _ graveler.StorageNamespace, _, _, _ graveler.MetaRangeID, _, _ string, _ graveler.Metadata) (graveler.MetaRangeID, error) {
+func (c *CommittedFake) Merge(_ context.Context, _ graveler.StorageNamespace, _, _, _ graveler.MetaRangeID, _, _ string, _ graveler.Metadata, _ graveler.MergeStrategy) (graveler.MetaRangeID, error) {
 	if c.Err != nil {
 		return "", c.Err
 	}
@@ -77,6 +118,9 @@ func (c *CommittedFake) Apply(_ context.Context, _ graveler.StorageNamespace, me
 	if c.Err != nil {
 		return "", c.Err
 	}
+	if c.ApplyFunc != nil {
+		c.ApplyFunc()
+	}
 	c.AppliedData.Values = values
 	c.AppliedData.MetaRangeID = metaRangeID
 	return c.MetaRangeID, nil
@@ -98,6 +142,7 @@ type StagingFake struct {
 	LastSetValueRecord *graveler.ValueRecord
 	LastRemovedKey     graveler.Key
 	DropCalled         bool
+	DroppedTokens      []graveler.StagingToken
 	SetErr             error
 }
 
@@ -105,8 +150,9 @@ func (s *StagingFake) DropByPrefix(_ context.Context, _ graveler.StagingToken, _
 	return nil
 }
 
-func (s *StagingFake) Drop(_ context.Context, _ graveler.StagingToken) error {
+func (s *StagingFake) Drop(_ context.Context, stagingToken graveler.StagingToken) error {
 	s.DropCalled = true
+	s.DroppedTokens = append(s.DroppedTokens, stagingToken)
 	if s.DropErr != nil {
 		return s.DropErr
 	}
@@ -131,6 +177,21 @@ func (s *StagingFake) Set(_ context.Context, _ graveler.StagingToken, key gravel
 	return nil
 }
 
+func (s *StagingFake) Update(_ context.Context, _ graveler.StagingToken, key graveler.Key, updateFunc graveler.ValueUpdateFunc) error {
+	if s.SetErr != nil {
+		return s.SetErr
+	}
+	value, err := updateFunc(s.Value, s.Err)
+	if err != nil {
+		return err
+	}
+	s.LastSetValueRecord = &graveler.ValueRecord{
+		Key:   key,
+		Value: value,
+	}
+	return nil
+}
+
 func (s *StagingFake) DropKey(_ context.Context, _ graveler.StagingToken, key graveler.Key) error {
 	if s.Err != nil {
 		return s.Err
@@ -179,8 +240,15 @@ type RefsFake struct {
 	Err                 error
 	CommitErr           error
 	AddedCommit         AddedCommitData
+	SetBranches         []graveler.Branch
 	CommitID            graveler.CommitID
 	Commit              *graveler.Commit
+	// RefCommitID is the commit ID RevParse resolves references to
+	RefCommitID graveler.CommitID
+	// Commits, when set, holds the commits returned by GetCommit
+	Commits map[graveler.CommitID]*graveler.Commit
+	// MergeBase, when set, is the commit returned by FindMergeBase
+	MergeBase *graveler.Commit
 }
 
 func (m *RefsFake) RevParse(_ context.Context, _ graveler.RepositoryID, _ graveler.Ref) (graveler.Reference, error) {
@@ -188,7 +256,7 @@ func (m *RefsFake) RevParse(_ context.Context, _ graveler.RepositoryID, _ gravel
 	if m.RefType == graveler.ReferenceTypeBranch {
 		branch = DefaultBranchID
 	}
-	return NewFakeReference(m.RefType, branch, ""), nil
+	return NewFakeReference(m.RefType, branch, m.RefCommitID), nil
 }
 
 func (m *RefsFake) GetRepository(_ context.Context, _ graveler.RepositoryID) (*graveler.Repository, error) {
@@ -211,7 +279,9 @@ func (m *RefsFake) GetBranch(_ context.Context, _ graveler.RepositoryID, _ grave
 	return m.Branch, m.Err
 }
 
-func (m *RefsFake) SetBranch(_ context.Context, _ graveler.RepositoryID, _ graveler.BranchID, _ graveler.Branch) error {
+func (m *RefsFake) SetBranch(_ context.Context, _ graveler.RepositoryID, _ graveler.BranchID, branch graveler.Branch) error {
+	m.SetBranches = append(m.SetBranches, branch)
+	m.Branch = &branch
 	return nil
 }
 
@@ -239,7 +309,14 @@ func (m *RefsFake) ListTags(_ context.Context, _ graveler.RepositoryID) (gravele
 	return m.ListTagsRes, nil
 }
 
-func (m *RefsFake) GetCommit(_ context.Context, _ graveler.RepositoryID, _ graveler.CommitID) (*graveler.Commit, error) {
+func (m *RefsFake) GetCommit(_ context.Context, _ graveler.RepositoryID, commitID graveler.CommitID) (*graveler.Commit, error) {
+	if m.Commits != nil {
+		commit, ok := m.Commits[commitID]
+		if !ok {
+			return nil, graveler.ErrCommitNotFound
+		}
+		return commit, nil
+	}
 	return m.Commit, nil
 }
 
@@ -258,6 +335,9 @@ func (m *RefsFake) AddCommit(_ context.Context, _ graveler.RepositoryID, commit
 }
 
 func (m *RefsFake) FindMergeBase(_ context.Context, _ graveler.RepositoryID, _ ...graveler.CommitID) (*graveler.Commit, error) {
+	if m.MergeBase != nil {
+		return m.MergeBase, nil
+	}
 	return &graveler.Commit{}, nil
 }
 
@@ -265,6 +345,10 @@ func (m *RefsFake) Log(_ context.Context, _ graveler.RepositoryID, _ graveler.Co
 	return m.CommitIter, nil
 }
 
+func (m *RefsFake) WalkCommits(_ context.Context, _ graveler.RepositoryID, _ graveler.CommitID) (graveler.CommitIterator, error) {
+	return m.CommitIter, nil
+}
+
 type diffIter struct {
 	current int
 	records []graveler.Diff
@@ -337,6 +421,117 @@ func (r *valueIteratorFake) Err() error {
 
 func (r *valueIteratorFake) Close() {}
 
+type commitIteratorFake struct {
+	current int
+	records []graveler.CommitRecord
+}
+
+func NewCommitIteratorFake(records []graveler.CommitRecord) graveler.CommitIterator {
+	return &commitIteratorFake{records: records, current: -1}
+}
+
+func (r *commitIteratorFake) Next() bool {
+	r.current++
+	return r.current < len(r.records)
+}
+
+func (r *commitIteratorFake) SeekGE(id graveler.CommitID) {
+	for i, record := range r.records {
+		if record.CommitID == id {
+			r.current = i - 1
+			return
+		}
+	}
+	r.current = len(r.records)
+}
+
+func (r *commitIteratorFake) Value() *graveler.CommitRecord {
+	if r.current < 0 || r.current >= len(r.records) {
+		return nil
+	}
+	return &r.records[r.current]
+}
+
+func (r *commitIteratorFake) Err() error {
+	return nil
+}
+
+func (r *commitIteratorFake) Close() {}
+
+type branchIteratorFake struct {
+	current int
+	records []graveler.BranchRecord
+}
+
+func NewBranchIteratorFake(records []graveler.BranchRecord) graveler.BranchIterator {
+	return &branchIteratorFake{records: records, current: -1}
+}
+
+func (r *branchIteratorFake) Next() bool {
+	r.current++
+	return r.current < len(r.records)
+}
+
+func (r *branchIteratorFake) SeekGE(id graveler.BranchID) {
+	for i, record := range r.records {
+		if record.BranchID >= id {
+			r.current = i - 1
+			return
+		}
+	}
+	r.current = len(r.records)
+}
+
+func (r *branchIteratorFake) Value() *graveler.BranchRecord {
+	if r.current < 0 || r.current >= len(r.records) {
+		return nil
+	}
+	return &r.records[r.current]
+}
+
+func (r *branchIteratorFake) Err() error {
+	return nil
+}
+
+func (r *branchIteratorFake) Close() {}
+
+type tagIteratorFake struct {
+	current int
+	records []graveler.TagRecord
+}
+
+func NewTagIteratorFake(records []graveler.TagRecord) graveler.TagIterator {
+	return &tagIteratorFake{records: records, current: -1}
+}
+
+func (r *tagIteratorFake) Next() bool {
+	r.current++
+	return r.current < len(r.records)
+}
+
+func (r *tagIteratorFake) SeekGE(id graveler.TagID) {
+	for i, record := range r.records {
+		if record.TagID >= id {
+			r.current = i - 1
+			return
+		}
+	}
+	r.current = len(r.records)
+}
+
+func (r *tagIteratorFake) Value() *graveler.TagRecord {
+	if r.current < 0 || r.current >= len(r.records) {
+		return nil
+	}
+	return &r.records[r.current]
+}
+
+func (r *tagIteratorFake) Err() error {
+	return nil
+}
+
+func (r *tagIteratorFake) Close() {}
+
 type committedValueIteratorFake struct {
 	current int
 	records []committed.Record
@@ -0,0 +1,19 @@
+package params
+
+import "time"
+
+// Webhook configures an HTTP endpoint called on catalog events
+type Webhook struct {
+	// Name identifies the webhook in logs and rejection reasons
+	Name string
+	// URL receives a POST request with the event
+	URL string
+	// Events the webhook is called on: "pre-commit", "pre-merge"
+	Events []string
+	// Repositories the webhook is called on, all repositories if empty
+	Repositories []string
+	// Branches are patterns of branches the webhook is called on, all branches if empty
+	Branches []string
+	// Timeout for the request, the event is rejected if the endpoint does not respond in time
+	Timeout time.Duration
+}
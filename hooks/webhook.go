@@ -0,0 +1,374 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"syscall"
+	"time"
+
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/hooks/params"
+	"github.com/treeverse/lakefs/logging"
+)
+
+const (
+	EventTypePreCommit = "pre-commit"
+	EventTypePreMerge  = "pre-merge"
+
+	DefaultWebhookTimeout = time.Minute
+
+	// maxRejectionBodySize is the size of the start of a rejecting response body reported
+	maxRejectionBodySize = 4 * 1024
+
+	// maxRedirects is the number of redirects a restricted webhook follows, as http.Client
+	maxRedirects = 10
+
+	// transport settings of restricted webhooks, as http.DefaultTransport
+	dialTimeout         = 30 * time.Second
+	dialKeepAlive       = 30 * time.Second
+	maxIdleConns        = 100
+	idleConnTimeout     = 90 * time.Second
+	tlsHandshakeTimeout = 10 * time.Second
+)
+
+var (
+	ErrWebhookMissingURL   = errors.New("webhook missing url")
+	ErrWebhookUnknownEvent = errors.New("webhook unknown event")
+	ErrWebhookRejected     = errors.New("webhook rejected")
+	ErrWebhookNotAllowed   = errors.New("webhook target not allowed")
+)
+
+// Difference is a single change passed to a webhook
+type Difference struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+// Event is the body of the request POSTed to a webhook
+type Event struct {
+	EventType            string            `json:"event_type"`
+	EventTime            time.Time         `json:"event_time"`
+	HookName             string            `json:"hook_name"`
+	Repository           string            `json:"repository_id"`
+	Branch               string            `json:"branch_id"`
+	SourceRef            string            `json:"source_ref,omitempty"`
+	Committer            string            `json:"committer"`
+	Message              string            `json:"commit_message"`
+	Metadata             map[string]string `json:"metadata,omitempty"`
+	Differences          []Difference      `json:"differences"`
+	DifferencesTruncated bool              `json:"differences_truncated"`
+}
+
+// Webhook POSTs catalog events to an HTTP endpoint.  A response with a 2xx status code allows
+// the operation, any other response rejects it.  The status code and the start of the body
+// of a rejecting response are reported, restricted webhooks report only the status code.
+type Webhook struct {
+	params     params.Webhook
+	client     *http.Client
+	reportBody bool
+}
+
+func NewWebhook(p params.Webhook) (*Webhook, error) {
+	if p.URL == "" {
+		return nil, fmt.Errorf("%w: %s", ErrWebhookMissingURL, p.Name)
+	}
+	for _, event := range p.Events {
+		if event != EventTypePreCommit && event != EventTypePreMerge {
+			return nil, fmt.Errorf("%w: %s: %s", ErrWebhookUnknownEvent, p.Name, event)
+		}
+	}
+	for _, pattern := range p.Branches {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("webhook %s branch pattern %s: %w", p.Name, pattern, err)
+		}
+	}
+	if p.Timeout == 0 {
+		p.Timeout = DefaultWebhookTimeout
+	}
+	return &Webhook{
+		params:     p,
+		client:     &http.Client{Timeout: p.Timeout},
+		reportBody: true,
+	}, nil
+}
+
+// TargetPolicy restricts the endpoints that webhooks defined by users may call.
+type TargetPolicy struct {
+	// AllowedHosts are patterns, as in path.Match, of the hosts webhooks may call.  No host
+	// is allowed if empty.
+	AllowedHosts []string
+	// AllowPrivateAddresses allows connecting to loopback, link-local and private addresses.
+	AllowPrivateAddresses bool
+}
+
+// hostAllowed returns true if u is an HTTP or HTTPS URL on a host allowed by the policy
+func (p TargetPolicy) hostAllowed(u *url.URL) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	host := u.Hostname()
+	for _, pattern := range p.AllowedHosts {
+		if matched, _ := path.Match(pattern, host); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// NewRestrictedWebhook returns a webhook that may only call endpoints allowed by policy, also
+// when redirected.  Unless policy allows private addresses, it refuses to connect to
+// loopback, link-local, private and unspecified addresses whatever its host resolves to.  It
+// never reports the body of a response, which could hold data from wherever it reached.
+func NewRestrictedWebhook(p params.Webhook, policy TargetPolicy) (*Webhook, error) {
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return nil, fmt.Errorf("webhook %s url: %w", p.Name, err)
+	}
+	if !policy.hostAllowed(u) {
+		return nil, fmt.Errorf("%w: %s: %s", ErrWebhookNotAllowed, p.Name, u.Redacted())
+	}
+	w, err := NewWebhook(p)
+	if err != nil {
+		return nil, err
+	}
+	w.reportBody = false
+	if !policy.AllowPrivateAddresses {
+		w.client.Transport = newPublicTransport()
+	}
+	w.client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("webhook %s: stopped after %d redirects", p.Name, maxRedirects)
+		}
+		if !policy.hostAllowed(req.URL) {
+			return fmt.Errorf("%w: %s: redirect to %s", ErrWebhookNotAllowed, p.Name, req.URL.Redacted())
+		}
+		return nil
+	}
+	return w, nil
+}
+
+// privateNetworks are the networks, other than loopback and link-local, that restricted
+// webhooks may not connect to
+var privateNetworks = mustParseCIDRs("0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7")
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+func isPrivateAddress(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// refusePrivateAddress is a net.Dialer Control function that fails connections to private
+// addresses.  It checks the address actually connected to, after name resolution.
+func refusePrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || isPrivateAddress(ip) {
+		return fmt.Errorf("%w: address %s", ErrWebhookNotAllowed, host)
+	}
+	return nil
+}
+
+// newPublicTransport returns a transport that only connects to public addresses.  It uses no
+// proxy, which would connect to any address on its behalf.
+func newPublicTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: dialKeepAlive,
+		Control:   refusePrivateAddress,
+	}
+	return &http.Transport{
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          maxIdleConns,
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// RegisterWebhooks builds webhooks from their configuration and adds them to catalogerHooks
+func RegisterWebhooks(catalogerHooks *catalog.CatalogerHooks, webhooks []params.Webhook) error {
+	for _, p := range webhooks {
+		w, err := NewWebhook(p)
+		if err != nil {
+			return err
+		}
+		if w.HasEvent(EventTypePreCommit) {
+			catalogerHooks.AddPreCommit(w.PreCommit)
+		}
+		if w.HasEvent(EventTypePreMerge) {
+			catalogerHooks.AddPreMerge(w.PreMerge)
+		}
+	}
+	return nil
+}
+
+// HasEvent returns true if the webhook is configured for eventType
+func (w *Webhook) HasEvent(eventType string) bool {
+	for _, event := range w.params.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if the webhook is configured for branch of repository
+func (w *Webhook) matches(repository, branch string) bool {
+	if len(w.params.Repositories) > 0 && !contains(w.params.Repositories, repository) {
+		return false
+	}
+	if len(w.params.Branches) == 0 {
+		return true
+	}
+	for _, pattern := range w.params.Branches {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// PreCommit is a catalog PreCommitFunc
+func (w *Webhook) PreCommit(ctx context.Context, repo, branch string, event catalog.PreCommitEvent) error {
+	if !w.matches(repo, branch) {
+		return nil
+	}
+	return w.post(ctx, &Event{
+		EventType:            EventTypePreCommit,
+		Repository:           repo,
+		Branch:               branch,
+		Committer:            event.Committer,
+		Message:              event.Message,
+		Metadata:             event.Metadata,
+		Differences:          newDifferences(event.Differences),
+		DifferencesTruncated: event.DifferencesTruncated,
+	})
+}
+
+// PreMerge is a catalog PreMergeFunc
+func (w *Webhook) PreMerge(ctx context.Context, repo, branch string, event catalog.PreMergeEvent) error {
+	if !w.matches(repo, branch) {
+		return nil
+	}
+	return w.post(ctx, &Event{
+		EventType:            EventTypePreMerge,
+		Repository:           repo,
+		Branch:               branch,
+		SourceRef:            event.SourceRef,
+		Committer:            event.Committer,
+		Message:              event.Message,
+		Metadata:             event.Metadata,
+		Differences:          newDifferences(event.Differences),
+		DifferencesTruncated: event.DifferencesTruncated,
+	})
+}
+
+func newDifferences(differences catalog.Differences) []Difference {
+	res := make([]Difference, len(differences))
+	for i, d := range differences {
+		res[i] = Difference{
+			Type: differenceTypeString(d.Type),
+			Path: d.Path,
+		}
+	}
+	return res
+}
+
+func differenceTypeString(t catalog.DifferenceType) string {
+	switch t {
+	case catalog.DifferenceTypeAdded:
+		return "added"
+	case catalog.DifferenceTypeRemoved:
+		return "removed"
+	case catalog.DifferenceTypeChanged:
+		return "changed"
+	case catalog.DifferenceTypeConflict:
+		return "conflict"
+	default:
+		return "none"
+	}
+}
+
+func (w *Webhook) post(ctx context.Context, event *Event) error {
+	event.EventTime = time.Now().UTC()
+	event.HookName = w.params.Name
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.params.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	log := logging.FromContext(ctx).WithFields(logging.Fields{
+		"webhook":    w.params.Name,
+		"event_type": event.EventType,
+		"repository": event.Repository,
+		"branch":     event.Branch,
+	})
+	start := time.Now()
+	resp, err := w.client.Do(req)
+	if err != nil {
+		log.WithError(err).Warn("webhook request failed")
+		return fmt.Errorf("webhook %s: %w", w.params.Name, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	log = log.WithFields(logging.Fields{
+		"status_code": resp.StatusCode,
+		"took":        time.Since(start),
+	})
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		log.Debug("webhook allowed event")
+		return nil
+	}
+	log.Info("webhook rejected event")
+	if !w.reportBody {
+		return fmt.Errorf("%w: %s (status %d)", ErrWebhookRejected, w.params.Name, resp.StatusCode)
+	}
+	reason, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRejectionBodySize))
+	if err != nil {
+		log.WithError(err).Warn("could not read webhook rejection")
+	}
+	return fmt.Errorf("%w: %s (status %d): %s", ErrWebhookRejected, w.params.Name, resp.StatusCode, reason)
+}
@@ -0,0 +1,202 @@
+package hooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/hooks"
+	"github.com/treeverse/lakefs/hooks/params"
+)
+
+func TestWebhook_PreCommit(t *testing.T) {
+	var received []hooks.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event hooks.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decode event: %s", err)
+		}
+		received = append(received, event)
+		if event.Message == "reject" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("missing schema file"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	catalogerHooks := &catalog.CatalogerHooks{}
+	err := hooks.RegisterWebhooks(catalogerHooks, []params.Webhook{
+		{Name: "check", URL: server.URL, Events: []string{hooks.EventTypePreCommit}, Branches: []string{"master"}},
+	})
+	if err != nil {
+		t.Fatalf("RegisterWebhooks: %s", err)
+	}
+	if len(catalogerHooks.PreCommit) != 1 || len(catalogerHooks.PreMerge) != 0 {
+		t.Fatalf("registered %d pre-commit and %d pre-merge hooks, expected 1 and 0", len(catalogerHooks.PreCommit), len(catalogerHooks.PreMerge))
+	}
+
+	ctx := context.Background()
+	event := catalog.PreCommitEvent{
+		Committer: "committer",
+		Message:   "allow",
+		Differences: catalog.Differences{
+			{Entry: catalog.Entry{Path: "a"}, Type: catalog.DifferenceTypeAdded},
+			{Entry: catalog.Entry{Path: "b"}, Type: catalog.DifferenceTypeRemoved},
+		},
+	}
+	if err := catalogerHooks.RunPreCommit(ctx, "repo", "master", event); err != nil {
+		t.Fatalf("RunPreCommit allowed commit: %s", err)
+	}
+	if len(received) != 1 {
+		t.Fatalf("webhook received %d events, expected 1", len(received))
+	}
+	expectedDifferences := []hooks.Difference{{Type: "added", Path: "a"}, {Type: "removed", Path: "b"}}
+	if diff := deep.Equal(received[0].Differences, expectedDifferences); diff != nil {
+		t.Errorf("unexpected differences: %s", diff)
+	}
+	if received[0].EventType != hooks.EventTypePreCommit || received[0].Repository != "repo" || received[0].Branch != "master" || received[0].HookName != "check" {
+		t.Errorf("unexpected event %+v", received[0])
+	}
+
+	// branch not matching the webhook is not sent
+	event.Message = "reject"
+	if err := catalogerHooks.RunPreCommit(ctx, "repo", "feature", event); err != nil {
+		t.Fatalf("RunPreCommit on unmatched branch: %s", err)
+	}
+	if len(received) != 1 {
+		t.Fatalf("webhook received %d events, expected 1", len(received))
+	}
+
+	err = catalogerHooks.RunPreCommit(ctx, "repo", "master", event)
+	if !errors.Is(err, catalog.ErrHookRejected) {
+		t.Fatalf("RunPreCommit err=%v, expected %s", err, catalog.ErrHookRejected)
+	}
+	if !strings.Contains(err.Error(), "missing schema file") {
+		t.Errorf("RunPreCommit err=%v, expected the response body", err)
+	}
+}
+
+func TestWebhook_RejectionBody(t *testing.T) {
+	const reason = "missing schema file"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(reason + strings.Repeat(".", 1024*1024)))
+	}))
+	defer server.Close()
+
+	w, err := hooks.NewWebhook(params.Webhook{Name: "check", URL: server.URL})
+	if err != nil {
+		t.Fatalf("NewWebhook: %s", err)
+	}
+	err = w.PreCommit(context.Background(), "repo", "master", catalog.PreCommitEvent{})
+	if !errors.Is(err, hooks.ErrWebhookRejected) {
+		t.Fatalf("PreCommit err=%v, expected %s", err, hooks.ErrWebhookRejected)
+	}
+	if !strings.Contains(err.Error(), reason) {
+		t.Errorf("PreCommit err=%v, expected the response body", err)
+	}
+	if len(err.Error()) > 64*1024 {
+		t.Errorf("PreCommit err has %d bytes, expected the response body to be truncated", len(err.Error()))
+	}
+
+	// restricted webhooks never report the response body
+	restricted, err := hooks.NewRestrictedWebhook(params.Webhook{Name: "check", URL: server.URL},
+		hooks.TargetPolicy{AllowedHosts: []string{"127.0.0.1"}, AllowPrivateAddresses: true})
+	if err != nil {
+		t.Fatalf("NewRestrictedWebhook: %s", err)
+	}
+	err = restricted.PreCommit(context.Background(), "repo", "master", catalog.PreCommitEvent{})
+	if !errors.Is(err, hooks.ErrWebhookRejected) {
+		t.Fatalf("restricted PreCommit err=%v, expected %s", err, hooks.ErrWebhookRejected)
+	}
+	if strings.Contains(err.Error(), reason) {
+		t.Errorf("restricted PreCommit err=%v, reports the response body", err)
+	}
+}
+
+func TestWebhook_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	w, err := hooks.NewWebhook(params.Webhook{Name: "gone", URL: url, Events: []string{hooks.EventTypePreMerge}})
+	if err != nil {
+		t.Fatalf("NewWebhook: %s", err)
+	}
+	err = w.PreMerge(context.Background(), "repo", "master", catalog.PreMergeEvent{SourceRef: "feature"})
+	if err == nil {
+		t.Fatal("PreMerge to unreachable webhook succeeded, expected rejection")
+	}
+}
+
+func TestNewWebhook_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  params.Webhook
+		wantErr error
+	}{
+		{name: "missing_url", params: params.Webhook{Name: "w", Events: []string{hooks.EventTypePreCommit}}, wantErr: hooks.ErrWebhookMissingURL},
+		{name: "unknown_event", params: params.Webhook{Name: "w", URL: "http://localhost", Events: []string{"post-commit"}}, wantErr: hooks.ErrWebhookUnknownEvent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := hooks.NewWebhook(tt.params)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewWebhook err=%v, expected %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewRestrictedWebhook(t *testing.T) {
+	policy := hooks.TargetPolicy{AllowedHosts: []string{"hooks.example.com", "*.hooks.example.net"}}
+	tests := []struct {
+		name    string
+		url     string
+		wantErr error
+	}{
+		{name: "allowed_host", url: "https://hooks.example.com/check"},
+		{name: "allowed_pattern", url: "http://ci.hooks.example.net:8080/check"},
+		{name: "other_host", url: "http://169.254.169.254/latest/meta-data", wantErr: hooks.ErrWebhookNotAllowed},
+		{name: "other_scheme", url: "ftp://hooks.example.com/check", wantErr: hooks.ErrWebhookNotAllowed},
+		{name: "host_suffix", url: "http://hooks.example.com.attacker.io/check", wantErr: hooks.ErrWebhookNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := hooks.NewRestrictedWebhook(params.Webhook{Name: "w", URL: tt.url}, policy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewRestrictedWebhook err=%v, expected %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRestrictedWebhook_PrivateAddress(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	// localhost is allowed by name, but resolves to a loopback address
+	url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	w, err := hooks.NewRestrictedWebhook(params.Webhook{Name: "w", URL: url}, hooks.TargetPolicy{AllowedHosts: []string{"localhost"}})
+	if err != nil {
+		t.Fatalf("NewRestrictedWebhook: %s", err)
+	}
+	err = w.PreCommit(context.Background(), "repo", "master", catalog.PreCommitEvent{})
+	if !errors.Is(err, hooks.ErrWebhookNotAllowed) {
+		t.Errorf("PreCommit err=%v, expected %s", err, hooks.ErrWebhookNotAllowed)
+	}
+	if called {
+		t.Error("webhook on a loopback address was called")
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/ory/dockertest/v3"
+	"github.com/treeverse/lakefs/actions"
 	"github.com/treeverse/lakefs/api"
+	"github.com/treeverse/lakefs/audit"
 	"github.com/treeverse/lakefs/auth"
 	"github.com/treeverse/lakefs/auth/crypt"
 	authmodel "github.com/treeverse/lakefs/auth/model"
@@ -19,6 +21,7 @@ import (
 	"github.com/treeverse/lakefs/db"
 	dbparams "github.com/treeverse/lakefs/db/params"
 	"github.com/treeverse/lakefs/dedup"
+	"github.com/treeverse/lakefs/hooks"
 	"github.com/treeverse/lakefs/logging"
 	"github.com/treeverse/lakefs/retention"
 	"github.com/treeverse/lakefs/stats"
@@ -81,6 +84,9 @@ func TestLocalLoad(t *testing.T) {
 		migrator,
 		nil,
 		dedupCleaner,
+		actions.NewService(conn, cataloger, blockAdapter, hooks.TargetPolicy{}),
+		audit.NewDBService(conn),
+		api.S3GatewayConfig{},
 		logging.Default(),
 	)
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/cmdutils"
 	"github.com/treeverse/lakefs/db"
@@ -22,6 +23,8 @@ type RepoActions interface {
 	cmdutils.ProgressReporter
 	ApplyImport(ctx context.Context, it Iterator, dryRun bool) (*Stats, error)
 	GetPreviousCommit(ctx context.Context) (commit *catalog.CommitLog, err error)
+	// ListCommitObjects returns the objects of a previous import commit, sorted by key.
+	ListCommitObjects(ctx context.Context, commitRef string) (block.InventoryIterator, error)
 	Commit(ctx context.Context, commitMsg string, metadata catalog.Metadata) (commitRef string, err error)
 }
 
@@ -167,6 +170,10 @@ func (c *MVCCCatalogRepoActions) GetPreviousCommit(ctx context.Context) (commit
 	return commit, nil
 }
 
+func (c *MVCCCatalogRepoActions) ListCommitObjects(ctx context.Context, commitRef string) (block.InventoryIterator, error) {
+	return newCommitObjectsIterator(ctx, c.cataloger, c.repository, commitRef), nil
+}
+
 func (c *MVCCCatalogRepoActions) Commit(ctx context.Context, commitMsg string, metadata catalog.Metadata) (string, error) {
 	c.commitProgress.Activate()
 	res, err := c.cataloger.Commit(ctx, c.repository, catalog.DefaultImportBranchName,
@@ -0,0 +1,81 @@
+package onboard
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/cmdutils"
+)
+
+const commitObjectsBatchSize = 1000
+
+// commitObjectsIterator iterates over the entries of an import commit as inventory objects,
+// sorted by path.
+type commitObjectsIterator struct {
+	ctx        context.Context
+	cataloger  catalog.Cataloger
+	repository string
+	reference  string
+	buffer     []*catalog.Entry
+	hasMore    bool
+	after      string
+	val        *block.InventoryObject
+	err        error
+	progress   *cmdutils.Progress
+}
+
+func newCommitObjectsIterator(ctx context.Context, cataloger catalog.Cataloger, repository, reference string) *commitObjectsIterator {
+	return &commitObjectsIterator{
+		ctx:        ctx,
+		cataloger:  cataloger,
+		repository: repository,
+		reference:  reference,
+		hasMore:    true,
+		progress:   cmdutils.NewActiveProgress("Previous Import Objects Read", cmdutils.Spinner),
+	}
+}
+
+func (it *commitObjectsIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if len(it.buffer) == 0 {
+		if !it.hasMore {
+			it.val = nil
+			it.progress.SetCompleted(true)
+			return false
+		}
+		it.buffer, it.hasMore, it.err = it.cataloger.ListEntries(it.ctx, it.repository, it.reference, "", it.after, "", commitObjectsBatchSize)
+		if it.err != nil || len(it.buffer) == 0 {
+			it.val = nil
+			it.progress.SetCompleted(true)
+			return false
+		}
+		it.after = it.buffer[len(it.buffer)-1].Path
+	}
+	entry := it.buffer[0]
+	it.buffer = it.buffer[1:]
+	creationDate := entry.CreationDate
+	it.val = &block.InventoryObject{
+		Key:             entry.Path,
+		Size:            entry.Size,
+		LastModified:    &creationDate,
+		Checksum:        entry.Checksum,
+		PhysicalAddress: entry.PhysicalAddress,
+	}
+	it.progress.Incr()
+	return true
+}
+
+func (it *commitObjectsIterator) Err() error {
+	return it.err
+}
+
+func (it *commitObjectsIterator) Get() *block.InventoryObject {
+	return it.val
+}
+
+func (it *commitObjectsIterator) Progress() []*cmdutils.Progress {
+	return []*cmdutils.Progress{it.progress}
+}
@@ -81,6 +81,11 @@ func CreateImporter(ctx context.Context, logger logging.Logger, config *Config)
 }
 
 func (s *Importer) diffIterator(ctx context.Context, commit catalog.CommitLog) (Iterator, error) {
+	if block.IsLiveInventory(s.inventory) || IsLiveImport(commit.Metadata) {
+		// listing the storage again returns its current state, compare with the objects
+		// of the previous import instead
+		return s.commitDiffIterator(ctx, commit)
+	}
 	previousInventoryURL := ExtractInventoryURL(commit.Metadata)
 	if previousInventoryURL == "" {
 		return nil, fmt.Errorf("%w. commit_ref=%s", ErrNoInventoryURL, commit.Reference)
@@ -98,6 +103,17 @@ func (s *Importer) diffIterator(ctx context.Context, commit catalog.CommitLog) (
 	return NewDiffIterator(previousObjs, currentObjs), nil
 }
 
+// commitDiffIterator returns the differences between the objects of commit, a previous
+// import, and the current inventory.
+func (s *Importer) commitDiffIterator(ctx context.Context, commit catalog.CommitLog) (Iterator, error) {
+	previousObjs, err := s.CatalogActions.ListCommitObjects(ctx, commit.Reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects of previous import: %w", err)
+	}
+	currentObjs := s.inventory.Iterator()
+	return NewDiffIterator(previousObjs, currentObjs), nil
+}
+
 func (s *Importer) Import(ctx context.Context, dryRun bool) (*Stats, error) {
 	var dataToImport Iterator
 	var err error
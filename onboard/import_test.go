@@ -22,6 +22,9 @@ func TestImport(t *testing.T) {
 		OverridePreviousInventoryURL string
 		Prefixes                     []string
 		PreviousPrefixes             []string
+		Live                         bool
+		PreviousLive                 bool
+		PreviousCommitObjects        []string
 	}{
 		"new inventory": {
 			NewInventory:  []string{"f1", "f2"},
@@ -135,6 +138,29 @@ func TestImport(t *testing.T) {
 			PreviousPrefixes:  []string{"a1", "a2", "b"},
 			ExpectedErr:       onboard.ErrIncompatiblePrefixes,
 		},
+		"live inventory - import again": {
+			NewInventory:          []string{"f4", "f2", "f1"},
+			Live:                  true,
+			PreviousCommitObjects: []string{"f1", "f2", "f3"},
+			ExpectedAdded:         []string{"f4"},
+			ExpectedDeleted:       []string{"f3"},
+		},
+		"live inventory - with prefix": {
+			NewInventory:          []string{"a1", "a2", "b1"},
+			Live:                  true,
+			Prefixes:              []string{"a"},
+			PreviousPrefixes:      []string{"a"},
+			PreviousCommitObjects: []string{"a1", "a3"},
+			ExpectedAdded:         []string{"a2"},
+			ExpectedDeleted:       []string{"a3"},
+		},
+		"previous live inventory": {
+			NewInventory:          []string{"f3", "f1"},
+			PreviousLive:          true,
+			PreviousCommitObjects: []string{"f1", "f2"},
+			ExpectedAdded:         []string{"f3"},
+			ExpectedDeleted:       []string{"f2"},
+		},
 	}
 	for _, dryRun := range []bool{true, false} {
 		for name, test := range testdata {
@@ -154,12 +180,25 @@ func TestImport(t *testing.T) {
 						previousCommitPrefixes:  test.PreviousPrefixes,
 					}
 				}
+				if len(test.PreviousCommitObjects) > 0 {
+					catalogActionsMock = mockCatalogActions{
+						previousCommitInventory: previousInventoryURL,
+						previousCommitPrefixes:  test.PreviousPrefixes,
+						previousCommitObjects:   test.PreviousCommitObjects,
+						previousCommitLive:      test.PreviousLive,
+					}
+					if test.Live {
+						// a live inventory is listed again from the same URL
+						catalogActionsMock.previousCommitInventory = newInventoryURL
+					}
+				}
 				inventoryGenerator := &mockInventoryGenerator{
 					newInventoryURL:      newInventoryURL,
 					previousInventoryURL: previousInventoryURL,
 					newInventory:         test.NewInventory,
 					previousInventory:    test.PreviousInventory,
 					sourceBucket:         "example-repo",
+					live:                 test.Live,
 				}
 				config := &onboard.Config{
 					CommitUsername:     "committer",
@@ -214,6 +253,10 @@ func TestImport(t *testing.T) {
 					t.Fatalf("unexpected inventory_url in commit metadata. expected=%s, got=%s", newInventoryURL, catalogActionsMock.lastCommitMetadata["inventory_url"])
 				}
 
+				if onboard.IsLiveImport(catalogActionsMock.lastCommitMetadata) != test.Live {
+					t.Fatalf("unexpected live_inventory in commit metadata. expected=%t, got=%s", test.Live, catalogActionsMock.lastCommitMetadata["live_inventory"])
+				}
+
 				addedOrChangedCount, err := strconv.Atoi(catalogActionsMock.lastCommitMetadata["added_or_changed_objects"])
 				if err != nil || addedOrChangedCount != len(expectedAddedToCatalog) {
 					t.Fatalf("unexpected added_or_changed_objects in commit metadata. expected=%d, got=%d", len(expectedDeletedFromCatalog), addedOrChangedCount)
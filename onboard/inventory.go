@@ -128,9 +128,19 @@ func CreateCommitMetadata(inv block.Inventory, stats Stats, prefixes []string) c
 		prefixesSerialized, _ := json.Marshal(prefixes)
 		metadata["key_prefixes"] = string(prefixesSerialized)
 	}
+	if block.IsLiveInventory(inv) {
+		metadata["live_inventory"] = strconv.FormatBool(true)
+	}
 	return metadata
 }
 
+// IsLiveImport returns true if metadata is of a commit that imported a live inventory, which
+// cannot be generated again.
+func IsLiveImport(metadata catalog.Metadata) bool {
+	live, _ := strconv.ParseBool(metadata["live_inventory"])
+	return live
+}
+
 func ExtractPrefixes(metadata catalog.Metadata) []string {
 	var prefixes []string
 	_ = json.Unmarshal([]byte(metadata["key_prefixes"]), &prefixes)
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/catalog/rocks"
 	"github.com/treeverse/lakefs/cmdutils"
@@ -75,6 +76,12 @@ func (c *RocksCatalogRepoActions) GetPreviousCommit(ctx context.Context) (commit
 	return nil, nil
 }
 
+var ErrListCommitObjects = errors.New("rocksCatalogRepoActions builds every import from scratch and cannot list previous imports")
+
+func (c *RocksCatalogRepoActions) ListCommitObjects(_ context.Context, _ string) (block.InventoryIterator, error) {
+	return nil, ErrListCommitObjects
+}
+
 var ErrNoMetaRange = errors.New("nothing to commit - meta-range wasn't created")
 
 func (c *RocksCatalogRepoActions) Commit(ctx context.Context, commitMsg string, metadata catalog.Metadata) (string, error) {
@@ -29,6 +29,7 @@ type mockInventory struct {
 	lastModified []time.Time
 	checksum     func(string) string
 	prefixes     []string
+	live         bool
 }
 
 type objectActions struct {
@@ -39,6 +40,8 @@ type objectActions struct {
 type mockCatalogActions struct {
 	previousCommitInventory string
 	previousCommitPrefixes  []string
+	previousCommitObjects   []string
+	previousCommitLive      bool
 	objectActions           objectActions
 	lastCommitMetadata      catalog.Metadata
 }
@@ -49,14 +52,15 @@ type mockInventoryGenerator struct {
 	newInventory         []string
 	previousInventory    []string
 	sourceBucket         string
+	live                 bool
 }
 
 func (m mockInventoryGenerator) GenerateInventory(_ context.Context, _ logging.Logger, inventoryURL string, shouldSort bool, prefixes []string) (block.Inventory, error) {
 	if inventoryURL == m.newInventoryURL {
-		return &mockInventory{keys: m.newInventory, inventoryURL: inventoryURL, sourceBucket: m.sourceBucket, shouldSort: shouldSort, prefixes: prefixes}, nil
+		return &mockInventory{keys: m.newInventory, inventoryURL: inventoryURL, sourceBucket: m.sourceBucket, shouldSort: shouldSort, prefixes: prefixes, live: m.live}, nil
 	}
 	if inventoryURL == m.previousInventoryURL {
-		return &mockInventory{keys: m.previousInventory, inventoryURL: inventoryURL, sourceBucket: m.sourceBucket, shouldSort: shouldSort, prefixes: prefixes}, nil
+		return &mockInventory{keys: m.previousInventory, inventoryURL: inventoryURL, sourceBucket: m.sourceBucket, shouldSort: shouldSort, prefixes: prefixes, live: m.live}, nil
 	}
 	return nil, errors.New("failed to create inventory")
 }
@@ -119,9 +123,17 @@ func (m *mockCatalogActions) GetPreviousCommit(_ context.Context) (commit *catal
 		prefixesSerialized, _ := json.Marshal(m.previousCommitPrefixes)
 		metadata["key_prefixes"] = string(prefixesSerialized)
 	}
+	if m.previousCommitLive {
+		metadata["live_inventory"] = "true"
+	}
 	return &catalog.CommitLog{Metadata: metadata}, nil
 }
 
+func (m *mockCatalogActions) ListCommitObjects(_ context.Context, _ string) (block.InventoryIterator, error) {
+	inv := &mockInventory{keys: m.previousCommitObjects, shouldSort: true}
+	return inv.Iterator(), nil
+}
+
 func (m *mockCatalogActions) Commit(_ context.Context, _ string, metadata catalog.Metadata) (string, error) {
 	m.lastCommitMetadata = metadata
 	return "", nil
@@ -176,3 +188,7 @@ func (m *mockInventory) SourceName() string {
 func (m *mockInventory) InventoryURL() string {
 	return m.inventoryURL
 }
+
+func (m *mockInventory) IsLive() bool {
+	return m.live
+}
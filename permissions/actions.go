@@ -27,11 +27,20 @@ const (
 	ReadBranchAction       = "fs:ReadBranch"
 	RevertBranchAction     = "fs:RevertBranch"
 	ListBranchesAction     = "fs:ListBranches"
+	CreateTagAction        = "fs:CreateTag"
+	DeleteTagAction        = "fs:DeleteTag"
+	ReadTagAction          = "fs:ReadTag"
+	ListTagsAction         = "fs:ListTags"
 	ExportConfigAction     = "fs:ExportConfig"
 
 	RetentionReadPolicyAction  = "retention:GetPolicy"
 	RetentionWritePolicyAction = "retention:WritePolicy"
 
+	BranchProtectionReadRulesAction  = "branches:GetBranchProtectionRules"
+	BranchProtectionWriteRulesAction = "branches:SetBranchProtectionRules"
+
+	ReadActionsAction = "actions:ReadActions"
+
 	ReadUserAction          = "auth:ReadUser"
 	CreateUserAction        = "auth:CreateUser"
 	DeleteUserAction        = "auth:DeleteUser"
@@ -54,12 +63,15 @@ const (
 	DeleteCredentialsAction = "auth:DeleteCredentials"
 	ListCredentialsAction   = "auth:ListCredentials"
 	ReadConfigAction        = "auth:ReadConfig"
+	ReadAuditLogAction      = "auth:ReadAuditLog"
 )
 
 var serviceSet = map[string]struct{}{
 	"fs":        {},
 	"auth":      {},
 	"retention": {},
+	"branches":  {},
+	"actions":   {},
 }
 
 func IsValidAction(name string) error {
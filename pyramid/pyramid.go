@@ -5,6 +5,8 @@ package pyramid
 import (
 	"io"
 	"os"
+
+	"github.com/treeverse/lakefs/block"
 )
 
 // FS is pyramid abstraction of filesystem where the persistent storage-layer is the block storage.
@@ -18,6 +20,13 @@ type FS interface {
 	// Open finds the referenced file and returns its read-only File.
 	// If file isn't in the local disk, it is fetched from the block storage.
 	Open(namespace, filename string) (File, error)
+
+	// Walk calls walkFn for every file stored under namespace in the block storage.
+	// Identifiers of the walked entries are file names.
+	Walk(namespace string, walkFn block.WalkFunc) error
+
+	// Remove deletes the file from the block storage and from the local disk.
+	Remove(namespace, filename string) error
 }
 
 // File is pyramid abstraction for an os.File
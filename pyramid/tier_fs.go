@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -113,7 +114,10 @@ func (tfs *TierFS) removeFromLocal(rPath params.RelativePath, filesize int64) {
 
 func (tfs *TierFS) removeFromLocalInternal(rPath params.RelativePath) {
 	p := path.Join(tfs.fsLocalBaseDir, string(rPath))
-	if err := os.Remove(p); err != nil {
+	if err := os.Remove(p); os.IsNotExist(err) {
+		// already removed by Remove
+		return
+	} else if err != nil {
 		tfs.logger.WithError(err).WithField("path", p).Error("Removing file failed")
 		errorsTotal.WithLabelValues(tfs.fsName, "FileRemoval")
 		return
@@ -158,10 +162,6 @@ func (tfs *TierFS) store(namespace, originalPath, filename string) error {
 // File isn't stored in TierFS until a successful close operation.
 // Open(namespace, filename) calls will return an error before the close was called.
 func (tfs *TierFS) Create(namespace string) (StoredFile, error) {
-	if err := validateNamespace(namespace); err != nil {
-		return nil, fmt.Errorf("invalid args: %w", err)
-	}
-
 	if err := tfs.createNSWorkspaceDir(namespace); err != nil {
 		return nil, fmt.Errorf("create namespace dir: %w", err)
 	}
@@ -186,7 +186,7 @@ func (tfs *TierFS) Create(namespace string) (StoredFile, error) {
 // Open returns the a file descriptor to the local file.
 // If the file is missing from the local disk, it will try to fetch it from the block storage.
 func (tfs *TierFS) Open(namespace, filename string) (File, error) {
-	if err := validateArgs(namespace, filename); err != nil {
+	if err := validateFilename(filename); err != nil {
 		return nil, err
 	}
 
@@ -209,6 +209,40 @@ func (tfs *TierFS) Open(namespace, filename string) (File, error) {
 	return tfs.openFile(fileRef, fh)
 }
 
+// Walk calls walkFn for every file stored under namespace in the block storage.
+func (tfs *TierFS) Walk(namespace string, walkFn block.WalkFunc) error {
+	prefix := tfs.remotePrefix + "/"
+	return tfs.adapter.Walk(block.ObjectPointer{
+		StorageNamespace: namespace,
+		Identifier:       prefix,
+	}, func(entry block.WalkEntry) error {
+		entry.Identifier = strings.TrimPrefix(entry.Identifier, prefix)
+		return walkFn(entry)
+	})
+}
+
+// Remove deletes the file from the block storage and from the local disk.
+// The file must not be used after it is removed.
+func (tfs *TierFS) Remove(namespace, filename string) error {
+	if err := validateFilename(filename); err != nil {
+		return err
+	}
+	if err := tfs.adapter.Remove(tfs.objPointer(namespace, filename)); err != nil {
+		return fmt.Errorf("remove from block storage: %w", err)
+	}
+
+	// eviction keeps tracking the file until it evicts it, after the file is already gone
+	fileRef := tfs.newLocalFileRef(namespace, filename)
+	if err := os.Remove(fileRef.fullPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove local file: %w", err)
+	}
+	if err := tfs.syncDir.deleteDirRecIfEmpty(path.Dir(fileRef.fullPath)); err != nil {
+		tfs.logger.WithError(err).Error("Failed deleting empty dir")
+		errorsTotal.WithLabelValues(tfs.fsName, "DirRemoval")
+	}
+	return nil
+}
+
 // openFile converts an os.File to pyramid.ROFile and updates the eviction control.
 func (tfs *TierFS) openFile(fileRef localFileRef, fh *os.File) (*ROFile, error) {
 	stat, err := fh.Stat()
@@ -280,15 +314,7 @@ func storeLocalFile(rPath string, size int64, eviction params.Eviction) error {
 	return nil
 }
 
-func validateArgs(namespace, filename string) error {
-	if err := validateNamespace(namespace); err != nil {
-		return err
-	}
-	return validateFilename(filename)
-}
-
 var (
-	errSeparatorInFS   = errors.New("path contains separator")
 	errPathInWorkspace = errors.New("file cannot be located in the workspace")
 	errEmptyDirInPath  = errors.New("file path cannot contain an empty directory")
 )
@@ -303,11 +329,11 @@ func validateFilename(filename string) error {
 	return nil
 }
 
-func validateNamespace(ns string) error {
-	if strings.ContainsRune(ns, os.PathSeparator) {
-		return errSeparatorInFS
-	}
-	return nil
+// localNamespaceDir returns the name of the local directory holding the files of namespace.
+// Namespaces are storage namespaces such as "s3://bucket/path", so they are escaped into a
+// single path element.
+func localNamespaceDir(namespace string) string {
+	return url.QueryEscape(namespace)
 }
 
 // localFileRef consists of all possible local file references
@@ -320,7 +346,7 @@ type localFileRef struct {
 }
 
 func (tfs *TierFS) newLocalFileRef(namespace, filename string) localFileRef {
-	relative := path.Join(namespace, filename)
+	relative := path.Join(localNamespaceDir(namespace), filename)
 	return localFileRef{
 		namespace: namespace,
 		filename:  filename,
@@ -350,7 +376,7 @@ func (tfs *TierFS) createNSWorkspaceDir(namespace string) error {
 }
 
 func (tfs *TierFS) workspaceDirPath(namespace string) string {
-	return path.Join(tfs.fsLocalBaseDir, namespace, workspaceDir)
+	return path.Join(tfs.fsLocalBaseDir, localNamespaceDir(namespace), workspaceDir)
 }
 
 func (tfs *TierFS) workspaceTempFilePath(namespace string) string {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/block/mem"
 	"github.com/treeverse/lakefs/pyramid/params"
 )
@@ -50,6 +51,27 @@ func TestReadFailDuringWrite(t *testing.T) {
 	checkContent(t, namespace, filename, content)
 }
 
+func TestWalkRemove(t *testing.T) {
+	namespace := uuid.New().String()
+	filenames := []string{"file1", "file2", "file3"}
+	for _, filename := range filenames {
+		writeToFile(t, namespace, filename, []byte("content of "+filename))
+	}
+	checkContent(t, namespace, "file2", []byte("content of file2"))
+
+	require.NoError(t, fs.Remove(namespace, "file2"))
+
+	var walked []string
+	require.NoError(t, fs.Walk(namespace, func(entry block.WalkEntry) error {
+		walked = append(walked, entry.Identifier)
+		return nil
+	}))
+	require.Equal(t, []string{"file1", "file3"}, walked)
+
+	_, err := fs.Open(namespace, "file2")
+	require.Error(t, err, "open removed file")
+}
+
 func TestEvictionSingleNamespace(t *testing.T) {
 	testEviction(t, uuid.New().String())
 }
@@ -139,8 +161,17 @@ func testEviction(t *testing.T, namespaces ...string) {
 	}
 }
 
+func TestStorageNamespace(t *testing.T) {
+	namespace := "s3://bucket/" + uuid.New().String()
+	filename := "file1"
+
+	content := []byte("hello world!")
+	writeToFile(t, namespace, filename, content)
+	checkContent(t, namespace, filename, content)
+}
+
 func TestInvalidArgs(t *testing.T) {
-	f, err := fs.Create("not/a/valid/namespace")
+	f, err := fs.Open(uuid.New().String(), "empty//dir")
 	require.Nil(t, f)
 	require.Error(t, err)
 }
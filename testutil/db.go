@@ -5,11 +5,13 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
 
 	"cloud.google.com/go/storage"
+	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -19,6 +21,7 @@ import (
 	_ "github.com/jackc/pgx/v4/stdlib"
 	"github.com/ory/dockertest/v3"
 	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/block/azure"
 	"github.com/treeverse/lakefs/block/gs"
 	"github.com/treeverse/lakefs/block/mem"
 	lakefsS3 "github.com/treeverse/lakefs/block/s3"
@@ -35,6 +38,10 @@ const (
 	envKeyAwsKeyID        = "AWS_ACCESS_KEY_ID"
 	envKeyAwsSecretKey    = "AWS_SECRET_ACCESS_KEY" //nolint:gosec
 	envKeyAwsRegion       = "AWS_DEFAULT_REGION"
+
+	envKeyAzureStorageAccount   = "AZURE_STORAGE_ACCOUNT"
+	envKeyAzureStorageAccessKey = "AZURE_STORAGE_ACCESS_KEY" //nolint:gosec
+	envKeyAzureEndpoint         = "AZURE_STORAGE_ENDPOINT"
 )
 
 var keepDB = flag.Bool("keep-db", false, "keep test DB instance running")
@@ -206,6 +213,23 @@ func NewBlockAdapterByType(t *testing.T, translator block.UploadIDTranslator, bl
 		svc := s3.New(sess)
 		return lakefsS3.NewAdapter(svc, lakefsS3.WithTranslator(translator))
 
+	case azure.BlockstoreType:
+		account := os.Getenv(envKeyAzureStorageAccount)
+		credential, err := azblob.NewSharedKeyCredential(account, os.Getenv(envKeyAzureStorageAccessKey))
+		if err != nil {
+			t.Fatal("Azure shared key credential", err)
+		}
+		endpoint, ok := os.LookupEnv(envKeyAzureEndpoint)
+		if !ok {
+			endpoint = fmt.Sprintf("https://%s.blob.core.windows.net/", account)
+		}
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil {
+			t.Fatal("Azure endpoint", err)
+		}
+		serviceURL := azblob.NewServiceURL(*endpointURL, azblob.NewPipeline(credential, azblob.PipelineOptions{}))
+		return azure.NewAdapter(serviceURL, azure.WithTranslator(translator), azure.WithStorageAccount(account))
+
 	default:
 		return mem.New(mem.WithTranslator(translator))
 	}
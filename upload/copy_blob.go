@@ -0,0 +1,47 @@
+package upload
+
+import (
+	"strings"
+
+	"github.com/treeverse/lakefs/block"
+)
+
+// CopyBlob returns the physical address, in destinationNamespace, of a copy of the blob at
+// sourceAddress in sourceNamespace.  Blobs in the same storage namespace, or already at a full
+// address, are not copied but linked.  So are blobs in the same bucket as
+// destinationNamespace, through their full address.  All other blobs are copied to a new
+// address by adapter.
+func CopyBlob(adapter block.Adapter, sourceNamespace, sourceAddress, destinationNamespace string) (string, error) {
+	if sourceNamespace == destinationNamespace || !block.IsResolvableKey(sourceAddress) {
+		return sourceAddress, nil
+	}
+	if sameBucket(sourceNamespace, destinationNamespace) {
+		return strings.TrimSuffix(sourceNamespace, "/") + "/" + sourceAddress, nil
+	}
+	address := NewPhysicalAddress()
+	err := adapter.Copy(block.ObjectPointer{
+		StorageNamespace: sourceNamespace,
+		Identifier:       sourceAddress,
+	}, block.ObjectPointer{
+		StorageNamespace: destinationNamespace,
+		Identifier:       address,
+	})
+	if err != nil {
+		return "", err
+	}
+	return address, nil
+}
+
+// sameBucket returns true if both storage namespaces are in the same bucket, or container, of
+// the same storage type.
+func sameBucket(namespace1, namespace2 string) bool {
+	qk1, err := block.ResolveNamespace(namespace1, "")
+	if err != nil {
+		return false
+	}
+	qk2, err := block.ResolveNamespace(namespace2, "")
+	if err != nil {
+		return false
+	}
+	return qk1.StorageType == qk2.StorageType && qk1.StorageNamespace == qk2.StorageNamespace
+}
@@ -0,0 +1,66 @@
+package upload_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/block/mem"
+	"github.com/treeverse/lakefs/upload"
+)
+
+func TestCopyBlob(t *testing.T) {
+	cases := []struct {
+		name                 string
+		sourceNamespace      string
+		sourceAddress        string
+		destinationNamespace string
+		expectedAddress      string
+	}{
+		{"same namespace", "s3://bucket/repo1", "abc", "s3://bucket/repo1", "abc"},
+		{"full address", "s3://bucket/repo1", "s3://other/data/abc", "s3://bucket2/repo2", "s3://other/data/abc"},
+		{"same bucket", "s3://bucket/repo1", "abc", "s3://bucket/repo2", "s3://bucket/repo1/abc"},
+		{"same bucket trailing slash", "s3://bucket/repo1/", "abc", "s3://bucket/repo2", "s3://bucket/repo1/abc"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			adapter := mem.New()
+			address, err := upload.CopyBlob(adapter, c.sourceNamespace, c.sourceAddress, c.destinationNamespace)
+			if err != nil {
+				t.Fatalf("CopyBlob: %s", err)
+			}
+			if address != c.expectedAddress {
+				t.Errorf("expected address %s, got %s", c.expectedAddress, address)
+			}
+		})
+	}
+}
+
+func TestCopyBlobAcrossBuckets(t *testing.T) {
+	const data = "data to copy"
+	adapter := mem.New()
+	source := block.ObjectPointer{StorageNamespace: "mem://bucket1/repo1", Identifier: "abc"}
+	err := adapter.Put(source, int64(len(data)), strings.NewReader(data), block.PutOpts{})
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	address, err := upload.CopyBlob(adapter, source.StorageNamespace, source.Identifier, "mem://bucket2/repo2")
+	if err != nil {
+		t.Fatalf("CopyBlob: %s", err)
+	}
+	if address == source.Identifier || !block.IsResolvableKey(address) {
+		t.Fatalf("expected a new address relative to the destination, got %s", address)
+	}
+	reader, err := adapter.Get(block.ObjectPointer{StorageNamespace: "mem://bucket2/repo2", Identifier: address}, int64(len(data)))
+	if err != nil {
+		t.Fatalf("Get copy: %s", err)
+	}
+	copied, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read copy: %s", err)
+	}
+	if string(copied) != data {
+		t.Errorf("expected copy %q, got %q", data, copied)
+	}
+}
@@ -0,0 +1,72 @@
+package upload
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultStagingTokenExpiry is how long a physical address may be linked after it is issued.
+const DefaultStagingTokenExpiry = 24 * time.Hour
+
+// physicalAddressLength is the length of the addresses returned by NewPhysicalAddress.
+const physicalAddressLength = 32
+
+var (
+	ErrInvalidStagingToken = errors.New("invalid staging token")
+	ErrStagingTokenExpired = errors.New("staging token expired")
+)
+
+// IsValidPhysicalAddress returns true if address has the form of the addresses returned by
+// NewPhysicalAddress.  Such addresses are relative to the storage namespace and cannot
+// escape it.
+func IsValidPhysicalAddress(address string) bool {
+	if len(address) != physicalAddressLength {
+		return false
+	}
+	_, err := hex.DecodeString(address)
+	return err == nil
+}
+
+// StagingToken returns a token allowing to link address to path on branch of repository until
+// expires.  The token is signed with secret, so only addresses issued by a server holding
+// secret can be linked, and only to the path for which they were issued.
+func StagingToken(secret []byte, repository, branch, path, address string, expires time.Time) string {
+	expiresAt := strconv.FormatInt(expires.Unix(), 10)
+	return expiresAt + "." + stagingTokenSignature(secret, expiresAt, repository, branch, path, address)
+}
+
+// VerifyStagingToken returns an error unless token was returned by StagingToken with the same
+// arguments and did not expire by now.
+func VerifyStagingToken(secret []byte, repository, branch, path, address, token string, now time.Time) error {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return ErrInvalidStagingToken
+	}
+	expected := stagingTokenSignature(secret, parts[0], repository, branch, path, address)
+	if !hmac.Equal([]byte(parts[1]), []byte(expected)) {
+		return ErrInvalidStagingToken
+	}
+	expiresAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return ErrInvalidStagingToken
+	}
+	if now.After(time.Unix(expiresAt, 0)) {
+		return ErrStagingTokenExpired
+	}
+	return nil
+}
+
+func stagingTokenSignature(secret []byte, fields ...string) string {
+	mac := hmac.New(sha256.New, secret)
+	for _, field := range fields {
+		// separate fields so that they cannot be shifted between each other
+		_, _ = mac.Write([]byte(field))
+		_, _ = mac.Write([]byte{0})
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
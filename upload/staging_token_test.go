@@ -0,0 +1,60 @@
+package upload_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/treeverse/lakefs/upload"
+)
+
+func TestIsValidPhysicalAddress(t *testing.T) {
+	cases := []struct {
+		address  string
+		expected bool
+	}{
+		{upload.NewPhysicalAddress(), true},
+		{"", false},
+		{"../../../etc/passwd", false},
+		{"0123456789abcdef0123456789abcdef/..", false},
+		{"0123456789abcdef0123456789abcdeg", false},
+		{"s3://bucket/0123456789abcdef0123", false},
+	}
+	for _, c := range cases {
+		if got := upload.IsValidPhysicalAddress(c.address); got != c.expected {
+			t.Errorf("IsValidPhysicalAddress(%q) = %t, expected %t", c.address, got, c.expected)
+		}
+	}
+}
+
+func TestVerifyStagingToken(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+	address := upload.NewPhysicalAddress()
+	token := upload.StagingToken(secret, "repo1", "master", "foo/bar", address, now.Add(time.Hour))
+
+	cases := []struct {
+		name        string
+		secret      []byte
+		path        string
+		address     string
+		token       string
+		now         time.Time
+		expectedErr error
+	}{
+		{"valid", secret, "foo/bar", address, token, now, nil},
+		{"other secret", []byte("other"), "foo/bar", address, token, now, upload.ErrInvalidStagingToken},
+		{"other path", secret, "foo/baz", address, token, now, upload.ErrInvalidStagingToken},
+		{"other address", secret, "foo/bar", upload.NewPhysicalAddress(), token, now, upload.ErrInvalidStagingToken},
+		{"no token", secret, "foo/bar", address, "", now, upload.ErrInvalidStagingToken},
+		{"expired", secret, "foo/bar", address, token, now.Add(2 * time.Hour), upload.ErrStagingTokenExpired},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := upload.VerifyStagingToken(c.secret, "repo1", "master", c.path, c.address, c.token, c.now)
+			if !errors.Is(err, c.expectedErr) {
+				t.Fatalf("expected error %v, got %v", c.expectedErr, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package upload
 import (
 	"encoding/hex"
 	"io"
+	"io/ioutil"
 
 	"github.com/google/uuid"
 	"github.com/treeverse/lakefs/block"
@@ -15,11 +16,17 @@ type Blob struct {
 	Size            int64
 }
 
+// NewPhysicalAddress returns a new unique address, relative to a storage namespace, to which
+// to write a blob.
+func NewPhysicalAddress() string {
+	uid := uuid.New()
+	return hex.EncodeToString(uid[:])
+}
+
 func WriteBlob(adapter block.Adapter, bucketName string, body io.Reader, contentLength int64, opts block.PutOpts) (*Blob, error) {
 	// handle the upload itself
 	hashReader := block.NewHashingReader(body, block.HashFunctionMD5, block.HashFunctionSHA256)
-	uid := uuid.New()
-	address := hex.EncodeToString(uid[:])
+	address := NewPhysicalAddress()
 	err := adapter.Put(block.ObjectPointer{
 		StorageNamespace: bucketName,
 		Identifier:       address,
@@ -36,3 +43,20 @@ func WriteBlob(adapter block.Adapter, bucketName string, body io.Reader, content
 		Size:            hashReader.CopiedSize,
 	}, nil
 }
+
+// ReadChecksum returns the hex MD5 checksum of the blob at obj, of size bytes, by reading it.
+// Use it when the underlying storage does not report the checksum.
+func ReadChecksum(adapter block.Adapter, obj block.ObjectPointer, size int64) (string, error) {
+	reader, err := adapter.Get(obj, size)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	hashReader := block.NewHashingReader(reader, block.HashFunctionMD5)
+	if _, err := io.Copy(ioutil.Discard, hashReader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hashReader.Md5.Sum(nil)), nil
+}
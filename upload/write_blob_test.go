@@ -0,0 +1,31 @@
+package upload_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/block/mem"
+	"github.com/treeverse/lakefs/upload"
+)
+
+func TestReadChecksum(t *testing.T) {
+	const data = "data to checksum"
+	adapter := mem.New()
+	obj := block.ObjectPointer{StorageNamespace: "mem://bucket/repo", Identifier: "abc"}
+	err := adapter.Put(obj, int64(len(data)), strings.NewReader(data), block.PutOpts{})
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	props, err := adapter.GetProperties(obj)
+	if err != nil {
+		t.Fatalf("GetProperties: %s", err)
+	}
+	checksum, err := upload.ReadChecksum(adapter, obj, int64(len(data)))
+	if err != nil {
+		t.Fatalf("ReadChecksum: %s", err)
+	}
+	if checksum != props.ETag {
+		t.Errorf("expected checksum %s, got %s", props.ETag, checksum)
+	}
+}